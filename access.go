@@ -0,0 +1,284 @@
+package telegrambot
+
+// Access policies of commands and handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrAccessDenied is returned when an update is denied by a Policy.
+var ErrAccessDenied = errors.New("access denied")
+
+// AdminRight is a right of chat administrators, named after its field in ChatMember.
+//
+// https://core.telegram.org/bots/api#chatmemberadministrator
+type AdminRight string
+
+// AdminRight strings
+const (
+	AdminRightManageChat       AdminRight = "can_manage_chat"
+	AdminRightPostMessages     AdminRight = "can_post_messages"
+	AdminRightEditMessages     AdminRight = "can_edit_messages"
+	AdminRightDeleteMessages   AdminRight = "can_delete_messages"
+	AdminRightManageVideoChats AdminRight = "can_manage_video_chats"
+	AdminRightRestrictMembers  AdminRight = "can_restrict_members"
+	AdminRightPromoteMembers   AdminRight = "can_promote_members"
+	AdminRightChangeInfo       AdminRight = "can_change_info"
+	AdminRightInviteUsers      AdminRight = "can_invite_users"
+	AdminRightPinMessages      AdminRight = "can_pin_messages"
+	AdminRightManageTopics     AdminRight = "can_manage_topics"
+
+	AdminRightPostStories          AdminRight = "can_post_stories"
+	AdminRightEditStories          AdminRight = "can_edit_stories"
+	AdminRightDeleteStories        AdminRight = "can_delete_stories"
+	AdminRightManageDirectMessages AdminRight = "can_manage_direct_messages"
+	AdminRightManageTags           AdminRight = "can_manage_tags"
+)
+
+// check if given chat member has the right (creators have all rights)
+func (r AdminRight) grantedTo(member ChatMember) bool {
+	switch member.Status {
+	case ChatMemberStatusCreator:
+		return true
+	case ChatMemberStatusAdministrator:
+	default:
+		return false
+	}
+
+	var right *bool
+	switch r {
+	case AdminRightManageChat:
+		right = member.CanManageChat
+	case AdminRightPostMessages:
+		right = member.CanPostMessages
+	case AdminRightEditMessages:
+		right = member.CanEditMessages
+	case AdminRightDeleteMessages:
+		right = member.CanDeleteMessages
+	case AdminRightManageVideoChats:
+		right = member.CanManageVideoChats
+	case AdminRightRestrictMembers:
+		right = member.CanRestrictMembers
+	case AdminRightPromoteMembers:
+		right = member.CanPromoteMembers
+	case AdminRightChangeInfo:
+		right = member.CanChangeInfo
+	case AdminRightInviteUsers:
+		right = member.CanInviteUsers
+	case AdminRightPinMessages:
+		right = member.CanPinMessages
+	case AdminRightManageTopics:
+		right = member.CanManageTopics
+	case AdminRightPostStories:
+		right = member.CanPostStories
+	case AdminRightEditStories:
+		right = member.CanEditStories
+	case AdminRightDeleteStories:
+		right = member.CanDeleteStories
+	case AdminRightManageDirectMessages:
+		right = member.CanManageDirectMessages
+	case AdminRightManageTags:
+		right = member.CanManageTags
+	}
+	return right != nil && *right
+}
+
+// rank of chat member status, for comparing roles
+func chatMemberStatusRank(status ChatMemberStatus) int {
+	switch status {
+	case ChatMemberStatusCreator:
+		return 3
+	case ChatMemberStatusAdministrator:
+		return 2
+	case ChatMemberStatusMember, ChatMemberStatusRestricted:
+		return 1
+	}
+	return 0
+}
+
+////////////////////////////////
+// Policy
+//
+
+// Policy is an access policy of commands and handlers.
+//
+// All conditions must be satisfied for an update to be allowed.
+//
+//	adminOnly := &Policy{
+//		MinimumStatus:  ChatMemberStatusAdministrator,
+//		RequiredRights: []AdminRight{AdminRightRestrictMembers},
+//		DenialText:     "Only administrators who can restrict members can do this.",
+//	}
+//	bot.AddCommand(Command{Name: "ban", Description: "ban a user", Policy: adminOnly, Handler: ban})
+//	router.Handle("ban:", adminOnly.Callback(confirmBan))
+type Policy struct {
+	AllowedUserIDs []int64 // allowed users (empty for all users)
+	AllowedChatIDs []int64 // allowed chats (empty for all chats)
+	PrivateOnly    bool    // allow only private chats
+
+	// minimum status of the user in the chat (empty for no requirement)
+	//
+	// administrators are looked up with `ChatAdministrators`, and others with `GetChatMember`
+	// only when this is a status of members (ChatMemberStatusMember or ChatMemberStatusRestricted)
+	MinimumStatus ChatMemberStatus
+
+	// rights of administrators which the user must have (creators have all rights)
+	//
+	// NOTE: users who are not administrators are denied without fetching their status with `GetChatMember`,
+	// so users who left or were banned are reported as members in the errors of denials.
+	RequiredRights []AdminRight
+
+	// text of the reply to denied updates (empty for no reply)
+	DenialText string
+
+	// function called for denied updates instead of replying with DenialText
+	OnDenied func(b *Bot, update Update, err error)
+}
+
+// chat of given update
+func chatOfUpdate(update Update) *Chat {
+	if message, _ := update.GetMessage(); message != nil {
+		return &message.Chat
+	} else if update.BusinessMessage != nil {
+		return &update.BusinessMessage.Chat
+	} else if update.HasCallbackQuery() && update.CallbackQuery.Message != nil {
+		return &update.CallbackQuery.Message.Chat
+	} else if update.HasMyChatMember() {
+		return &update.MyChatMember.Chat
+	} else if update.HasChatMember() {
+		return &update.ChatMember.Chat
+	} else if update.HasChatJoinRequest() {
+		return &update.ChatJoinRequest.Chat
+	}
+	return nil
+}
+
+// Check checks if given update is allowed by the policy.
+//
+// Returns an error wrapping ErrAccessDenied when denied,
+// or another error when the status of the user could not be fetched.
+func (p *Policy) Check(ctx context.Context, b *Bot, update Update) error {
+	from := update.GetFrom()
+	chat := chatOfUpdate(update)
+
+	if len(p.AllowedUserIDs) > 0 && (from == nil || !slices.Contains(p.AllowedUserIDs, from.ID)) {
+		return fmt.Errorf("%w: user is not allowed", ErrAccessDenied)
+	}
+	if len(p.AllowedChatIDs) > 0 && (chat == nil || !slices.Contains(p.AllowedChatIDs, chat.ID)) {
+		return fmt.Errorf("%w: chat is not allowed", ErrAccessDenied)
+	}
+	if p.PrivateOnly && (chat == nil || chat.Type != ChatTypePrivate) {
+		return fmt.Errorf("%w: only allowed in private chats", ErrAccessDenied)
+	}
+
+	if p.MinimumStatus == "" && len(p.RequiredRights) == 0 {
+		return nil
+	}
+	if from == nil || chat == nil || chat.Type == ChatTypePrivate {
+		return fmt.Errorf("%w: not a member of a group", ErrAccessDenied)
+	}
+
+	member, err := b.chatMemberForPolicy(ctx, chat.ID, from.ID, p.MinimumStatus)
+	if err != nil {
+		return err
+	}
+	if chatMemberStatusRank(member.Status) < chatMemberStatusRank(p.MinimumStatus) {
+		return fmt.Errorf("%w: status '%s' is lower than '%s'", ErrAccessDenied, member.Status, p.MinimumStatus)
+	}
+	for _, right := range p.RequiredRights {
+		if !right.grantedTo(member) {
+			return fmt.Errorf("%w: no right '%s'", ErrAccessDenied, right)
+		}
+	}
+	return nil
+}
+
+// fetch a chat member for checking a policy
+//
+// (administrators are looked up from the cache, so users not in it are considered members
+// unless the policy needs to tell members apart from others: as they are denied anyway
+// by policies which require administrators or their rights, users who left or were banned
+// are not fetched for them, and reported as members)
+func (b *Bot) chatMemberForPolicy(ctx context.Context, chatID, userID int64, minimumStatus ChatMemberStatus) (member ChatMember, err error) {
+	admins, err := b.ChatAdministrators(ctx, chatID)
+	if err != nil {
+		return member, err
+	}
+	for _, admin := range admins {
+		if admin.User.ID == userID {
+			return admin, nil
+		}
+	}
+
+	if chatMemberStatusRank(minimumStatus) != chatMemberStatusRank(ChatMemberStatusMember) {
+		return ChatMember{Status: ChatMemberStatusMember, User: User{ID: userID}}, nil
+	}
+
+	res, err := b.GetChatMember(ctx, chatID, userID)
+	if err != nil {
+		return member, err
+	}
+	if res.Result == nil {
+		return member, fmt.Errorf("no chat member in the response")
+	}
+	member = *res.Result
+	if member.Status == ChatMemberStatusRestricted && (member.IsMember == nil || !*member.IsMember) {
+		member.Status = ChatMemberStatusLeft
+	}
+	return member, nil
+}
+
+// handle a denied update
+func (p *Policy) deny(ctx context.Context, b *Bot, update Update, err error) {
+	if !errors.Is(err, ErrAccessDenied) {
+		b.error("failed to check access policy: %s", err)
+		return
+	}
+
+	if p.OnDenied != nil {
+		p.OnDenied(b, update, err)
+	} else if p.DenialText != "" {
+		if message, _ := update.GetMessage(); message != nil {
+			if _, err := replyToMessage(ctx, b, *message, p.DenialText); err != nil {
+				b.error("failed to send denial reply: %s", err)
+			}
+		}
+	}
+}
+
+// Command wraps a command handler, so that it is called only for allowed updates.
+func (p *Policy) Command(handler func(b *Bot, update Update, args string)) func(b *Bot, update Update, args string) {
+	return func(b *Bot, update Update, args string) {
+		ctx := context.Background()
+		if err := p.Check(ctx, b, update); err != nil {
+			p.deny(ctx, b, update, err)
+			return
+		}
+		handler(b, update, args)
+	}
+}
+
+// Callback wraps a callback handler, so that it is called only for allowed callback queries.
+//
+// Denied callback queries are answered with DenialText as an alert.
+func (p *Policy) Callback(handler CallbackHandler) CallbackHandler {
+	return func(ctx context.Context, c *CallbackContext) error {
+		if err := p.Check(ctx, c.Bot, c.Update); err != nil {
+			if !errors.Is(err, ErrAccessDenied) {
+				return err
+			}
+			if p.OnDenied != nil {
+				p.OnDenied(c.Bot, c.Update, err)
+				return nil
+			}
+			if p.DenialText == "" {
+				return c.Answer(ctx, OptionsAnswerCallbackQuery{})
+			}
+			return c.AnswerText(ctx, p.DenialText, true)
+		}
+		return handler(ctx, c)
+	}
+}
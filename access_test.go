@@ -0,0 +1,162 @@
+// access_test.go
+//
+// offline tests of access policies
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// a fake server which returns chat administrators (and `member` for `getChatMember`), and records sent messages
+func newTestAdminsServer(admins, member string) (server *httptest.Server, calls func(method string) []string) {
+	var mu sync.Mutex
+	recorded := map[string][]string{}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		mu.Lock()
+		recorded[method] = append(recorded[method], r.FormValue("text"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch method {
+		case "getChatAdministrators":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":%s}`, admins)
+		case "getChatMember":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":%s}`, member)
+		case "sendMessage":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":12345,"type":"private"},"text":%q}}`, r.FormValue("text"))
+		default:
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		}
+	}))
+	return server, func(method string) []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return recorded[method]
+	}
+}
+
+// policies should check allowlists, chat types, statuses and rights of users.
+func TestPolicy(t *testing.T) {
+	slog.Info("testing access policies...")
+
+	user := telegrambottest.User()
+	admin := User{ID: 10002, FirstName: "Admin"}
+	server, calls := newTestAdminsServer(fmt.Sprintf(`[
+		{"status":"creator","user":{"id":999,"is_bot":false,"first_name":"Owner"}},
+		{"status":"administrator","user":{"id":%d,"is_bot":false,"first_name":"Admin"},"can_restrict_members":true},
+		{"status":"administrator","user":{"id":%d,"is_bot":false,"first_name":"Test"},"can_restrict_members":false}
+	]`, admin.ID, user.ID), `{}`)
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	group := telegrambottest.GroupChat()
+	inGroup := func(from User) Update {
+		return telegrambottest.NewUpdate().Message().From(from).InChat(group).Text("/ban").Build()
+	}
+	inPrivate := telegrambottest.NewUpdate().Message().From(user).InChat(telegrambottest.PrivateChat(user)).Text("/ban").Build()
+
+	for _, tc := range []struct {
+		name    string
+		policy  Policy
+		update  Update
+		allowed bool
+	}{
+		{"allowlisted user", Policy{AllowedUserIDs: []int64{user.ID}}, inPrivate, true},
+		{"not allowlisted user", Policy{AllowedUserIDs: []int64{admin.ID}}, inPrivate, false},
+		{"allowlisted chat", Policy{AllowedChatIDs: []int64{group.ID}}, inGroup(user), true},
+		{"private only in group", Policy{PrivateOnly: true}, inGroup(user), false},
+		{"administrator", Policy{MinimumStatus: ChatMemberStatusAdministrator}, inGroup(user), true},
+		{"creator required", Policy{MinimumStatus: ChatMemberStatusCreator}, inGroup(user), false},
+		{"administrator in private", Policy{MinimumStatus: ChatMemberStatusAdministrator}, inPrivate, false},
+		{"right granted", Policy{RequiredRights: []AdminRight{AdminRightRestrictMembers}}, inGroup(admin), true},
+		{"right not granted", Policy{RequiredRights: []AdminRight{AdminRightRestrictMembers}}, inGroup(user), false},
+		{"not an administrator", Policy{MinimumStatus: ChatMemberStatusAdministrator}, inGroup(User{ID: 1}), false},
+	} {
+		err := tc.policy.Check(context.TODO(), client, tc.update)
+		if tc.allowed && err != nil {
+			t.Errorf("expected '%s' to be allowed, got: %s", tc.name, err)
+		} else if !tc.allowed && !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("expected '%s' to be denied, got: %v", tc.name, err)
+		}
+	}
+
+	// administrators should be fetched once, and cached
+	if fetched := len(calls("getChatAdministrators")); fetched != 1 {
+		t.Errorf("expected administrators to be fetched once, got %d times", fetched)
+	}
+	client.InvalidateChatAdministrators(group.ID)
+	if _, err := client.ChatAdministrators(context.TODO(), group.ID); err != nil {
+		t.Errorf("failed to fetch administrators: %s", err)
+	} else if fetched := len(calls("getChatAdministrators")); fetched != 2 {
+		t.Errorf("expected administrators to be fetched again, got %d times", fetched)
+	}
+
+	// denied commands should not be handled, and be replied with the denial text
+	handled := false
+	policy := &Policy{
+		MinimumStatus: ChatMemberStatusCreator,
+		DenialText:    "owner only",
+	}
+	client.AddCommand(Command{
+		Name:    "ban",
+		Policy:  policy,
+		Handler: func(b *Bot, update Update, args string) { handled = true },
+	})
+	client.CommandHandler("/ban")(client, inGroup(user), "")
+	if handled {
+		t.Errorf("denied command should not be handled")
+	}
+	if sent := calls("sendMessage"); len(sent) != 1 || sent[0] != "owner only" {
+		t.Errorf("unexpected denial replies: %v", sent)
+	}
+	client.CommandHandler("/ban")(client, inGroup(User{ID: 999}), "")
+	if !handled {
+		t.Errorf("allowed command should be handled")
+	}
+}
+
+// rights should be looked up from their fields of administrators.
+func TestAdminRightGrantedTo(t *testing.T) {
+	slog.Info("testing rights of administrators...")
+
+	admin := ChatMember{
+		Status:                  ChatMemberStatusAdministrator,
+		CanPostStories:          new(true),
+		CanEditStories:          new(false),
+		CanManageDirectMessages: new(true),
+		CanManageTags:           new(true),
+	}
+	for right, granted := range map[AdminRight]bool{
+		AdminRightPostStories:          true,
+		AdminRightEditStories:          false,
+		AdminRightDeleteStories:        false,
+		AdminRightManageDirectMessages: true,
+		AdminRightManageTags:           true,
+	} {
+		if right.GrantedTo(admin) != granted {
+			t.Errorf("expected %s granted = %t", right, granted)
+		}
+		if !right.GrantedTo(ChatMember{Status: ChatMemberStatusCreator}) {
+			t.Errorf("expected %s granted to creators", right)
+		}
+	}
+}
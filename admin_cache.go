@@ -0,0 +1,343 @@
+package telegrambot
+
+// Cache of chat administrators and the bot's own rights, kept fresh from chat member updates
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAdminCacheTTL = 5 * time.Minute
+)
+
+// ErrBotLacksRight is returned when the bot does not have a right required for an action in a chat.
+var ErrBotLacksRight = errors.New("bot lacks a required right")
+
+// adminCacheEntry is a cached list of chat administrators
+type adminCacheEntry struct {
+	admins    []ChatMember
+	expiresAt time.Time
+}
+
+// adminCacheMember is a cached chat member
+type adminCacheMember struct {
+	member    ChatMember
+	expiresAt time.Time
+}
+
+// adminCache is a cache of chat administrators and the bot's own memberships with a TTL
+type adminCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[int64]adminCacheEntry  // administrators by chat ids
+	bot     map[int64]adminCacheMember // the bot's own memberships by chat ids
+	botID   int64
+	botName string // username of the bot
+}
+
+// expiration time of entries cached now
+func (c *adminCache) expiration(now time.Time) time.Time {
+	return now.Add(cmp.Or(c.ttl, defaultAdminCacheTTL))
+}
+
+// update cached administrators and memberships with given chat member update
+func (c *adminCache) update(updated ChatMemberUpdated, isMine bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	chatID := updated.Chat.ID
+	member := updated.NewChatMember
+	isAdmin := member.Status == ChatMemberStatusCreator || member.Status == ChatMemberStatusAdministrator
+
+	if isMine {
+		if c.bot == nil {
+			c.bot = map[int64]adminCacheMember{}
+		}
+		c.bot[chatID] = adminCacheMember{
+			member:    member,
+			expiresAt: c.expiration(time.Now()),
+		}
+
+		// administrators are not visible to the bot anymore
+		if member.Status == ChatMemberStatusLeft || member.Status == ChatMemberStatusBanned {
+			delete(c.entries, chatID)
+			return
+		}
+	}
+
+	entry, exists := c.entries[chatID]
+	if !exists {
+		return // (will be fetched lazily)
+	}
+	admins := slices.DeleteFunc(slices.Clone(entry.admins), func(admin ChatMember) bool {
+		return admin.User.ID == member.User.ID
+	})
+	if isAdmin {
+		admins = append(admins, member)
+	}
+	c.entries[chatID] = adminCacheEntry{
+		admins:    admins,
+		expiresAt: entry.expiresAt,
+	}
+}
+
+// keep cached administrators fresh with given update, if it is a chat member update
+func (b *Bot) updateAdminCache(update Update) {
+	if update.HasMyChatMember() {
+		b.admins.update(*update.MyChatMember, true)
+	} else if update.HasChatMember() {
+		b.admins.update(*update.ChatMember, false)
+	}
+}
+
+// SetAdminCacheTTL sets the duration of caching chat administrators and the bot's own rights (default: 5 minutes).
+//
+// Cached entries are also updated with `my_chat_member` and `chat_member` updates,
+// so the TTL is only a fallback for updates which were not received (eg. `chat_member` is not in allowed updates).
+func (b *Bot) SetAdminCacheTTL(ttl time.Duration) {
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	b.admins.ttl = ttl
+}
+
+// ChatAdministrators returns administrators of given chat, fetched with `GetChatAdministrators`
+// and cached for the duration set with `SetAdminCacheTTL`.
+func (b *Bot) ChatAdministrators(ctx context.Context, chatID int64) (admins []ChatMember, err error) {
+	now := time.Now()
+
+	b.admins.mu.Lock()
+	entry, exists := b.admins.entries[chatID]
+	b.admins.mu.Unlock()
+	if exists && now.Before(entry.expiresAt) {
+		return entry.admins, nil
+	}
+
+	res, err := b.GetChatAdministrators(ctx, chatID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.Result != nil {
+		admins = *res.Result
+	}
+
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	if b.admins.entries == nil {
+		b.admins.entries = map[int64]adminCacheEntry{}
+	}
+	b.admins.entries[chatID] = adminCacheEntry{
+		admins:    admins,
+		expiresAt: b.admins.expiration(now),
+	}
+	return admins, nil
+}
+
+// AdministratorRights returns rights of given user in the chat, or nil if the user is not an administrator.
+//
+// Creators have all rights.
+func (b *Bot) AdministratorRights(ctx context.Context, chatID, userID int64) (rights *ChatAdministratorRights, err error) {
+	admins, err := b.ChatAdministrators(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	for _, admin := range admins {
+		if admin.User.ID == userID {
+			return new(administratorRightsOf(admin)), nil
+		}
+	}
+	return nil, nil
+}
+
+// InvalidateChatAdministrators removes cached administrators and the bot's own membership of given chat.
+func (b *Bot) InvalidateChatAdministrators(chatID int64) {
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	delete(b.admins.entries, chatID)
+	delete(b.admins.bot, chatID)
+}
+
+// id of the bot, parsed from its token or fetched with `GetMe`
+func (b *Bot) botUserID(ctx context.Context) (id int64, err error) {
+	b.admins.mu.Lock()
+	id = b.admins.botID
+	b.admins.mu.Unlock()
+	if id != 0 {
+		return id, nil
+	}
+
+	if prefix, _, found := strings.Cut(b.token, ":"); found {
+		id, _ = strconv.ParseInt(prefix, 10, 64)
+	}
+	if id == 0 {
+		res, err := b.GetMe(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if res.Result == nil {
+			return 0, fmt.Errorf("no user in the response")
+		}
+		id = res.Result.ID
+	}
+
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	b.admins.botID = id
+	return id, nil
+}
+
+// username of the bot, fetched with `GetMe`
+func (b *Bot) botUsername(ctx context.Context) (username string, err error) {
+	b.admins.mu.Lock()
+	username = b.admins.botName
+	b.admins.mu.Unlock()
+	if username != "" {
+		return username, nil
+	}
+
+	res, err := b.GetMe(ctx)
+	if err != nil {
+		return "", err
+	}
+	if res.Result == nil || res.Result.Username == nil {
+		return "", fmt.Errorf("no username in the response")
+	}
+
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	b.admins.botID = res.Result.ID
+	b.admins.botName = *res.Result.Username
+	return b.admins.botName, nil
+}
+
+// BotChatMember returns the bot's own membership in given chat, fetched with `GetChatMember`
+// and cached for the duration set with `SetAdminCacheTTL`.
+func (b *Bot) BotChatMember(ctx context.Context, chatID int64) (member ChatMember, err error) {
+	now := time.Now()
+
+	b.admins.mu.Lock()
+	cached, exists := b.admins.bot[chatID]
+	b.admins.mu.Unlock()
+	if exists && now.Before(cached.expiresAt) {
+		return cached.member, nil
+	}
+
+	botID, err := b.botUserID(ctx)
+	if err != nil {
+		return member, err
+	}
+	res, err := b.GetChatMember(ctx, chatID, botID)
+	if err != nil {
+		return member, err
+	}
+	if res.Result == nil {
+		return member, fmt.Errorf("no chat member in the response")
+	}
+	member = *res.Result
+
+	b.admins.mu.Lock()
+	defer b.admins.mu.Unlock()
+
+	if b.admins.bot == nil {
+		b.admins.bot = map[int64]adminCacheMember{}
+	}
+	b.admins.bot[chatID] = adminCacheMember{
+		member:    member,
+		expiresAt: b.admins.expiration(now),
+	}
+	return member, nil
+}
+
+// RequireBotRights checks if the bot has all given rights in the chat,
+// so that handlers can fail fast before calling methods which require them.
+//
+// Returns an error wrapping ErrBotLacksRight if any right is missing.
+func (b *Bot) RequireBotRights(ctx context.Context, chatID int64, rights ...AdminRight) error {
+	member, err := b.BotChatMember(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	for _, right := range rights {
+		if !right.grantedTo(member) {
+			return fmt.Errorf("%w: '%s' in chat %d (status: %s)", ErrBotLacksRight, right, chatID, member.Status)
+		}
+	}
+	return nil
+}
+
+// CanBotDeleteMessages checks if the bot can delete messages of others in given chat.
+func (b *Bot) CanBotDeleteMessages(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightDeleteMessages)
+}
+
+// CanBotRestrictMembers checks if the bot can restrict, ban, or unban members in given chat.
+func (b *Bot) CanBotRestrictMembers(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightRestrictMembers)
+}
+
+// CanBotPromoteMembers checks if the bot can promote members in given chat.
+func (b *Bot) CanBotPromoteMembers(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightPromoteMembers)
+}
+
+// CanBotPinMessages checks if the bot can pin messages in given chat.
+func (b *Bot) CanBotPinMessages(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightPinMessages)
+}
+
+// CanBotInviteUsers checks if the bot can invite users (or manage invite links) in given chat.
+func (b *Bot) CanBotInviteUsers(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightInviteUsers)
+}
+
+// CanBotChangeInfo checks if the bot can change the title, photo, and other settings of given chat.
+func (b *Bot) CanBotChangeInfo(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightChangeInfo)
+}
+
+// CanBotManageTopics checks if the bot can manage topics of given forum chat.
+func (b *Bot) CanBotManageTopics(ctx context.Context, chatID int64) error {
+	return b.RequireBotRights(ctx, chatID, AdminRightManageTopics)
+}
+
+// rights of given administrator (creators have all rights)
+func administratorRightsOf(member ChatMember) ChatAdministratorRights {
+	granted := func(right *bool) bool {
+		return right != nil && *right
+	}
+
+	if member.Status == ChatMemberStatusCreator {
+		return allAdministratorRights(granted(member.IsAnonymous))
+	}
+	return ChatAdministratorRights{
+		IsAnonymous:             granted(member.IsAnonymous),
+		CanManageChat:           granted(member.CanManageChat),
+		CanDeleteMessages:       granted(member.CanDeleteMessages),
+		CanManageVideoChats:     granted(member.CanManageVideoChats),
+		CanRestrictMembers:      granted(member.CanRestrictMembers),
+		CanPromoteMembers:       granted(member.CanPromoteMembers),
+		CanChangeInfo:           granted(member.CanChangeInfo),
+		CanInviteUsers:          granted(member.CanInviteUsers),
+		CanPostStories:          granted(member.CanPostStories),
+		CanEditStories:          granted(member.CanEditStories),
+		CanDeleteStories:        granted(member.CanDeleteStories),
+		CanPostMessages:         member.CanPostMessages,
+		CanEditMessages:         member.CanEditMessages,
+		CanPinMessages:          member.CanPinMessages,
+		CanManageTopics:         member.CanManageTopics,
+		CanManageDirectMessages: member.CanManageDirectMessages,
+		CanManageTags:           member.CanManageTags,
+	}
+}
@@ -0,0 +1,127 @@
+// admin_cache_test.go
+//
+// offline tests of caching chat administrators and the bot's own rights
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// cached administrators should expire after the TTL.
+func TestAdminCacheTTL(t *testing.T) {
+	slog.Info("testing expiration of cached administrators...")
+
+	server, calls := newTestAdminsServer(`[]`, `{}`)
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+	client.SetAdminCacheTTL(10 * time.Millisecond)
+
+	for range 2 {
+		if _, err := client.ChatAdministrators(context.TODO(), 1); err != nil {
+			t.Fatalf("failed to fetch administrators: %s", err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := client.ChatAdministrators(context.TODO(), 1); err != nil {
+		t.Fatalf("failed to fetch administrators: %s", err)
+	}
+
+	if fetched := len(calls("getChatAdministrators")); fetched != 2 {
+		t.Errorf("expected administrators to be fetched twice, got %d times", fetched)
+	}
+}
+
+// cached administrators should be updated with chat member updates, without fetching them again.
+func TestAdminCacheUpdates(t *testing.T) {
+	slog.Info("testing updates of cached administrators...")
+
+	user := telegrambottest.User()
+	group := telegrambottest.GroupChat()
+	server, calls := newTestAdminsServer(`[{"status":"creator","user":{"id":999,"is_bot":false,"first_name":"Owner"}}]`, `{}`)
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+	client.SetChatMemberUpdateHandler(func(b *Bot, update Update, memberUpdated ChatMemberUpdated, isMine bool) {})
+
+	if rights, err := client.AdministratorRights(context.TODO(), group.ID, user.ID); err != nil || rights != nil {
+		t.Errorf("expected no rights before promotion, got: %+v (%v)", rights, err)
+	}
+
+	// promoted
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().ChatMember().InChat(group).Change(
+		ChatMember{Status: ChatMemberStatusMember, User: user},
+		ChatMember{Status: ChatMemberStatusAdministrator, User: user, CanPinMessages: new(true)},
+	).Build()})
+	client.WaitForHandlers()
+
+	if rights, err := client.AdministratorRights(context.TODO(), group.ID, user.ID); err != nil || rights == nil || rights.CanPinMessages == nil || !*rights.CanPinMessages || rights.CanDeleteMessages {
+		t.Errorf("unexpected rights after promotion: %+v (%v)", rights, err)
+	}
+
+	// demoted
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().ChatMember().InChat(group).Change(
+		ChatMember{Status: ChatMemberStatusAdministrator, User: user},
+		ChatMember{Status: ChatMemberStatusMember, User: user},
+	).Build()})
+	client.WaitForHandlers()
+
+	if rights, err := client.AdministratorRights(context.TODO(), group.ID, user.ID); err != nil || rights != nil {
+		t.Errorf("expected no rights after demotion, got: %+v (%v)", rights, err)
+	}
+	if owner, err := client.AdministratorRights(context.TODO(), group.ID, 999); err != nil || owner == nil || !owner.CanRestrictMembers {
+		t.Errorf("creator should have all rights, got: %+v (%v)", owner, err)
+	}
+
+	if fetched := len(calls("getChatAdministrators")); fetched != 1 {
+		t.Errorf("expected administrators to be fetched once, got %d times", fetched)
+	}
+}
+
+// rights of the bot should be fetched lazily, and updated with `my_chat_member` updates.
+func TestBotRights(t *testing.T) {
+	slog.Info("testing rights of the bot...")
+
+	group := telegrambottest.GroupChat()
+	bot := User{ID: 1234567890, IsBot: true, FirstName: "Bot"}
+	server, calls := newTestAdminsServer(`[]`, `{"status":"administrator","user":{"id":1234567890,"is_bot":true,"first_name":"Bot"},"can_delete_messages":true,"can_restrict_members":false}`)
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+	client.SetChatMemberUpdateHandler(func(b *Bot, update Update, memberUpdated ChatMemberUpdated, isMine bool) {})
+
+	if err := client.CanBotDeleteMessages(context.TODO(), group.ID); err != nil {
+		t.Errorf("bot should be able to delete messages: %s", err)
+	}
+	if err := client.CanBotRestrictMembers(context.TODO(), group.ID); !errors.Is(err, ErrBotLacksRight) {
+		t.Errorf("bot should not be able to restrict members, got: %v", err)
+	}
+	if fetched := calls("getChatMember"); len(fetched) != 1 {
+		t.Errorf("expected the bot's membership to be fetched once, got %d times", len(fetched))
+	}
+
+	// demoted
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().MyChatMember().InChat(group).Change(
+		ChatMember{Status: ChatMemberStatusAdministrator, User: bot},
+		ChatMember{Status: ChatMemberStatusMember, User: bot},
+	).Build()})
+	client.WaitForHandlers()
+
+	if err := client.CanBotDeleteMessages(context.TODO(), group.ID); !errors.Is(err, ErrBotLacksRight) {
+		t.Errorf("bot should not be able to delete messages after demotion, got: %v", err)
+	}
+	if fetched := calls("getChatMember"); len(fetched) != 1 {
+		t.Errorf("expected the bot's membership not to be fetched again, got %d times", len(fetched))
+	}
+}
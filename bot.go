@@ -17,12 +17,15 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	apiBaseURL  = "https://api.telegram.org/bot"
-	fileBaseURL = "https://api.telegram.org/file/bot"
+	defaultBaseURL = "https://api.telegram.org"
+
+	apiPathPrefix  = "/bot"
+	filePathPrefix = "/file/bot"
 
 	webhookPath = "/telegram/bot/webhook"
 )
@@ -50,11 +53,19 @@ type Bot struct {
 	webhookPort int    // webhook port number
 	webhookURL  string // webhook url
 
+	apiBaseURL  string // base url of API requests
+	fileBaseURL string // base url of file downloads
+
 	httpClient *http.Client // http client
 
+	cassette                *os.File          // cassette file for recording HTTP interactions
+	transportBeforeCassette http.RoundTripper // original transport of http client, replaced while recording/replaying
+
 	quitLoop  chan struct{}    // quit channel of polling loop
 	workerSem chan struct{} // semaphore for limiting concurrent handler goroutines
 
+	handlerGoroutines sync.Map // ids of goroutines which are running handlers (holding slots of `workerSem`)
+
 	// manual update handler - must be set
 	updateHandler func(b *Bot, update Update, err error)
 
@@ -72,10 +83,34 @@ type Bot struct {
 	chatMemberUpdateHandler   func(b *Bot, update Update, memberUpdated ChatMemberUpdated, isMine bool)
 	chatJoinRequestHandler    func(b *Bot, update Update, chatJoinRequest ChatJoinRequest)
 
+	// goroutines waiting for updates (matching updates are delivered to them before any handler)
+	waiters waiterRegistry
+
+	// conversations (updates in active conversations are handled before commands)
+	conversations []*Conversation
+
+	// router of callback queries (if no route matches, update will be passed to `callbackQueryHandler`)
+	callbackRouter *CallbackRouter
+
+	// cached administrators of chats and the bot's own rights
+	admins adminCache
+
+	// whether updates with unknown types of flat unions are treated as errors
+	strictUnions bool
+
+	// handler function for unknown fields in responses and webhook updates (strict decoding)
+	unknownFieldHandler func(b *Bot, field UnknownField)
+
+	// whether the original json of updates and messages is kept
+	keepRawJSON bool
+
 	// command handlers (if not set, update will be passed to `updateHandler`)
 	commandHandlers          map[string](func(b *Bot, update Update, args string)) // command handler functions
 	noMatchingCommandHandler func(b *Bot, update Update, cmd, args string)         // handler function for no matching command
 
+	// declared commands (for synchronizing command lists and generating help texts)
+	commands []Command
+
 	Verbose  bool // print verbose log messages or not
 	DumpHTTP bool // dump HTTP request and response or not
 }
@@ -86,6 +121,9 @@ func NewClient(token string) *Bot {
 		token:       token,
 		tokenHashed: fmt.Sprintf("%x", md5.Sum([]byte(token))),
 
+		apiBaseURL:  defaultBaseURL + apiPathPrefix,
+		fileBaseURL: defaultBaseURL + filePathPrefix,
+
 		httpClient: nil,
 
 		quitLoop:  make(chan struct{}, 1),
@@ -136,6 +174,17 @@ func GenCertAndKey(
 	return nil
 }
 
+// SetBaseURL sets the base url of Bot API server.
+//
+// Default is "https://api.telegram.org". Set this for using a local Bot API server
+// (https://github.com/tdlib/telegram-bot-api), or a fake one in tests.
+func (b *Bot) SetBaseURL(baseURL string) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	b.apiBaseURL = baseURL + apiPathPrefix
+	b.fileBaseURL = baseURL + filePathPrefix
+}
+
 // SetMaxWorkers sets the maximum number of concurrent handler goroutines.
 //
 // Default is 100. When all worker slots are occupied, new updates will block
@@ -148,7 +197,13 @@ func (b *Bot) SetMaxWorkers(n int) {
 func (b *Bot) runHandler(fn func()) {
 	b.workerSem <- struct{}{}
 	go func() {
-		defer func() { <-b.workerSem }()
+		id := currentGoroutineID()
+		b.handlerGoroutines.Store(id, struct{}{})
+		defer func() {
+			b.handlerGoroutines.Delete(id)
+			<-b.workerSem
+		}()
+
 		fn()
 	}()
 }
@@ -303,6 +358,18 @@ func (b *Bot) StartWebhookServerAndWait(
 	}
 }
 
+// WebhookHandler returns a http.Handler which handles incoming webhooks,
+// for serving them with your own http server (or feeding them in tests).
+//
+// Incoming webhooks will be received through webhookHandler function.
+func (b *Bot) WebhookHandler(
+	webhookHandler func(b *Bot, webhook Update, err error),
+) http.Handler {
+	b.updateHandler = webhookHandler
+
+	return http.HandlerFunc(b.handleWebhook)
+}
+
 // StartPollingUpdates retrieves updates from API server constantly, synchronously.
 //
 // `optionalParams` can be:
@@ -355,40 +422,7 @@ loop:
 					}
 				}
 
-				if b.mediaGroupHandler != nil {
-					// group updates by media group id,
-					groups := groupUpdatesByMediaGroupID(*updates.Result)
-
-					// handle updates by group id
-					for groupID, groupedUpdates := range groups {
-						if groupID == "" { // NOTE: no group id
-							for _, update := range groupedUpdates {
-								// if there is a matching command, handle it as a command,
-								if !handleUpdateAsCommand(b, update) {
-									// if it was not handled as a command, handle it by type:
-									if !handleUpdateByType(b, update) {
-										// otherwise, handle it manually
-										b.runHandler(func() { b.updateHandler(b, update, nil) })
-									}
-								}
-							}
-						} else { // with group id
-							b.runHandler(func() { b.mediaGroupHandler(b, groupedUpdates, groupID) })
-						}
-					}
-				} else {
-					// ordinary handling of updates
-					for _, update := range *updates.Result {
-						// if there is a matching command, handle it as a command,
-						if !handleUpdateAsCommand(b, update) {
-							// if it was not handled as a command, handle it by type:
-							if !handleUpdateByType(b, update) {
-								// otherwise, handle it manually
-								b.runHandler(func() { b.updateHandler(b, update, nil) })
-							}
-						}
-					}
-				}
+				b.dispatchUpdates(*updates.Result)
 			} else {
 				b.runHandler(func() { b.updateHandler(b, Update{}, fmt.Errorf("%s", *updates.Description)) })
 			}
@@ -402,6 +436,63 @@ loop:
 	b.verbose("stopped polling updates")
 }
 
+// dispatch updates to handlers, grouping them by media group id if needed
+func (b *Bot) dispatchUpdates(updates []Update) {
+	if b.mediaGroupHandler != nil {
+		// group updates by media group id,
+		groups := groupUpdatesByMediaGroupID(updates)
+
+		// handle updates by group id
+		for groupID, groupedUpdates := range groups {
+			if groupID == "" { // NOTE: no group id
+				for _, update := range groupedUpdates {
+					b.dispatchUpdate(update)
+				}
+			} else { // with group id
+				b.runHandler(func() { b.mediaGroupHandler(b, groupedUpdates, groupID) })
+			}
+		}
+	} else {
+		// ordinary handling of updates
+		for _, update := range updates {
+			b.dispatchUpdate(update)
+		}
+	}
+}
+
+// dispatch an update to a matching handler
+func (b *Bot) dispatchUpdate(update Update) {
+	// in strict mode, pass updates with unknown types of unions to the update handler as errors,
+	if b.strictUnions {
+		if err := CheckUnionTypes(update); err != nil {
+			b.runHandler(func() { b.updateHandler(b, update, err) })
+			return
+		}
+	}
+
+	// keep cached administrators fresh,
+	b.updateAdminCache(update)
+
+	// if a goroutine is waiting for it, deliver it to the goroutine,
+	if handleUpdateAsWaited(b, update) {
+		return
+	}
+
+	// if it is a part of a conversation, handle it in the conversation,
+	if handleUpdateAsConversation(b, update) {
+		return
+	}
+
+	// if there is a matching command, handle it as a command,
+	if !handleUpdateAsCommand(b, update) {
+		// if it was not handled as a command, handle it by type:
+		if !handleUpdateByType(b, update) {
+			// otherwise, handle it manually
+			b.runHandler(func() { b.updateHandler(b, update, nil) })
+		}
+	}
+}
+
 // group updates by their media group id
 func groupUpdatesByMediaGroupID(updates []Update) (groups map[string][]Update) {
 	groups = map[string][]Update{}
@@ -503,6 +594,10 @@ func handleUpdateByType(b *Bot, update Update) bool {
 	} else if b.chosenInlineResultHandler != nil && update.HasChosenInlineResult() {
 		b.runHandler(func() { b.chosenInlineResultHandler(b, update, *update.ChosenInlineResult) })
 
+		return true
+	} else if route, found := b.routeCallbackQuery(update); found {
+		b.runHandler(func() { b.callbackRouter.handle(b, update, route) })
+
 		return true
 	} else if b.callbackQueryHandler != nil && update.HasCallbackQuery() {
 		b.runHandler(func() { b.callbackQueryHandler(b, update, *update.CallbackQuery) })
@@ -0,0 +1,59 @@
+// bot_handlers_test.go
+//
+// offline tests of routing updates to handlers
+
+package telegrambot_test
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// commands in built updates should be routed to matching command handlers.
+func TestHandleUpdateAsCommand(t *testing.T) {
+	slog.Info("testing routing of commands...")
+
+	var mu sync.Mutex
+	handled := map[string]string{}
+
+	client := NewClient(TestToken)
+	client.AddCommandHandler("/start", func(b *Bot, update Update, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled["/start"] = args
+	})
+	client.SetNoMatchingCommandHandler(func(b *Bot, update Update, cmd, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled["unknown"] = cmd
+	})
+
+	for _, tc := range []struct {
+		update  Update
+		handled bool
+	}{
+		{telegrambottest.NewUpdate().Message().Text("/start foo bar").Build(), true},
+		{telegrambottest.NewUpdate().EditedMessage().Text("/unknown").Build(), true},
+		{telegrambottest.NewUpdate().Message().Text("not a command").Build(), false},
+		{telegrambottest.NewUpdate().Message().Caption("/start").Photo("photo").Build(), false},
+		{telegrambottest.NewUpdate().CallbackQuery().Data("/start").Build(), false},
+	} {
+		if handled := HandleUpdateAsCommand(client, tc.update); handled != tc.handled {
+			t.Errorf("expected handled = %t for update: %+v", tc.handled, tc.update)
+		}
+	}
+	client.WaitForHandlers()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if handled["/start"] != "foo bar" {
+		t.Errorf("expected args 'foo bar', got %q", handled["/start"])
+	}
+	if handled["unknown"] != "/unknown" {
+		t.Errorf("expected command '/unknown', got %q", handled["unknown"])
+	}
+}
@@ -0,0 +1,31 @@
+package telegrambot
+
+// Calling Bot API methods which are not supported by this library yet
+
+import (
+	"context"
+)
+
+// Call calls a Bot API method with given params, and decodes its result as T.
+//
+// It is for methods which are newly added to the Bot API and not supported by this library yet.
+// Params are encoded the same way as the built-in methods: in multipart form data if any of them is a file
+// (`InputFile`, `*os.File`, or `[]byte`), and in urlencoded form otherwise (non-string values are encoded in json).
+// Errors are redacted and classified (eg. ErrChatNotFound) like the built-in methods.
+//
+// NOTE: There is no middleware or retry in this library, so callers need to retry on their own (eg. after `Parameters.RetryAfter` of the response).
+//
+//	res, err := Call[bool](ctx, bot, "setSomethingNew", map[string]any{
+//		"chat_id": chatID,
+//		"photo":   NewInputFileFromBytes(bytes),
+//	})
+func Call[T any](ctx context.Context, b *Bot, method string, params map[string]any) (result APIResponse[T], err error) {
+	return requestGeneric[T](ctx, b, method, params)
+}
+
+// CallMessageOrBool calls a Bot API method whose result is a Message or a bool (eg. editing inline messages).
+//
+// See `Call` for the encoding of params.
+func (b *Bot) CallMessageOrBool(ctx context.Context, method string, params map[string]any) (result APIResponseMessageOrBool, err error) {
+	return b.requestMessageOrBool(ctx, method, params)
+}
@@ -0,0 +1,70 @@
+// call_test.go
+//
+// offline tests of calling unsupported methods
+
+package telegrambot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// unsupported methods should be called with the same encoding and error handling as the built-in ones.
+func TestCall(t *testing.T) {
+	slog.Info("testing calling unsupported methods...")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch method {
+		case "sendSomethingNew":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				_, _ = fmt.Fprintf(w, `{"ok":false,"description":"Bad Request: not multipart (%s)"}`, err)
+				return
+			}
+			file, _, err := r.FormFile("photo")
+			if err != nil {
+				_, _ = fmt.Fprint(w, `{"ok":false,"description":"Bad Request: no photo"}`)
+				return
+			}
+			bytes, _ := io.ReadAll(file)
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":%s,"type":"private"},"caption":%q}}`, r.FormValue("chat_id"), string(bytes))
+		case "editSomethingNew":
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	res, err := Call[Message](context.TODO(), client, "sendSomethingNew", map[string]any{
+		"chat_id": int64(12345),
+		"photo":   NewInputFileFromBytes([]byte("photo bytes")),
+	})
+	if err != nil {
+		t.Errorf("failed to call method: %s", err)
+	} else if res.Result.Chat.ID != 12345 || res.Result.Caption == nil || *res.Result.Caption != "photo bytes" {
+		t.Errorf("unexpected result: %+v", res.Result)
+	}
+
+	if res, err := client.CallMessageOrBool(context.TODO(), "editSomethingNew", map[string]any{"text": "edited"}); err != nil {
+		t.Errorf("failed to call method: %s", err)
+	} else if res.ResultBool == nil || !*res.ResultBool {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	if _, err := Call[bool](context.TODO(), client, "doSomethingNew", nil); !errors.As(err, &ErrChatNotFound{}) {
+		t.Errorf("expected a classified error, got: %v", err)
+	}
+}
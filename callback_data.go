@@ -0,0 +1,284 @@
+package telegrambot
+
+// Encoding and decoding of typed callback data
+//
+// https://core.telegram.org/bots/api#inlinekeyboardbutton
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	callbackDataSeparator = ":"
+	callbackRefMarker     = "@" // marks a body which is a key of a CallbackStore
+	callbackSignatureLen  = 8   // length of a truncated HMAC signature in bytes
+	callbackRefKeyLen     = 9   // length of a reference key in bytes
+)
+
+var (
+	// ErrInvalidCallbackData is returned when a callback data cannot be decoded.
+	ErrInvalidCallbackData = errors.New("invalid callback data")
+
+	// ErrCallbackDataExpired is returned when a referenced payload of a callback data is not found in the store.
+	ErrCallbackDataExpired = errors.New("callback data is expired")
+)
+
+////////////////////////////////
+// CallbackStore
+//
+
+// CallbackStore is a key-value store for payloads of callback data which are longer than 64 bytes.
+type CallbackStore interface {
+	// stores `value` with `key`
+	Put(ctx context.Context, key string, value []byte) error
+
+	// returns the value of `key`, or false if it does not exist (or is expired)
+	Get(ctx context.Context, key string) (value []byte, exists bool, err error)
+}
+
+// MemoryCallbackStore is an in-memory CallbackStore whose values expire after a TTL.
+type MemoryCallbackStore struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	items map[string]memoryCallbackItem
+}
+
+type memoryCallbackItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCallbackStore returns a new MemoryCallbackStore with given TTL (0 for no expiration).
+func NewMemoryCallbackStore(ttl time.Duration) *MemoryCallbackStore {
+	return &MemoryCallbackStore{
+		ttl:   ttl,
+		items: map[string]memoryCallbackItem{},
+	}
+}
+
+// Put stores `value` with `key`, and removes expired ones.
+func (s *MemoryCallbackStore) Put(_ context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, item := range s.items {
+		if item.expired(now) {
+			delete(s.items, k)
+		}
+	}
+
+	item := memoryCallbackItem{value: value}
+	if s.ttl > 0 {
+		item.expiresAt = now.Add(s.ttl)
+	}
+	s.items[key] = item
+
+	return nil
+}
+
+// Get returns the value of `key`.
+func (s *MemoryCallbackStore) Get(_ context.Context, key string) (value []byte, exists bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.items[key]
+	if !exists || item.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return item.value, true, nil
+}
+
+// check if the item is expired
+func (i memoryCallbackItem) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && now.After(i.expiresAt)
+}
+
+////////////////////////////////
+// CallbackCodec
+//
+
+// CallbackCodec encodes values of type T into callback data, and decodes them back.
+//
+// Callback data are encoded as `prefix:version[:signature]:body`,
+// where `body` is a JSON array of exported fields of T (or a JSON value if T is not a struct).
+//
+// When the encoded data is longer than 64 bytes, its body is put into the Store
+// and replaced with a short reference key (`@key`).
+//
+//	type productAction struct {
+//		Action string
+//		ID     int64
+//		Page   int
+//	}
+//	codec := CallbackCodec[productAction]{Prefix: "prd", Version: 1, Secret: secret, Store: NewMemoryCallbackStore(24 * time.Hour)}
+//	data, err := codec.Encode(ctx, productAction{Action: "buy", ID: 42, Page: 3}) // => `prd:1:<signature>:["buy",42,3]`
+type CallbackCodec[T any] struct {
+	Prefix  string // prefix which identifies the type of data (should not contain ':')
+	Version int    // version of the encoding of T
+
+	Secret []byte        // secret for HMAC signatures (nil for no signature)
+	Store  CallbackStore // store for overflowed payloads (nil for failing with ErrCallbackDataTooLong)
+}
+
+// Matches checks if given callback data was encoded with this codec's prefix.
+func (c CallbackCodec[T]) Matches(data string) bool {
+	return strings.HasPrefix(data, c.Prefix+callbackDataSeparator)
+}
+
+// Encode encodes given value into callback data.
+func (c CallbackCodec[T]) Encode(ctx context.Context, value T) (data string, err error) {
+	body, err := marshalCallbackBody(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode callback data: %w", err)
+	}
+
+	data = c.compose(body)
+	if len(data) <= maxCallbackDataBytes {
+		return data, nil
+	}
+
+	// put the body into the store, and refer to it with a key
+	if c.Store == nil {
+		return "", fmt.Errorf("%w: %q (%d bytes)", ErrCallbackDataTooLong, data, len(data))
+	}
+	hash := sha256.Sum256([]byte(c.Prefix + callbackDataSeparator + body))
+	key := base64.RawURLEncoding.EncodeToString(hash[:callbackRefKeyLen])
+	if err := c.Store.Put(ctx, key, []byte(body)); err != nil {
+		return "", fmt.Errorf("failed to store callback data: %w", err)
+	}
+
+	data = c.compose(callbackRefMarker + key)
+	if len(data) > maxCallbackDataBytes {
+		return "", fmt.Errorf("%w: prefix %q is too long", ErrCallbackDataTooLong, c.Prefix)
+	}
+	return data, nil
+}
+
+// Decode decodes given callback data into a value, validating its prefix, version, and signature.
+func (c CallbackCodec[T]) Decode(ctx context.Context, data string) (value T, err error) {
+	if !c.Matches(data) {
+		return value, fmt.Errorf("%w: prefix does not match %q", ErrInvalidCallbackData, c.Prefix)
+	}
+	rest := strings.TrimPrefix(data, c.Prefix+callbackDataSeparator)
+
+	version, body, ok := strings.Cut(rest, callbackDataSeparator)
+	if !ok {
+		return value, fmt.Errorf("%w: no version", ErrInvalidCallbackData)
+	}
+	if version != strconv.Itoa(c.Version) {
+		return value, fmt.Errorf("%w: unsupported version %q", ErrInvalidCallbackData, version)
+	}
+
+	if c.Secret != nil {
+		var signature string
+		if signature, body, ok = strings.Cut(body, callbackDataSeparator); !ok || !hmac.Equal([]byte(signature), []byte(c.sign(body))) {
+			return value, fmt.Errorf("%w: signature mismatch", ErrInvalidCallbackData)
+		}
+	}
+
+	if key, isRef := strings.CutPrefix(body, callbackRefMarker); isRef {
+		if c.Store == nil {
+			return value, fmt.Errorf("%w: no store for referenced data", ErrInvalidCallbackData)
+		}
+		stored, exists, err := c.Store.Get(ctx, key)
+		if err != nil {
+			return value, fmt.Errorf("failed to load callback data: %w", err)
+		}
+		if !exists {
+			return value, ErrCallbackDataExpired
+		}
+		body = string(stored)
+	}
+
+	if err := unmarshalCallbackBody(body, &value); err != nil {
+		return value, fmt.Errorf("%w: %w", ErrInvalidCallbackData, err)
+	}
+	return value, nil
+}
+
+// Button returns an InlineKeyboardButton with given text and encoded value as its callback data.
+func (c CallbackCodec[T]) Button(ctx context.Context, text string, value T) (InlineKeyboardButton, error) {
+	data, err := c.Encode(ctx, value)
+	if err != nil {
+		return InlineKeyboardButton{}, err
+	}
+	return NewInlineKeyboardButton(text).SetCallbackData(data), nil
+}
+
+// compose callback data with given body
+func (c CallbackCodec[T]) compose(body string) string {
+	parts := []string{c.Prefix, strconv.Itoa(c.Version)}
+	if c.Secret != nil {
+		parts = append(parts, c.sign(body))
+	}
+	return strings.Join(append(parts, body), callbackDataSeparator)
+}
+
+// truncated HMAC signature of given body
+func (c CallbackCodec[T]) sign(body string) string {
+	mac := hmac.New(sha256.New, c.Secret)
+	mac.Write([]byte(c.Prefix + callbackDataSeparator + strconv.Itoa(c.Version) + callbackDataSeparator + body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:callbackSignatureLen])
+}
+
+// marshal given value as a JSON array of its exported fields, or as a JSON value if it is not a struct
+func marshalCallbackBody(value any) (string, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Struct {
+		bytes, err := json.Marshal(value)
+		return string(bytes), err
+	}
+
+	fields := []any{}
+	for field := range v.Type().Fields() {
+		if field.IsExported() && field.Tag.Get("json") != "-" {
+			fields = append(fields, v.FieldByIndex(field.Index).Interface())
+		}
+	}
+	bytes, err := json.Marshal(fields)
+	return string(bytes), err
+}
+
+// unmarshal given body into `ptr`, reversing marshalCallbackBody
+func unmarshalCallbackBody(body string, ptr any) error {
+	v := reflect.ValueOf(ptr).Elem()
+	if v.Kind() != reflect.Struct {
+		return json.Unmarshal([]byte(body), ptr)
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &values); err != nil {
+		return err
+	}
+
+	i := 0
+	for field := range v.Type().Fields() {
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if i >= len(values) {
+			return fmt.Errorf("missing value for field %s", field.Name)
+		}
+		if err := json.Unmarshal(values[i], v.FieldByIndex(field.Index).Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid value for field %s: %w", field.Name, err)
+		}
+		i++
+	}
+	if i != len(values) {
+		return fmt.Errorf("expected %d values, got %d", i, len(values))
+	}
+	return nil
+}
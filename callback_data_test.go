@@ -0,0 +1,128 @@
+// callback_data_test.go
+//
+// offline tests of callback data codecs
+
+package telegrambot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCallbackAction struct {
+	Action string
+	ID     int64
+	Page   int
+	Note   string `json:"-"`
+	hidden bool
+}
+
+// values should be encoded into callback data, and decoded back.
+func TestCallbackCodec(t *testing.T) {
+	slog.Info("testing callback data codecs...")
+
+	ctx := context.TODO()
+	codec := CallbackCodec[testCallbackAction]{Prefix: "act", Version: 2}
+
+	data, err := codec.Encode(ctx, testCallbackAction{Action: "buy", ID: 42, Page: 3, Note: "ignored"})
+	if err != nil {
+		t.Fatalf("failed to encode: %s", err)
+	}
+	if data != `act:2:["buy",42,3]` {
+		t.Errorf("unexpected callback data: %s", data)
+	}
+	if !codec.Matches(data) || codec.Matches("other:2:[]") {
+		t.Errorf("unexpected prefix matching")
+	}
+
+	decoded, err := codec.Decode(ctx, data)
+	if err != nil {
+		t.Fatalf("failed to decode: %s", err)
+	}
+	if decoded != (testCallbackAction{Action: "buy", ID: 42, Page: 3}) {
+		t.Errorf("unexpected decoded value: %+v", decoded)
+	}
+
+	// invalid ones
+	for _, invalid := range []string{
+		`act:1:["buy",42,3]`,
+		`act:2:["buy",42]`,
+		`act:2:["buy","42",3]`,
+		`act:2:{}`,
+		`other:2:["buy",42,3]`,
+	} {
+		if _, err := codec.Decode(ctx, invalid); !errors.Is(err, ErrInvalidCallbackData) {
+			t.Errorf("expected ErrInvalidCallbackData for %s, got: %v", invalid, err)
+		}
+	}
+
+	// non-struct values
+	pages := CallbackCodec[int]{Prefix: "p"}
+	if data, _ := pages.Encode(ctx, 7); data != "p:0:7" {
+		t.Errorf("unexpected callback data: %s", data)
+	}
+}
+
+// signed callback data should be verified.
+func TestCallbackCodecSignature(t *testing.T) {
+	slog.Info("testing signed callback data...")
+
+	ctx := context.TODO()
+	codec := CallbackCodec[testCallbackAction]{Prefix: "act", Version: 1, Secret: []byte("secret")}
+
+	data, err := codec.Encode(ctx, testCallbackAction{Action: "del", ID: 1})
+	if err != nil {
+		t.Fatalf("failed to encode: %s", err)
+	}
+	if _, err := codec.Decode(ctx, data); err != nil {
+		t.Errorf("failed to decode signed data: %s", err)
+	}
+
+	tampered := strings.Replace(data, `,1,`, `,2,`, 1)
+	if _, err := codec.Decode(ctx, tampered); !errors.Is(err, ErrInvalidCallbackData) {
+		t.Errorf("expected tampered data to be rejected, got: %v", err)
+	}
+
+	other := CallbackCodec[testCallbackAction]{Prefix: "act", Version: 1, Secret: []byte("other")}
+	if _, err := other.Decode(ctx, data); !errors.Is(err, ErrInvalidCallbackData) {
+		t.Errorf("expected data signed with another secret to be rejected, got: %v", err)
+	}
+}
+
+// long payloads should overflow into the store.
+func TestCallbackCodecStore(t *testing.T) {
+	slog.Info("testing overflow of callback data...")
+
+	ctx := context.TODO()
+	long := testCallbackAction{Action: strings.Repeat("x", 100), ID: 1}
+
+	// without a store
+	codec := CallbackCodec[testCallbackAction]{Prefix: "act", Version: 1, Secret: []byte("secret")}
+	if _, err := codec.Encode(ctx, long); !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Errorf("expected ErrCallbackDataTooLong, got: %v", err)
+	}
+
+	// with a store
+	codec.Store = NewMemoryCallbackStore(50 * time.Millisecond)
+	button, err := codec.Button(ctx, "long", long)
+	if err != nil {
+		t.Fatalf("failed to encode: %s", err)
+	}
+	data := *button.CallbackData
+	if len(data) > 64 || !strings.Contains(data, ":@") {
+		t.Errorf("expected a reference key, got: %s", data)
+	}
+	if decoded, err := codec.Decode(ctx, data); err != nil || decoded != long {
+		t.Errorf("failed to decode referenced data: %+v, %v", decoded, err)
+	}
+
+	// expired
+	time.Sleep(100 * time.Millisecond)
+	if _, err := codec.Decode(ctx, data); !errors.Is(err, ErrCallbackDataExpired) {
+		t.Errorf("expected ErrCallbackDataExpired, got: %v", err)
+	}
+}
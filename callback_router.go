@@ -0,0 +1,278 @@
+package telegrambot
+
+// Routing of callback queries by their data, with automatic answers
+//
+// https://core.telegram.org/bots/api#callbackquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrCallbackQueryAlreadyAnswered is returned when answering a callback query which was already answered.
+	ErrCallbackQueryAlreadyAnswered = errors.New("callback query is already answered")
+
+	// ErrMessageInaccessible is returned when editing a message which is inaccessible to the bot.
+	ErrMessageInaccessible = errors.New("message is inaccessible")
+)
+
+// CallbackHandler is a function for handling a routed callback query.
+type CallbackHandler func(ctx context.Context, c *CallbackContext) error
+
+// callbackRoute is a route of callback queries with a prefix
+type callbackRoute struct {
+	prefix  string
+	handler CallbackHandler
+}
+
+// CallbackRouter routes callback queries to handlers by prefixes of their data.
+//
+//	router := NewCallbackRouter()
+//	router.AutoAnswer = 3 * time.Second
+//	router.Handle("menu:", func(ctx context.Context, c *CallbackContext) error {
+//		_, err := c.EditText(ctx, "selected: "+c.Args, nil)
+//		return err
+//	})
+//	HandleCallback(router, productCodec, func(ctx context.Context, c *CallbackContext, action productAction) error {
+//		return c.AnswerText(ctx, "bought "+strconv.FormatInt(action.ID, 10), false)
+//	})
+//	bot.SetCallbackRouter(router)
+type CallbackRouter struct {
+	// answer callback queries automatically if handlers have not answered them
+	// within this duration (0 for disabling automatic answers)
+	AutoAnswer time.Duration
+
+	// text of automatic answers (empty for answering without a text)
+	AutoAnswerText string
+
+	// text of the answer when a handler returns an error or the data cannot be decoded (empty for no text)
+	ErrorText string
+
+	routes   []callbackRoute
+	fallback CallbackHandler
+}
+
+// NewCallbackRouter returns a new CallbackRouter.
+func NewCallbackRouter() *CallbackRouter {
+	return &CallbackRouter{}
+}
+
+// Handle adds a handler for callback queries whose data start with given prefix.
+//
+// When multiple prefixes match, the longest one is chosen.
+func (r *CallbackRouter) Handle(prefix string, handler CallbackHandler) {
+	r.routes = append(r.routes, callbackRoute{
+		prefix:  prefix,
+		handler: handler,
+	})
+}
+
+// Fallback sets a handler for callback queries which match no route.
+//
+// Without a fallback, unmatched queries are passed to the handler set with `SetCallbackQueryHandler`.
+func (r *CallbackRouter) Fallback(handler CallbackHandler) {
+	r.fallback = handler
+}
+
+// HandleCallback adds a handler for callback queries encoded with given codec.
+//
+// Queries whose data cannot be decoded are answered with the router's ErrorText.
+func HandleCallback[T any](r *CallbackRouter, codec CallbackCodec[T], handler func(ctx context.Context, c *CallbackContext, value T) error) {
+	r.Handle(codec.Prefix+callbackDataSeparator, func(ctx context.Context, c *CallbackContext) error {
+		value, err := codec.Decode(ctx, c.Data)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, c, value)
+	})
+}
+
+// find a route for given callback data
+func (r *CallbackRouter) route(data string) (route callbackRoute, found bool) {
+	for _, candidate := range r.routes {
+		if strings.HasPrefix(data, candidate.prefix) && (!found || len(candidate.prefix) > len(route.prefix)) {
+			route, found = candidate, true
+		}
+	}
+	if !found && r.fallback != nil {
+		return callbackRoute{handler: r.fallback}, true
+	}
+	return route, found
+}
+
+// handle a callback query with given route, answering it automatically if needed
+func (r *CallbackRouter) handle(b *Bot, update Update, route callbackRoute) {
+	query := *update.CallbackQuery
+	c := &CallbackContext{
+		Bot:    b,
+		Update: update,
+		Query:  query,
+	}
+	if query.Data != nil {
+		c.Data = *query.Data
+		c.Args = strings.TrimPrefix(c.Data, route.prefix)
+	}
+
+	ctx := context.Background()
+
+	if r.AutoAnswer > 0 {
+		timer := time.AfterFunc(r.AutoAnswer, func() {
+			r.autoAnswer(ctx, c, r.AutoAnswerText)
+		})
+		defer timer.Stop()
+	}
+
+	if err := route.handler(ctx, c); err != nil {
+		b.error("failed to handle callback query (data: %s): %s", c.Data, err)
+
+		r.autoAnswer(ctx, c, r.ErrorText)
+	} else if r.AutoAnswer > 0 {
+		r.autoAnswer(ctx, c, r.AutoAnswerText)
+	}
+}
+
+// answer given callback query with text, if it is not answered yet
+func (r *CallbackRouter) autoAnswer(ctx context.Context, c *CallbackContext, text string) {
+	options := OptionsAnswerCallbackQuery{}
+	if text != "" {
+		options = options.SetText(text)
+	}
+	if err := c.Answer(ctx, options); err != nil && !errors.Is(err, ErrCallbackQueryAlreadyAnswered) {
+		c.Bot.error("failed to answer callback query automatically: %s", err)
+	}
+}
+
+// SetCallbackRouter sets a router for handling callback queries.
+//
+// Callback queries which match no route of the router are passed to the handler set with `SetCallbackQueryHandler`.
+func (b *Bot) SetCallbackRouter(router *CallbackRouter) {
+	b.callbackRouter = router
+}
+
+////////////////////////////////
+// CallbackContext
+//
+
+// CallbackContext is a callback query being handled by a CallbackRouter.
+type CallbackContext struct {
+	Bot    *Bot
+	Update Update
+	Query  CallbackQuery
+
+	Data string // data of the callback query
+	Args string // data without the matched prefix
+
+	answered atomic.Bool
+}
+
+// Answered checks if the callback query was already answered.
+func (c *CallbackContext) Answered() bool {
+	return c.answered.Load()
+}
+
+// Answer answers the callback query with given options.
+//
+// https://core.telegram.org/bots/api#answercallbackquery
+func (c *CallbackContext) Answer(ctx context.Context, options OptionsAnswerCallbackQuery) error {
+	if !c.answered.CompareAndSwap(false, true) {
+		return ErrCallbackQueryAlreadyAnswered
+	}
+
+	_, err := c.Bot.AnswerCallbackQuery(ctx, c.Query.ID, options)
+	return err
+}
+
+// AnswerText answers the callback query with given text, as a notification or an alert.
+func (c *CallbackContext) AnswerText(ctx context.Context, text string, showAlert bool) error {
+	return c.Answer(ctx, OptionsAnswerCallbackQuery{}.SetText(text).SetShowAlert(showAlert))
+}
+
+// IsInaccessible checks if the originating message of the callback query is inaccessible to the bot.
+func (c *CallbackContext) IsInaccessible() bool {
+	return c.Query.Message != nil && c.Query.Message.IsInaccessible()
+}
+
+// target given edit options to the originating message of the callback query
+func (c *CallbackContext) target(options map[string]any) error {
+	if c.Query.InlineMessageID != nil {
+		options["inline_message_id"] = *c.Query.InlineMessageID
+		return nil
+	}
+	if c.Query.Message == nil {
+		return fmt.Errorf("callback query has no originating message")
+	}
+
+	options["chat_id"] = c.Query.Message.Chat.ID
+	options["message_id"] = c.Query.Message.MessageID
+	if c.Query.Message.BusinessConnectionID != nil {
+		options["business_connection_id"] = *c.Query.Message.BusinessConnectionID
+	}
+	return nil
+}
+
+// EditText edits the text of the originating message in place.
+//
+// When the message is inaccessible to the bot, a new message with the text is sent to its chat instead.
+//
+// https://core.telegram.org/bots/api#editmessagetext
+func (c *CallbackContext) EditText(ctx context.Context, text string, options OptionsEditMessageText) (result APIResponseMessageOrBool, err error) {
+	options = OptionsEditMessageText(cloneOptions(options))
+
+	if c.IsInaccessible() {
+		res, err := c.Bot.SendMessage(ctx, c.Query.Message.Chat.ID, text, OptionsSendMessage(options))
+		return APIResponseMessageOrBool{
+			OK:            res.OK,
+			Description:   res.Description,
+			Parameters:    res.Parameters,
+			ResultMessage: res.Result,
+		}, err
+	}
+
+	if err := c.target(options); err != nil {
+		return result, err
+	}
+	return c.Bot.EditMessageText(ctx, text, options)
+}
+
+// EditCaption edits the caption of the originating message in place.
+//
+// https://core.telegram.org/bots/api#editmessagecaption
+func (c *CallbackContext) EditCaption(ctx context.Context, caption string, options OptionsEditMessageCaption) (result APIResponseMessageOrBool, err error) {
+	if c.IsInaccessible() {
+		return result, ErrMessageInaccessible
+	}
+
+	options = OptionsEditMessageCaption(cloneOptions(options)).SetCaption(caption)
+	if err := c.target(options); err != nil {
+		return result, err
+	}
+	return c.Bot.EditMessageCaption(ctx, options)
+}
+
+// EditReplyMarkup replaces the inline keyboard of the originating message in place.
+//
+// https://core.telegram.org/bots/api#editmessagereplymarkup
+func (c *CallbackContext) EditReplyMarkup(ctx context.Context, markup InlineKeyboardMarkup) (result APIResponseMessageOrBool, err error) {
+	if c.IsInaccessible() {
+		return result, ErrMessageInaccessible
+	}
+
+	options := OptionsEditMessageReplyMarkup{}.SetReplyMarkup(markup)
+	if err := c.target(options); err != nil {
+		return result, err
+	}
+	return c.Bot.EditMessageReplyMarkup(ctx, options)
+}
+
+// find a route of the callback router for given update
+func (b *Bot) routeCallbackQuery(update Update) (route callbackRoute, found bool) {
+	if b.callbackRouter == nil || !update.HasCallbackQuery() || update.CallbackQuery.Data == nil {
+		return route, false
+	}
+	return b.callbackRouter.route(*update.CallbackQuery.Data)
+}
@@ -0,0 +1,97 @@
+// callback_router_test.go
+//
+// offline tests of routing callback queries
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// callback queries should be routed by prefixes and codecs, and answered automatically.
+func TestCallbackRouter(t *testing.T) {
+	slog.Info("testing routing of callback queries...")
+
+	server := NewTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	var mu sync.Mutex
+	handled := map[string]string{}
+	record := func(route, value string) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled[route] = value
+	}
+
+	codec := CallbackCodec[TestCallbackAction]{Prefix: "act", Version: 1}
+
+	router := NewCallbackRouter()
+	router.AutoAnswer = 50 * time.Millisecond
+	router.AutoAnswerText = "done"
+	router.Handle("menu:", func(ctx context.Context, c *CallbackContext) error {
+		record("menu", c.Args)
+		_, err := c.EditText(ctx, "menu "+c.Args, nil)
+		return err
+	})
+	router.Handle("menu:slow:", func(ctx context.Context, c *CallbackContext) error {
+		time.Sleep(100 * time.Millisecond)
+		record("slow", c.Args)
+		if err := c.AnswerText(ctx, "late", false); !errors.Is(err, ErrCallbackQueryAlreadyAnswered) {
+			t.Errorf("expected ErrCallbackQueryAlreadyAnswered, got: %v", err)
+		}
+		return nil
+	})
+	HandleCallback(router, codec, func(ctx context.Context, c *CallbackContext, action TestCallbackAction) error {
+		record("codec", action.Action)
+		return c.AnswerText(ctx, "got "+action.Action, true)
+	})
+	client.SetCallbackRouter(router)
+
+	unrouted := ""
+	client.SetCallbackQueryHandler(func(b *Bot, update Update, callbackQuery CallbackQuery) {
+		record("unrouted", *callbackQuery.Data)
+		unrouted = *callbackQuery.Data
+	})
+
+	data, _ := codec.Encode(context.TODO(), TestCallbackAction{Action: "buy"})
+	message := telegrambottest.NewUpdate().Message().Text("menu").Message()
+	client.DispatchUpdates([]Update{
+		telegrambottest.NewUpdate().CallbackQuery().Data("menu:settings").OnMessage(message).Build(),
+		telegrambottest.NewUpdate().CallbackQuery().Data("menu:slow:1").OnInlineMessage("inline-1").Build(),
+		telegrambottest.NewUpdate().CallbackQuery().Data(data).OnMessage(message).Build(),
+		telegrambottest.NewUpdate().CallbackQuery().Data("other").OnMessage(message).Build(),
+		telegrambottest.NewUpdate().CallbackQuery().Data("menu:gone").OnInaccessibleMessage(message.Chat, 999).Build(),
+	})
+	client.WaitForHandlers()
+
+	if handled["menu"] == "" || handled["slow"] != "1" || handled["codec"] != "buy" || unrouted != "other" {
+		t.Errorf("unexpected routes: %+v", handled)
+	}
+
+	answers := map[string]string{}
+	for _, call := range server.CallsTo("answerCallbackQuery") {
+		answers[call["text"]] += "+"
+	}
+	if answers["done"] != "+++" || answers["got buy"] != "+" {
+		t.Errorf("unexpected answers: %+v", server.CallsTo("answerCallbackQuery"))
+	}
+
+	// edits in place, or sends a new message for inaccessible ones
+	if edits := server.CallsTo("editMessageText"); len(edits) != 1 || edits[0]["text"] != "menu settings" || edits[0]["message_id"] == "" {
+		t.Errorf("unexpected edits: %+v", edits)
+	}
+	if sent := server.CallsTo("sendMessage"); len(sent) != 1 || sent[0]["text"] != "menu gone" {
+		t.Errorf("unexpected sent messages: %+v", sent)
+	}
+}
@@ -0,0 +1,620 @@
+// Code generated by mockgen from methods.go; DO NOT EDIT.
+
+package telegrambot
+
+import "context"
+
+// UpdatesAPI is a set of methods for fetching updates and managing webhooks.
+type UpdatesAPI interface {
+	// GetUpdates retrieves updates from Telegram bot API.
+	GetUpdates(ctx context.Context, options OptionsGetUpdates) (result APIResponse[[]Update], err error)
+
+	// SetWebhook sets various options for receiving incoming updates.
+	SetWebhook(ctx context.Context, host string, port int, options OptionsSetWebhook) (result APIResponse[bool], err error)
+
+	// DeleteWebhook deletes webhook for this bot.
+	DeleteWebhook(ctx context.Context, dropPendingUpdates bool) (result APIResponse[bool], err error)
+
+	// GetWebhookInfo gets webhook info for this bot.
+	GetWebhookInfo(ctx context.Context) (result APIResponse[WebhookInfo], err error)
+}
+
+// BusinessAPI is a set of methods for managing business accounts and their messages.
+type BusinessAPI interface {
+	// ReadBusinessMessage marks an incoming message as read on behalf of a business account.
+	ReadBusinessMessage(ctx context.Context, businessConnectionID string, chatID int64, messageID int64) (result APIResponse[bool], err error)
+
+	// DeleteBusinessMessages deletes messages on behalf of a business account.
+	DeleteBusinessMessages(ctx context.Context, businessConnectionID string, messageIDs []int64) (result APIResponse[bool], err error)
+
+	// SetBusinessAccountName changes the first and last name of a managed business account.
+	SetBusinessAccountName(ctx context.Context, businessConnectionID string, firstName string, options OptionsSetBusinessAccountName) (result APIResponse[bool], err error)
+
+	// SetBusinessAccountUsername changes the username of a managed business account.
+	SetBusinessAccountUsername(ctx context.Context, businessConnectionID string, options OptionsSetBusinessAccountUsername) (result APIResponse[bool], err error)
+
+	// SetBusinessAccountBio changes the bio of a managed business account.
+	SetBusinessAccountBio(ctx context.Context, businessConnectionID string, options OptionsSetBusinessAccountBio) (result APIResponse[bool], err error)
+
+	// SetBusinessAccountProfilePhoto changes the profile photo of a managed business account.
+	SetBusinessAccountProfilePhoto(ctx context.Context, businessConnectionID string, photo InputProfilePhoto, options OptionsSetBusinessAccountProfilePhoto) (result APIResponse[bool], err error)
+
+	// RemoveBusinessAccountProfilePhoto removes the current profile photo of a managed business account.
+	RemoveBusinessAccountProfilePhoto(ctx context.Context, businessConnectionID string, options OptionsRemoveBusinessAccountProfilePhoto) (result APIResponse[bool], err error)
+
+	// SetBusinessAccountGiftSettings changes the privacy settings pertaining to incoming gifts in a managed business account.
+	SetBusinessAccountGiftSettings(ctx context.Context, businessConnectionID string, showGiftButton bool, acceptedGiftTypes AcceptedGiftTypes) (result APIResponse[bool], err error)
+
+	// GetBusinessAccountStarBalance returns the amount of Telegram Stars owned by a managed business account.
+	GetBusinessAccountStarBalance(ctx context.Context, businessConnectionID string) (result APIResponse[StarAmount], err error)
+
+	// TransferBusinessAccountStars transfers Telegram Stars from the business account balance to the bot's balance.
+	TransferBusinessAccountStars(ctx context.Context, businessConnectionID string, starCount int) (result APIResponse[bool], err error)
+
+	// GetBusinessAccountGifts returns the gifts received and owned by a managed business account.
+	GetBusinessAccountGifts(ctx context.Context, businessConnectionID string, options OptionsGetBusinessAccountGifts) (result APIResponse[OwnedGifts], err error)
+
+	// GetBusinessConnection gets a business connection.
+	GetBusinessConnection(ctx context.Context, businessConnectionID string) (result APIResponse[BusinessConnection], err error)
+}
+
+// ForumTopicsAPI is a set of methods for managing forum topics.
+type ForumTopicsAPI interface {
+	// CreateForumTopic creates a topic in a forum supergroup chat or a private chat with a user.
+	CreateForumTopic(ctx context.Context, chatID ChatID, name string, options OptionsCreateForumTopic) (result APIResponse[ForumTopic], err error)
+
+	// EditForumTopic edits a topic in a forum supergroup chat or a private chat with a user.
+	EditForumTopic(ctx context.Context, chatID ChatID, messageThreadID int64, options OptionsEditForumTopic) (result APIResponse[bool], err error)
+
+	// CloseForumTopic closes an open topic in a forum supergroup chat.
+	CloseForumTopic(ctx context.Context, chatID ChatID, messageThreadID int64) (result APIResponse[bool], err error)
+
+	// ReopenForumTopic reopens a closed topic in a forum supergroup chat.
+	ReopenForumTopic(ctx context.Context, chatID ChatID, messageThreadID int64) (result APIResponse[bool], err error)
+
+	// DeleteForumTopic deletes a forum topic along with all its messages in a forum supergroup chat or a private chat with a user.
+	DeleteForumTopic(ctx context.Context, chatID ChatID, messageThreadID int64) (result APIResponse[bool], err error)
+
+	// UnpinAllForumTopicMessages clears the list of pinned messages in a forum topic in a forum supergroup chat or a private chat with a user.
+	UnpinAllForumTopicMessages(ctx context.Context, chatID ChatID, messageThreadID int64) (result APIResponse[bool], err error)
+
+	// EditGeneralForumTopic edits the name of the 'General' topic in a forum supergroup chat.
+	EditGeneralForumTopic(ctx context.Context, chatID ChatID, name string) (result APIResponse[bool], err error)
+
+	// CloseGeneralForumTopic closes an open 'General' topic in a forum supergroup chat.
+	CloseGeneralForumTopic(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// ReopenGeneralForumTopic reopens a closed 'General' topic in a forum supergroup chat.
+	ReopenGeneralForumTopic(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// HideGeneralForumTopic hides the 'General' topic in a forum supergroup chat.
+	HideGeneralForumTopic(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// UnhideGeneralForumTopic unhides the 'General' topic in a forum supergroup chat.
+	UnhideGeneralForumTopic(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// UnpinAllGeneralForumTopicMessages clears the list of pinned messages in a General forum topic.
+	UnpinAllGeneralForumTopicMessages(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// GetForumTopicIconStickers fetches custom emoji stickers which can be used as a forum topic icon by any user.
+	GetForumTopicIconStickers(ctx context.Context) (result APIResponse[[]Sticker], err error)
+}
+
+// StoriesAPI is a set of methods for posting and managing stories.
+type StoriesAPI interface {
+	// PostStory posts a story on behalf of a managed business account.
+	PostStory(ctx context.Context, businessConnectionID string, content InputStoryContent, activePeriod int, options OptionsPostStory) (result APIResponse[Story], err error)
+
+	// RepostStory reposts a story on behalf of a business account from another business account.
+	RepostStory(ctx context.Context, businessConnectionID string, fromChatID int64, fromStoryID int64, activePeriod int, options OptionsRepostStory) (result APIResponse[Story], err error)
+
+	// EditStory edits a story previously posted by the bot on behalf of a managed business account.
+	EditStory(ctx context.Context, businessConnectionID string, storyID int64, content InputStoryContent, options OptionsEditStory) (result APIResponse[Story], err error)
+
+	// DeleteStory deletes a story previously posted by the bot on behalf of a managed business account.
+	DeleteStory(ctx context.Context, businessConnectionID string, storyID int64) (result APIResponse[bool], err error)
+}
+
+// GiftsAPI is a set of methods for sending and managing gifts.
+type GiftsAPI interface {
+	// GetAvailableGifts returns the list of gifts that can be sent by the bot to users.
+	GetAvailableGifts(ctx context.Context) (result APIResponse[Gifts], err error)
+
+	// SendGift sends a gift to the given user.
+	SendGift(ctx context.Context, giftID string, options OptionsSendGift) (result APIResponse[bool], err error)
+
+	// GiftPremiumSubscription gifts a Telegram Premium subscription to the given user.
+	GiftPremiumSubscription(ctx context.Context, userID int64, monthCount int, starCount int, options OptionsGiftPremiumSubscription) (result APIResponse[bool], err error)
+
+	// GetUserGifts returns the gifts owned and hosted by a user.
+	GetUserGifts(ctx context.Context, userID int64, options OptionsGetUserGifts) (result APIResponse[OwnedGifts], err error)
+
+	// GetChatGifts returns the gifts owned by a chat.
+	GetChatGifts(ctx context.Context, chatID ChatID, options OptionsGetChatGifts) (result APIResponse[OwnedGifts], err error)
+
+	// ConvertGiftToStars converts a given regular gift to Telegram Stars.
+	ConvertGiftToStars(ctx context.Context, businessConnectionID string, ownedGiftID string) (result APIResponse[bool], err error)
+
+	// UpgradeGift upgrades a given regular gift to a unique gift.
+	UpgradeGift(ctx context.Context, businessConnectionID string, ownedGiftID string, options OptionsUpgradeGift) (result APIResponse[bool], err error)
+
+	// TransferGift transfers an owned unique gift to another user.
+	TransferGift(ctx context.Context, businessConnectionID string, ownedGiftID string, newOwnerChatID int64, options OptionsTransferGift) (result APIResponse[bool], err error)
+}
+
+// PaymentsAPI is a set of methods for payments, Telegram Stars, and paid media.
+type PaymentsAPI interface {
+	// SendPaidMedia sends paid media.
+	SendPaidMedia(ctx context.Context, chatID ChatID, starCount int, media []InputPaidMedia, options OptionsSendPaidMedia) (result APIResponse[Message], err error)
+
+	// SendInvoice sends an invoice.
+	SendInvoice(ctx context.Context, chatID int64, title string, description string, payload string, providerToken string, currency string, prices []LabeledPrice, options OptionsSendInvoice) (result APIResponse[Message], err error)
+
+	// CreateInvoiceLink creates a link for an invoice.
+	CreateInvoiceLink(ctx context.Context, title string, description string, payload string, currency string, prices []LabeledPrice, options OptionsCreateInvoiceLink) (result APIResponse[string], err error)
+
+	// AnswerShippingQuery answers a shipping query.
+	AnswerShippingQuery(ctx context.Context, shippingQueryID string, ok bool, shippingOptions []ShippingOption, errorMessage *string) (result APIResponse[bool], err error)
+
+	// AnswerPreCheckoutQuery answers a pre-checkout query.
+	AnswerPreCheckoutQuery(ctx context.Context, preCheckoutQueryID string, ok bool, errorMessage *string) (result APIResponse[bool], err error)
+
+	// GetMyStarBalance fetches the current balance of Telegram Stars.
+	GetMyStarBalance(ctx context.Context) (result APIResponse[StarAmount], err error)
+
+	// GetStarTransactions gets star transactions.
+	GetStarTransactions(ctx context.Context, options OptionsGetStarTransactions) (result APIResponse[StarTransactions], err error)
+
+	// RefundStarPayment refunds a successful payment in Telegram Stars.
+	RefundStarPayment(ctx context.Context, userID int64, telegramPaymentChargeID string) (result APIResponse[bool], err error)
+
+	// EditUserStarSubscription allows the bot to cancel or re-enable extension of a subscription.
+	EditUserStarSubscription(ctx context.Context, userID int64, telegramPaymentChargeID string, isCanceled bool) (result APIResponse[bool], err error)
+}
+
+// GamesAPI is a set of methods for games.
+type GamesAPI interface {
+	// SendGame sends a game.
+	SendGame(ctx context.Context, chatID ChatID, gameShortName string, options OptionsSendGame) (result APIResponse[Message], err error)
+
+	// SetGameScore sets score of a game.
+	SetGameScore(ctx context.Context, userID int64, score int, options OptionsSetGameScore) (result APIResponseMessageOrBool, err error)
+
+	// GetGameHighScores gets high scores of a game.
+	GetGameHighScores(ctx context.Context, userID int64, options OptionsGetGameHighScores) (result APIResponse[[]GameHighScore], err error)
+}
+
+// BotSettingsAPI is a set of methods for the bot itself and its settings.
+type BotSettingsAPI interface {
+	// GetMe gets info of this bot.
+	GetMe(ctx context.Context) (result APIResponse[User], err error)
+
+	// LogOut logs this bot from cloud Bot API server.
+	LogOut(ctx context.Context) (result APIResponse[bool], err error)
+
+	// Close closes this bot from local Bot API server.
+	Close(ctx context.Context) (result APIResponse[bool], err error)
+
+	// GetMyCommands fetches commands of this bot.
+	GetMyCommands(ctx context.Context, options OptionsGetMyCommands) (result APIResponse[[]BotCommand], err error)
+
+	// SetMyName changes the bot's name.
+	SetMyName(ctx context.Context, name string, options OptionsSetMyName) (result APIResponse[bool], err error)
+
+	// GetMyName fetches the bot's name.
+	GetMyName(ctx context.Context, options OptionsGetMyName) (result APIResponse[BotName], err error)
+
+	// SetMyDescription sets the bot's description.
+	SetMyDescription(ctx context.Context, options OptionsSetMyDescription) (result APIResponse[bool], err error)
+
+	// GetMyDescription gets the bot's description.
+	GetMyDescription(ctx context.Context, options OptionsGetMyDescription) (result APIResponse[BotDescription], err error)
+
+	// SetMyShortDescription sets the bot's short description.
+	SetMyShortDescription(ctx context.Context, options OptionsSetMyShortDescription) (result APIResponse[bool], err error)
+
+	// GetMyShortDescription gets the bot's short description.
+	GetMyShortDescription(ctx context.Context, options OptionsGetMyShortDescription) (result APIResponse[BotShortDescription], err error)
+
+	// GetManagedBotToken gets the token of a managed bot.
+	GetManagedBotToken(ctx context.Context, userID int64) (result APIResponse[string], err error)
+
+	// ReplaceManagedBotToken revokes the current token of a managed bot and generates a new one.
+	ReplaceManagedBotToken(ctx context.Context, userID int64) (result APIResponse[string], err error)
+
+	// GetManagedBotAccessSettings gets access settings of a managed bot.
+	GetManagedBotAccessSettings(ctx context.Context, userID int64) (result APIResponse[BotAccessSettings], err error)
+
+	// SetManagedBotAccessSettings changes the access settings of a managed bot.
+	SetManagedBotAccessSettings(ctx context.Context, userID int64, isAccessRestricted bool, options OptionsSetManagedBotAccessSettings) (result APIResponse[bool], err error)
+
+	// SetMyCommands sets commands of this bot.
+	SetMyCommands(ctx context.Context, commands []BotCommand, options OptionsSetMyCommands) (result APIResponse[bool], err error)
+
+	// DeleteMyCommands deletes commands of this bot.
+	DeleteMyCommands(ctx context.Context, options OptionsDeleteMyCommands) (result APIResponse[bool], err error)
+
+	// SetMyProfilePhoto sets the bot's profile photo.
+	SetMyProfilePhoto(ctx context.Context, photo InputProfilePhoto) (result APIResponse[bool], err error)
+
+	// RemoveMyProfilePhoto deletes the bot's profile photo.
+	RemoveMyProfilePhoto(ctx context.Context) (result APIResponse[bool], err error)
+
+	// SetChatMenuButton sets chat menu button.
+	SetChatMenuButton(ctx context.Context, options OptionsSetChatMenuButton) (result APIResponse[bool], err error)
+
+	// GetChatMenuButton fetches current chat menu button.
+	GetChatMenuButton(ctx context.Context, options OptionsGetChatMenuButton) (result APIResponse[MenuButton], err error)
+
+	// SetMyDefaultAdministratorRights sets my default administrator rights.
+	SetMyDefaultAdministratorRights(ctx context.Context, options OptionsSetMyDefaultAdministratorRights) (result APIResponse[bool], err error)
+
+	// GetMyDefaultAdministratorRights gets my default administrator rights.
+	GetMyDefaultAdministratorRights(ctx context.Context, options OptionsGetMyDefaultAdministratorRights) (result APIResponse[bool], err error)
+}
+
+// ChatAdminAPI is a set of methods for administrating chats and their members.
+type ChatAdminAPI interface {
+	// BanChatMember bans a chat member.
+	BanChatMember(ctx context.Context, chatID ChatID, userID int64, options OptionsBanChatMember) (result APIResponse[bool], err error)
+
+	// LeaveChat leaves a chat.
+	LeaveChat(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// UnbanChatMember unbans a chat member.
+	UnbanChatMember(ctx context.Context, chatID ChatID, userID int64, onlyIfBanned bool) (result APIResponse[bool], err error)
+
+	// RestrictChatMember restricts a chat member.
+	RestrictChatMember(ctx context.Context, chatID ChatID, userID int64, permissions ChatPermissions, options OptionsRestrictChatMember) (result APIResponse[bool], err error)
+
+	// PromoteChatMember promotes a chat member.
+	PromoteChatMember(ctx context.Context, chatID ChatID, userID int64, options OptionsPromoteChatMember) (result APIResponse[bool], err error)
+
+	// SetChatAdministratorCustomTitle sets chat administrator's custom title.
+	SetChatAdministratorCustomTitle(ctx context.Context, chatID ChatID, userID int64, customTitle string) (result APIResponse[bool], err error)
+
+	// SetChatMemberTag sets a tag for a regular member in a group or a supergroup.
+	SetChatMemberTag(ctx context.Context, chatID ChatID, userID int64, options OptionsSetChatMemberTag) (result APIResponse[bool], err error)
+
+	// BanChatSenderChat bans a channel chat in a supergroup or a channel.
+	BanChatSenderChat(ctx context.Context, chatID ChatID, senderChatID int64) (result APIResponse[bool], err error)
+
+	// UnbanChatSenderChat unbans a previously banned channel chat in a supergroup or a channel.
+	UnbanChatSenderChat(ctx context.Context, chatID ChatID, senderChatID int64) (result APIResponse[bool], err error)
+
+	// SetChatPermissions sets permissions of a chat.
+	SetChatPermissions(ctx context.Context, chatID ChatID, permissions ChatPermissions, options OptionsSetChatPermissions) (result APIResponse[bool], err error)
+
+	// ExportChatInviteLink exports a chat invite link.
+	ExportChatInviteLink(ctx context.Context, chatID ChatID) (result APIResponse[string], err error)
+
+	// CreateChatInviteLink creates a chat invite link.
+	CreateChatInviteLink(ctx context.Context, chatID ChatID, options OptionsCreateChatInviteLink) (result APIResponse[ChatInviteLink], err error)
+
+	// EditChatInviteLink edits a chat invite link.
+	EditChatInviteLink(ctx context.Context, chatID ChatID, inviteLink string, options OptionsCreateChatInviteLink) (result APIResponse[ChatInviteLink], err error)
+
+	// CreateChatSubscriptionInviteLink creates a subscription invite link for a channel chat.
+	CreateChatSubscriptionInviteLink(ctx context.Context, chatID ChatID, subscriptionPeriod int, subscriptionPrice int, options OptionsCreateChatSubscriptionInviteLink) (result APIResponse[ChatInviteLink], err error)
+
+	// EditChatSubscriptionInviteLink edits a subscription invite link created by the bot.
+	EditChatSubscriptionInviteLink(ctx context.Context, chatID ChatID, inviteLink string, options OptionsEditChatSubscriptionInviteLink) (result APIResponse[ChatInviteLink], err error)
+
+	// RevokeChatInviteLink revoks a chat invite link.
+	RevokeChatInviteLink(ctx context.Context, chatID ChatID, inviteLink string) (result APIResponse[ChatInviteLink], err error)
+
+	// ApproveChatJoinRequest approves chat join request.
+	ApproveChatJoinRequest(ctx context.Context, chatID ChatID, userID int64) (result APIResponse[bool], err error)
+
+	// DeclineChatJoinRequest declines chat join request.
+	DeclineChatJoinRequest(ctx context.Context, chatID ChatID, userID int64) (result APIResponse[bool], err error)
+
+	// AnswerChatJoinRequestQuery processes a received chat join request query.
+	AnswerChatJoinRequestQuery(ctx context.Context, chatJoinRequestQueryID string, res string) (result APIResponse[bool], err error)
+
+	// SendChatJoinRequestWebApp processes a received chat join request query
+	SendChatJoinRequestWebApp(ctx context.Context, chatJoinRequestQueryID string, webAppURL string) (result APIResponse[bool], err error)
+
+	// SetChatPhoto sets a chat photo.
+	SetChatPhoto(ctx context.Context, chatID ChatID, photo InputFile) (result APIResponse[bool], err error)
+
+	// DeleteChatPhoto deletes a chat photo.
+	DeleteChatPhoto(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// SetChatTitle sets a chat title.
+	SetChatTitle(ctx context.Context, chatID ChatID, title string) (result APIResponse[bool], err error)
+
+	// SetChatDescription sets a chat description.
+	SetChatDescription(ctx context.Context, chatID ChatID, description string) (result APIResponse[bool], err error)
+
+	// PinChatMessage pins a chat message.
+	PinChatMessage(ctx context.Context, chatID ChatID, messageID int64, options OptionsPinChatMessage) (result APIResponse[bool], err error)
+
+	// UnpinChatMessage unpins a chat message.
+	UnpinChatMessage(ctx context.Context, chatID ChatID, options OptionsUnpinChatMessage) (result APIResponse[bool], err error)
+
+	// UnpinAllChatMessages unpins all chat messages.
+	UnpinAllChatMessages(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// GetChat gets a chat.
+	GetChat(ctx context.Context, chatID ChatID) (result APIResponse[ChatFullInfo], err error)
+
+	// GetChatAdministrators gets chat administrators.
+	GetChatAdministrators(ctx context.Context, chatID ChatID, options OptionsGetChatAdministrators) (result APIResponse[[]ChatMember], err error)
+
+	// GetChatMemberCount gets chat members' count.
+	GetChatMemberCount(ctx context.Context, chatID ChatID) (result APIResponse[int], err error)
+
+	// GetChatMember gets a chat member.
+	GetChatMember(ctx context.Context, chatID ChatID, userID int64) (result APIResponse[ChatMember], err error)
+
+	// SetChatStickerSet sets a chat sticker set.
+	SetChatStickerSet(ctx context.Context, chatID ChatID, stickerSetName string) (result APIResponse[bool], err error)
+
+	// DeleteChatStickerSet deletes a chat sticker set.
+	DeleteChatStickerSet(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+}
+
+// StickersAPI is a set of methods for sending and managing stickers.
+type StickersAPI interface {
+	// SendSticker sends a sticker.
+	SendSticker(ctx context.Context, chatID ChatID, sticker InputFile, options OptionsSendSticker) (result APIResponse[Message], err error)
+
+	// GetStickerSet gets a sticker set.
+	GetStickerSet(ctx context.Context, name string) (result APIResponse[StickerSet], err error)
+
+	// GetCustomEmojiStickers gets custom emoji stickers.
+	GetCustomEmojiStickers(ctx context.Context, customEmojiIDs []string) (result APIResponse[[]Sticker], err error)
+
+	// UploadStickerFile uploads a sticker file.
+	UploadStickerFile(ctx context.Context, userID int64, sticker InputFile, stickerFormat StickerFormat) (result APIResponse[File], err error)
+
+	// CreateNewStickerSet creates a new sticker set.
+	CreateNewStickerSet(ctx context.Context, userID int64, name string, title string, stickers []InputSticker, options OptionsCreateNewStickerSet) (result APIResponse[bool], err error)
+
+	// AddStickerToSet adds a sticker to set.
+	AddStickerToSet(ctx context.Context, userID int64, name string, sticker InputSticker, options OptionsAddStickerToSet) (result APIResponse[bool], err error)
+
+	// SetStickerPositionInSet sets sticker position in set.
+	SetStickerPositionInSet(ctx context.Context, sticker string, position int) (result APIResponse[bool], err error)
+
+	// DeleteStickerFromSet deletes a sticker from set.
+	DeleteStickerFromSet(ctx context.Context, sticker string) (result APIResponse[bool], err error)
+
+	// SetStickerSetThumbnail sets a thumbnail of a sticker set.
+	SetStickerSetThumbnail(ctx context.Context, name string, userID int64, format StickerFormat, options OptionsSetStickerSetThumbnail) (result APIResponse[bool], err error)
+
+	// SetCustomEmojiStickerSetThumbnail sets the custom emoji sticker set's thumbnail.
+	SetCustomEmojiStickerSetThumbnail(ctx context.Context, name string, options OptionsSetCustomEmojiStickerSetThumbnail) (result APIResponse[bool], err error)
+
+	// SetStickerSetTitle sets the title of sticker set.
+	SetStickerSetTitle(ctx context.Context, name string, title string) (result APIResponse[bool], err error)
+
+	// DeleteStickerSet deletes a sticker set.
+	DeleteStickerSet(ctx context.Context, name string) (result APIResponse[bool], err error)
+
+	// ReplaceStickerInSet replaces an existing sticker in a sticker set with a new one.
+	ReplaceStickerInSet(ctx context.Context, userID string, name string, oldSticker string, sticker InputSticker) (result APIResponse[bool], err error)
+
+	// SetStickerEmojiList sets the emoji list of sticker set.
+	SetStickerEmojiList(ctx context.Context, sticker string, emojiList []string) (result APIResponse[bool], err error)
+
+	// SetStickerKeywords sets the keywords of sticker.
+	SetStickerKeywords(ctx context.Context, sticker string, keywords []string) (result APIResponse[bool], err error)
+
+	// SetStickerMaskPosition sets mask position of sticker.
+	SetStickerMaskPosition(ctx context.Context, sticker string, options OptionsSetStickerMaskPosition) (result APIResponse[bool], err error)
+}
+
+// UsersAPI is a set of methods for users and verifications.
+type UsersAPI interface {
+	// VerifyUser verifies a user.
+	VerifyUser(ctx context.Context, userID int64, options OptionsVerifyUser) (result APIResponse[bool], err error)
+
+	// VerifyChat verifies a chat.
+	VerifyChat(ctx context.Context, chatID ChatID, options OptionsVerifyChat) (result APIResponse[bool], err error)
+
+	// RemoveUserVerification removes a user's verification.
+	RemoveUserVerification(ctx context.Context, userID int64) (result APIResponse[bool], err error)
+
+	// RemoveChatVerification removes a chat's verification.
+	RemoveChatVerification(ctx context.Context, chatID ChatID) (result APIResponse[bool], err error)
+
+	// GetUserProfilePhotos gets user profile photos.
+	GetUserProfilePhotos(ctx context.Context, userID int64, options OptionsGetUserProfilePhotos) (result APIResponse[UserProfilePhotos], err error)
+
+	// GetUserProfileAudios gets a list of profile audios for a user.
+	GetUserProfileAudios(ctx context.Context, userID int64, options OptionsGetUserProfileAudios) (result APIResponse[UserProfileAudios], err error)
+
+	// SetUserEmojiStatus changes the emoji status for a given user that previously allowed the bot to manage their emoji status via the Mini App.
+	SetUserEmojiStatus(ctx context.Context, userID int64, options OptionsSetUserEmojiStatus) (result APIResponse[bool], err error)
+
+	// GetUserPersonalChatMessages gets the last messages from the personal chat of a given user.
+	GetUserPersonalChatMessages(ctx context.Context, userID int64, limit int) (result APIResponse[[]Message], err error)
+
+	// GetUserChatBoosts gets boosts of a user.
+	GetUserChatBoosts(ctx context.Context, chatID ChatID, userID int64) (result APIResponse[UserChatBoosts], err error)
+}
+
+// InlineAPI is a set of methods for inline queries, web apps, and guest queries.
+type InlineAPI interface {
+	// AnswerGuestQuery answers a received guest message.
+	AnswerGuestQuery(ctx context.Context, guestQueryID string, queryResult InlineQueryResult) (result APIResponse[SentGuestMessage], err error)
+
+	// AnswerInlineQuery sends answers to an inline query.
+	AnswerInlineQuery(ctx context.Context, inlineQueryID string, results []InlineQueryResultVariant, options OptionsAnswerInlineQuery) (result APIResponse[bool], err error)
+
+	// AnswerWebAppQuery answers a web app's query.
+	AnswerWebAppQuery(ctx context.Context, webAppQueryID string, res InlineQueryResult) (result APIResponse[SentWebAppMessage], err error)
+
+	// SavePreparedInlineMessage stores a message that can be sent by a user of a Mini App.
+	SavePreparedInlineMessage(ctx context.Context, userID int64, result InlineQueryResult, options OptionsSavePreparedInlineMessage) (res APIResponse[PreparedInlineMessage], err error)
+
+	// SavePreparedKeyboardButton stores a keyboard button that can be used by a user within a Mini App.
+	SavePreparedKeyboardButton(ctx context.Context, userID int64, button KeyboardButton) (res APIResponse[PreparedKeyboardButton], err error)
+}
+
+// MessagingAPI is a set of methods for sending, editing, and deleting messages.
+type MessagingAPI interface {
+	// SendMessage sends a message to the bot.
+	SendMessage(ctx context.Context, chatID ChatID, text string, options OptionsSendMessage) (result APIResponse[Message], err error)
+
+	// ForwardMessage forwards a message.
+	ForwardMessage(ctx context.Context, chatID ChatID, fromChatID ChatID, messageID int64, options OptionsForwardMessage) (result APIResponse[Message], err error)
+
+	// ForwardMessages forwards messages.
+	ForwardMessages(ctx context.Context, chatID ChatID, fromChatID ChatID, messageIDs []int64, options OptionsForwardMessages) (result APIResponse[[]MessageID], err error)
+
+	// CopyMessage copies a message.
+	CopyMessage(ctx context.Context, chatID ChatID, fromChatID ChatID, messageID int64, options OptionsCopyMessage) (result APIResponse[MessageID], err error)
+
+	// CopyMessages copies messages.
+	CopyMessages(ctx context.Context, chatID ChatID, fromChatID ChatID, messageIDs []int64, options OptionsCopyMessages) (result APIResponse[[]MessageID], err error)
+
+	// SendPhoto sends a photo.
+	SendPhoto(ctx context.Context, chatID ChatID, photo InputFile, options OptionsSendPhoto) (result APIResponse[Message], err error)
+
+	// SendLivePhoto sends a live photo.
+	SendLivePhoto(ctx context.Context, chatID ChatID, livePhoto InputFile, photo InputFile, options OptionsSendLivePhoto) (result APIResponse[Message], err error)
+
+	// SendAudio sends an audio file. (.mp3 or .m4a format, will be played with external players)
+	SendAudio(ctx context.Context, chatID ChatID, audio InputFile, options OptionsSendAudio) (result APIResponse[Message], err error)
+
+	// SendDocument sends a general file.
+	SendDocument(ctx context.Context, chatID ChatID, document InputFile, options OptionsSendDocument) (result APIResponse[Message], err error)
+
+	// SendRichMessage sends a rich message.
+	SendRichMessage(ctx context.Context, chatID ChatID, richMessage InputRichMessage, options OptionsSendRichMessage) (result APIResponse[Message], err error)
+
+	// SendRichMessageDraft streams a partial rich message to a user
+	SendRichMessageDraft(ctx context.Context, chatID ChatID, draftID int64, richMessage InputRichMessage, options OptionsSendRichMessageDraft) (result APIResponse[bool], err error)
+
+	// SendVideo sends a video file.
+	SendVideo(ctx context.Context, chatID ChatID, video InputFile, options OptionsSendVideo) (result APIResponse[Message], err error)
+
+	// SendAnimation sends an animation.
+	SendAnimation(ctx context.Context, chatID ChatID, animation InputFile, options OptionsSendAnimation) (result APIResponse[Message], err error)
+
+	// SendVoice sends a voice file. (.ogg format only, will be played with Telegram itself))
+	SendVoice(ctx context.Context, chatID ChatID, voice InputFile, options OptionsSendVoice) (result APIResponse[Message], err error)
+
+	// SendVideoNote sends a video note.
+	SendVideoNote(ctx context.Context, chatID ChatID, videoNote InputFile, options OptionsSendVideoNote) (result APIResponse[Message], err error)
+
+	// SendMediaGroup sends a group of photos or videos as an album.
+	SendMediaGroup(ctx context.Context, chatID ChatID, media []InputMedia, options OptionsSendMediaGroup) (result APIResponse[[]Message], err error)
+
+	// SendLocation sends locations.
+	SendLocation(ctx context.Context, chatID ChatID, latitude float32, longitude float32, options OptionsSendLocation) (result APIResponse[Message], err error)
+
+	// SendVenue sends venues.
+	SendVenue(ctx context.Context, chatID ChatID, latitude float32, longitude float32, title string, address string, options OptionsSendVenue) (result APIResponse[Message], err error)
+
+	// SendContact sends contacts.
+	SendContact(ctx context.Context, chatID ChatID, phoneNumber string, firstName string, options OptionsSendContact) (result APIResponse[Message], err error)
+
+	// SendPoll sends a poll.
+	SendPoll(ctx context.Context, chatID ChatID, question string, pollOptions []InputPollOption, options OptionsSendPoll) (result APIResponse[Message], err error)
+
+	// StopPoll stops a poll.
+	StopPoll(ctx context.Context, chatID ChatID, messageID int64, options OptionsStopPoll) (result APIResponse[Poll], err error)
+
+	// ApproveSuggestedPost approves a suggested post.
+	ApproveSuggestedPost(ctx context.Context, chatID int64, messageID int64, options OptionsApproveSuggestedPost) (result APIResponse[bool], err error)
+
+	// DeclineSuggestedPost declines a suggested post.
+	DeclineSuggestedPost(ctx context.Context, chatID int64, messageID int64, options OptionsDeclineSuggestedPost) (result APIResponse[bool], err error)
+
+	// SendChecklist sends a checklist.
+	SendChecklist(ctx context.Context, businessConnectionID string, chatID ChatID, checklist InputChecklist, options OptionsSendChecklist) (result APIResponse[Message], err error)
+
+	// SendDice sends a random dice.
+	SendDice(ctx context.Context, chatID ChatID, options OptionsSendDice) (result APIResponse[Message], err error)
+
+	// SendMessageDraft sends a message draft.
+	SendMessageDraft(ctx context.Context, chatID ChatID, draftID int64, text string, options OptionsSendMessageDraft) (result APIResponse[bool], err error)
+
+	// SendChatAction sends chat actions.
+	SendChatAction(ctx context.Context, chatID ChatID, action ChatAction, options OptionsSendChatAction) (result APIResponse[bool], err error)
+
+	// SetMessageReaction sets message reaction.
+	SetMessageReaction(ctx context.Context, chatID ChatID, messageID int64, options OptionsSetMessageReaction) (result APIResponse[bool], err error)
+
+	// GetFile gets file info and prepare for download.
+	GetFile(ctx context.Context, fileID string) (result APIResponse[File], err error)
+
+	// GetFileURL gets download link from a given File.
+	GetFileURL(file File) string
+
+	// AnswerCallbackQuery answers a callback query.
+	AnswerCallbackQuery(ctx context.Context, callbackQueryID string, options OptionsAnswerCallbackQuery) (result APIResponse[bool], err error)
+
+	// EditMessageText edits text of a message.
+	EditMessageText(ctx context.Context, text string, options OptionsEditMessageText) (result APIResponseMessageOrBool, err error)
+
+	// EditMessageCaption edits caption of a message.
+	EditMessageCaption(ctx context.Context, options OptionsEditMessageCaption) (result APIResponseMessageOrBool, err error)
+
+	// EditMessageMedia edites a media message.
+	EditMessageMedia(ctx context.Context, media InputMedia, options OptionsEditMessageMedia) (result APIResponseMessageOrBool, err error)
+
+	// EditMessageLiveLocation edits live location of a message.
+	EditMessageLiveLocation(ctx context.Context, latitude float32, longitude float32, options OptionsEditMessageLiveLocation) (result APIResponseMessageOrBool, err error)
+
+	// StopMessageLiveLocation stops live location of a message.
+	StopMessageLiveLocation(ctx context.Context, options OptionsStopMessageLiveLocation) (result APIResponseMessageOrBool, err error)
+
+	// EditMessageChecklist edits check list of a message.
+	EditMessageChecklist(ctx context.Context, businessConnectionID string, chatID int64, messageID int64, checklist InputChecklist, options OptionsEditMessageChecklist) (result APIResponse[Message], err error)
+
+	// EditMessageReplyMarkup edits reply markup of a message.
+	EditMessageReplyMarkup(ctx context.Context, options OptionsEditMessageReplyMarkup) (result APIResponseMessageOrBool, err error)
+
+	// DeleteMessage deletes a message.
+	DeleteMessage(ctx context.Context, chatID ChatID, messageID int64) (result APIResponse[bool], err error)
+
+	// DeleteMessages deletes messages.
+	DeleteMessages(ctx context.Context, chatID ChatID, messageIDs []int64) (result APIResponse[bool], err error)
+
+	// DeleteMessageReaction removes a reaction from a message in a group or a supergroup chat.
+	DeleteMessageReaction(ctx context.Context, chatID ChatID, messageID int64, options OptionsDeleteMessageReaction) (result APIResponse[bool], err error)
+
+	// DeleteAllMessageReactions deletes up to 10,000 recent reactions in a group or
+	DeleteAllMessageReactions(ctx context.Context, chatID ChatID, options OptionsDeleteAllMessageReactions) (result APIResponse[bool], err error)
+
+	// EditEphemeralMessageText edits an ephemeral text message.
+	EditEphemeralMessageText(ctx context.Context, chatID ChatID, receiverUserID int64, ephemeralMessageID int64, text string, options OptionsEditEphemeralMessageText) (result APIResponse[bool], err error)
+
+	// EditEphemeralMessageMedia edits the media of an ephemeral message.
+	EditEphemeralMessageMedia(ctx context.Context, chatID ChatID, receiverUserID int64, ephemeralMessageID int64, options OptionsEditEphemeralMessageMedia, media InputMedia) (result APIResponse[bool], err error)
+
+	// EditEphemeralMessageCaption edits the caption of an ephemeral message.
+	EditEphemeralMessageCaption(ctx context.Context, chatID ChatID, receiverUserID int64, ephemeralMessageID int64, options OptionsEditEphemeralMessageCaption) (result APIResponse[bool], err error)
+
+	// EditEphemeralMessageReplyMarkup edits the reply markup of an ephemeral message.
+	EditEphemeralMessageReplyMarkup(ctx context.Context, chatID ChatID, receiverUserID int64, ephemeralMessageID int64, options OptionsEditEphemeralMessageReplyMarkup) (result APIResponse[bool], err error)
+
+	// DeleteEphemeralMessage deletes an ephemeral message.
+	DeleteEphemeralMessage(ctx context.Context, chatID ChatID, receiverUserID int64, ephemeralMessageID int64) (result APIResponse[bool], err error)
+}
+
+// API is a set of all methods of the Bot API, which is satisfied by *Bot.
+//
+// Depend on smaller capabilities (eg. MessagingAPI) where possible, so that they can be easily mocked.
+type API interface {
+	UpdatesAPI
+	BusinessAPI
+	ForumTopicsAPI
+	StoriesAPI
+	GiftsAPI
+	PaymentsAPI
+	GamesAPI
+	BotSettingsAPI
+	ChatAdminAPI
+	StickersAPI
+	UsersAPI
+	InlineAPI
+	MessagingAPI
+}
+
+var _ API = (*Bot)(nil)
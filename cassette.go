@@ -0,0 +1,369 @@
+package telegrambot
+
+// Recording & replaying HTTP interactions with Bot API server (for regression tests),
+// and replaying updates from JSON lines (for reproducing incidents locally).
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	maxUpdateLineBytes = 16 * 1024 * 1024 // max length of a line in JSON lines of updates
+)
+
+// ErrCassetteInUse is returned when recording or replaying is started while a cassette is already in use.
+var ErrCassetteInUse = errors.New("cassette is already in use")
+
+// Interaction is a recorded HTTP interaction with Bot API server.
+//
+// A cassette file is composed of Interactions in JSON lines.
+type Interaction struct {
+	Method       string            `json:"method"`           // API method (eg. "sendMessage"), or "file/<file_path>" for file downloads
+	Params       map[string]string `json:"params,omitempty"` // request params (uploaded files are replaced with placeholders)
+	StatusCode   int               `json:"status_code"`
+	Response     json.RawMessage   `json:"response,omitempty"`      // response body (when it is a JSON)
+	ResponseText *string           `json:"response_text,omitempty"` // response body (when it is not a JSON)
+}
+
+// StartRecording starts recording HTTP interactions to a cassette file at given path.
+//
+// Interactions will be appended to the file in JSON lines, with confidential info redacted.
+//
+// NOTE: Call this before sending any request, and call StopRecording() when done.
+func (b *Bot) StartRecording(cassettePath string) (err error) {
+	if b.cassetteInUse() {
+		return ErrCassetteInUse
+	}
+
+	var file *os.File
+	if file, err = os.OpenFile(cassettePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return fmt.Errorf("failed to open cassette file: %w", err)
+	}
+
+	b.cassette = file
+	b.transportBeforeCassette = b.httpClient.Transport
+	b.httpClient.Transport = &recordingTransport{
+		b:    b,
+		base: b.baseTransport(),
+		file: file,
+	}
+
+	return nil
+}
+
+// StopRecording stops recording HTTP interactions and closes the cassette file.
+func (b *Bot) StopRecording() error {
+	return b.restoreTransport()
+}
+
+// StartReplaying starts replaying HTTP interactions from a cassette file at given path,
+// instead of sending requests to the server.
+//
+// Recorded responses are returned in the recorded order of each API method.
+// Requests without any recorded response left will fail.
+//
+// NOTE: Call this before sending any request, and call StopReplaying() when done.
+func (b *Bot) StartReplaying(cassettePath string) (err error) {
+	if b.cassetteInUse() {
+		return ErrCassetteInUse
+	}
+
+	var file *os.File
+	if file, err = os.Open(cassettePath); err != nil {
+		return fmt.Errorf("failed to open cassette file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	transport := &replayingTransport{
+		b:            b,
+		interactions: map[string][]Interaction{},
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxUpdateLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var interaction Interaction
+		if err = json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return fmt.Errorf("failed to parse interaction at line %d: %w", line, err)
+		}
+		transport.interactions[interaction.Method] = append(transport.interactions[interaction.Method], interaction)
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read cassette file: %w", err)
+	}
+
+	b.transportBeforeCassette = b.httpClient.Transport
+	b.httpClient.Transport = transport
+
+	return nil
+}
+
+// StopReplaying stops replaying HTTP interactions.
+func (b *Bot) StopReplaying() error {
+	return b.restoreTransport()
+}
+
+// ReplayUpdates reads updates in JSON lines from given reader, and dispatches them
+// to the registered handlers just like polled ones, then waits for the handlers to finish.
+//
+// Updates which are not handled by any other handler will be passed to `updateHandler`,
+// which replaces the bot's own update handler only while replaying.
+func (b *Bot) ReplayUpdates(
+	reader io.Reader,
+	updateHandler func(b *Bot, update Update, err error),
+) (count int, err error) {
+	if updateHandler == nil {
+		return 0, fmt.Errorf("given update handler is nil")
+	}
+
+	updates := []Update{}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxUpdateLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var update Update
+		if err = json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			return 0, fmt.Errorf("failed to parse update at line %d: %w", line, err)
+		}
+		b.keepRawJSONOf(scanner.Bytes(), &update)
+		updates = append(updates, update)
+	}
+	if err = scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read updates: %w", err)
+	}
+
+	previous := b.updateHandler
+	b.updateHandler = updateHandler
+	defer func() { b.updateHandler = previous }()
+
+	b.dispatchUpdates(updates)
+	b.waitForHandlers()
+
+	return len(updates), nil
+}
+
+// wait for all running handlers to finish
+func (b *Bot) waitForHandlers() {
+	sem := b.workerSem
+	for range cap(sem) {
+		sem <- struct{}{}
+	}
+	for range cap(sem) {
+		<-sem
+	}
+}
+
+// check if a cassette is being recorded or replayed
+func (b *Bot) cassetteInUse() bool {
+	switch b.httpClient.Transport.(type) {
+	case *recordingTransport, *replayingTransport:
+		return true
+	}
+	return false
+}
+
+// get the base transport of http client
+func (b *Bot) baseTransport() http.RoundTripper {
+	if b.httpClient.Transport != nil {
+		return b.httpClient.Transport
+	}
+	return http.DefaultTransport
+}
+
+// restore the transport of http client, replaced for recording/replaying
+func (b *Bot) restoreTransport() (err error) {
+	if b.cassetteInUse() {
+		b.httpClient.Transport = b.transportBeforeCassette
+		b.transportBeforeCassette = nil
+	}
+
+	if b.cassette != nil {
+		err = b.cassette.Close()
+		b.cassette = nil
+	}
+
+	return err
+}
+
+// get the key of an interaction from given request url
+func (b *Bot) interactionMethod(req *http.Request) string {
+	url := req.URL.String()
+
+	if filePath, ok := strings.CutPrefix(url, b.fileBaseURL+b.token+"/"); ok {
+		return "file/" + filePath
+	}
+	if method, ok := strings.CutPrefix(url, b.apiBaseURL+b.token+"/"); ok {
+		return method
+	}
+
+	return b.redact(req.URL.Path)
+}
+
+// recordingTransport records HTTP interactions to a cassette file
+type recordingTransport struct {
+	b    *Bot
+	base http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// RoundTrip sends a request and records it with its response.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		_ = req.Body.Close()
+
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		req.ContentLength = int64(len(reqBody))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var respBody []byte
+	respBody, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Method:     t.b.interactionMethod(req),
+		Params:     t.b.redactedParams(req.Header.Get("Content-Type"), reqBody),
+		StatusCode: resp.StatusCode,
+	}
+	redacted := t.b.redact(string(respBody))
+	if json.Valid([]byte(redacted)) {
+		interaction.Response = json.RawMessage(redacted)
+	} else {
+		interaction.ResponseText = &redacted
+	}
+
+	if line, err := json.Marshal(interaction); err == nil {
+		t.mu.Lock()
+		if _, err := t.file.Write(append(line, '\n')); err != nil {
+			t.b.error("failed to write interaction to cassette: %s", err)
+		}
+		t.mu.Unlock()
+	} else {
+		t.b.error("failed to encode interaction: %s", err)
+	}
+
+	return resp, nil
+}
+
+// decode params from given request body, with confidential info redacted
+func (b *Bot) redactedParams(contentType string, body []byte) map[string]string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	params := map[string]string{}
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		if err := req.ParseMultipartForm(int64(len(body)) + 1); err != nil {
+			return nil
+		}
+		for key, values := range req.MultipartForm.Value {
+			if len(values) > 0 {
+				params[key] = b.redact(values[0])
+			}
+		}
+		for key, headers := range req.MultipartForm.File {
+			if len(headers) > 0 {
+				params[key] = fmt.Sprintf("<file: %s (%d bytes)>", headers[0].Filename, headers[0].Size)
+			}
+		}
+	} else {
+		if err := req.ParseForm(); err != nil {
+			return nil
+		}
+		for key, values := range req.PostForm {
+			if len(values) > 0 {
+				params[key] = b.redact(values[0])
+			}
+		}
+	}
+
+	return params
+}
+
+// replayingTransport replays HTTP interactions from a cassette
+type replayingTransport struct {
+	b *Bot
+
+	mu           sync.Mutex
+	interactions map[string][]Interaction // recorded interactions, by method
+}
+
+// RoundTrip returns the next recorded response for the request's method.
+func (t *replayingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	method := t.b.interactionMethod(req)
+
+	t.mu.Lock()
+	recorded := t.interactions[method]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded interaction left for: %s", method)
+	}
+	interaction := recorded[0]
+	t.interactions[method] = recorded[1:]
+	t.mu.Unlock()
+
+	var body []byte
+	header := http.Header{}
+	if interaction.ResponseText != nil {
+		body = []byte(*interaction.ResponseText)
+		header.Set("Content-Type", "text/plain")
+	} else {
+		body = interaction.Response
+		header.Set("Content-Type", "application/json")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,151 @@
+// cassette_test.go
+//
+// offline tests of recording/replaying HTTP interactions and updates
+
+package telegrambot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testToken = "1234567890:TEST-telegram-bot-go-token"
+)
+
+// recorded interactions should be redacted, and replayed without the server.
+func TestRecordAndReplay(t *testing.T) {
+	slog.Info("testing recording and replaying of HTTP interactions...")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":7,"date":0,"chat":{"id":12345,"type":"private"},"text":%q}}`, r.PostForm.Get("text"))
+	}))
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	// record
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+	if err := client.StartRecording(cassettePath); err != nil {
+		t.Fatalf("failed to start recording: %s", err)
+	}
+	if err := client.StartRecording(cassettePath); !errors.Is(err, ErrCassetteInUse) {
+		t.Errorf("expected ErrCassetteInUse while recording, got: %v", err)
+	}
+	if err := client.StartReplaying(cassettePath); !errors.Is(err, ErrCassetteInUse) {
+		t.Errorf("expected ErrCassetteInUse for replaying while recording, got: %v", err)
+	}
+	if _, err := client.SendMessage(context.TODO(), 12345, "token: "+testToken, nil); err != nil {
+		t.Fatalf("failed to send message while recording: %s", err)
+	}
+	if err := client.StopRecording(); err != nil {
+		t.Fatalf("failed to stop recording: %s", err)
+	}
+	server.Close()
+
+	recorded, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %s", err)
+	}
+	if strings.Contains(string(recorded), testToken) {
+		t.Errorf("expected the token to be redacted from cassette: %s", recorded)
+	}
+	if !strings.Contains(string(recorded), `"method":"sendMessage"`) {
+		t.Errorf("expected the method to be recorded: %s", recorded)
+	}
+
+	// replay (the server is closed now)
+	client = NewClient(testToken)
+	client.SetBaseURL(server.URL)
+	if err := client.StartReplaying(cassettePath); err != nil {
+		t.Fatalf("failed to start replaying: %s", err)
+	}
+	defer func() { _ = client.StopReplaying() }()
+	if err := client.StartRecording(cassettePath); !errors.Is(err, ErrCassetteInUse) {
+		t.Errorf("expected ErrCassetteInUse for recording while replaying, got: %v", err)
+	}
+
+	if sent, err := client.SendMessage(context.TODO(), 12345, "anything", nil); err != nil {
+		t.Errorf("failed to replay a recorded interaction: %s", err)
+	} else if sent.Result.MessageID != 7 || *sent.Result.Text != "token: "+redactedString {
+		t.Errorf("unexpected replayed message: %+v", sent.Result)
+	}
+
+	// no more interaction left
+	if _, err := client.SendMessage(context.TODO(), 12345, "anything", nil); err == nil {
+		t.Errorf("expected an error when no recorded interaction is left")
+	}
+}
+
+// updates in JSON lines should be dispatched to matching handlers.
+func TestReplayUpdates(t *testing.T) {
+	slog.Info("testing replaying of updates from JSON lines...")
+
+	jsonl := `{"update_id":1,"message":{"message_id":1,"date":0,"chat":{"id":12345,"type":"private"},"text":"/start foo"}}
+
+{"update_id":2,"message":{"message_id":2,"date":0,"chat":{"id":12345,"type":"private"},"text":"hello"}}
+{"update_id":3,"poll":{"id":"poll","question":"?","options":[],"total_voter_count":0,"is_closed":false,"is_anonymous":true,"type":"regular","allows_multiple_answers":false}}
+`
+
+	var mu sync.Mutex
+	handled := []string{}
+
+	client := NewClient(testToken)
+	client.AddCommandHandler("/start", func(b *Bot, update Update, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, "command:"+args)
+	})
+	client.SetMessageHandler(func(b *Bot, update Update, message Message, edited bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, "message:"+*message.Text)
+	})
+
+	count, err := client.ReplayUpdates(strings.NewReader(jsonl), func(b *Bot, update Update, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, fmt.Sprintf("update:%d", update.UpdateID))
+	})
+	if err != nil {
+		t.Fatalf("failed to replay updates: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 replayed updates, got %d", count)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, expected := range []string{"command:foo", "message:hello", "update:3"} {
+		found := false
+		for _, h := range handled {
+			if h == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %q to be handled, got: %v", expected, handled)
+		}
+	}
+
+	// the given handler should not be kept after replaying
+	if client.updateHandler != nil {
+		t.Errorf("expected the update handler to be restored after replaying")
+	}
+
+	// malformed lines should be reported with their line numbers
+	if _, err := client.ReplayUpdates(strings.NewReader("{\n"), func(b *Bot, update Update, err error) {}); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an error at line 1, got: %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+package telegrambot
+
+// Typed variants of chat members, and differences of chat member updates
+//
+// https://core.telegram.org/bots/api#chatmember
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChatMemberVariant is a concrete variant of a chat member:
+// *ChatMemberOwner, *ChatMemberAdministrator, *ChatMemberMember,
+// *ChatMemberRestricted, *ChatMemberLeft, or *ChatMemberBanned.
+//
+//	variant, err := member.Variant()
+//	switch m := variant.(type) {
+//	case *ChatMemberAdministrator:
+//		canDelete = m.CanDeleteMessages
+//	case *ChatMemberRestricted:
+//		until = m.UntilDate
+//	}
+type ChatMemberVariant interface {
+	// status of the chat member
+	GetStatus() ChatMemberStatus
+
+	// user of the chat member
+	GetUser() User
+
+	// whether the user is currently in the chat
+	IsInChat() bool
+
+	// permissions of the chat member, with given default permissions of the chat
+	EffectivePermissions(defaults ChatPermissions) ChatPermissions
+
+	// administrator rights of the chat member (all false for non-administrators)
+	EffectiveRights() ChatAdministratorRights
+
+	chatMemberVariant()
+}
+
+// returns a new variant of given status (nil if unknown)
+func newChatMemberOfStatus(status ChatMemberStatus) ChatMemberVariant {
+	switch status {
+	case ChatMemberStatusCreator:
+		return &ChatMemberOwner{}
+	case ChatMemberStatusAdministrator:
+		return &ChatMemberAdministrator{}
+	case ChatMemberStatusMember:
+		return &ChatMemberMember{}
+	case ChatMemberStatusRestricted:
+		return &ChatMemberRestricted{}
+	case ChatMemberStatusLeft:
+		return &ChatMemberLeft{}
+	case ChatMemberStatusBanned:
+		return &ChatMemberBanned{}
+	}
+	return nil
+}
+
+// DecodeChatMember decodes given json into a concrete variant of a chat member, discriminated by its `status`.
+func DecodeChatMember(data []byte) (variant ChatMemberVariant, err error) {
+	var head struct {
+		Status ChatMemberStatus `json:"status"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	variant = newChatMemberOfStatus(head.Status)
+	if variant == nil {
+		return nil, fmt.Errorf("unknown chat member status: '%s'", head.Status)
+	}
+	if err := json.Unmarshal(data, variant); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+// Variant returns the concrete variant of the chat member, built from its current fields.
+func (m ChatMember) Variant() (variant ChatMemberVariant, err error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeChatMember(data)
+}
+
+// all permissions allowed
+func allChatPermissions() ChatPermissions {
+	return ChatPermissions{
+		CanSendMessages:       new(true),
+		CanSendAudios:         new(true),
+		CanSendDocuments:      new(true),
+		CanSendPhotos:         new(true),
+		CanSendVideos:         new(true),
+		CanSendVideoNotes:     new(true),
+		CanSendVoiceNotes:     new(true),
+		CanSendPolls:          new(true),
+		CanSendOtherMessages:  new(true),
+		CanAddWebPagePreviews: new(true),
+		CanReactToMessages:    new(true),
+		CanEditTag:            new(true),
+		CanChangeInfo:         new(true),
+		CanInviteUsers:        new(true),
+		CanPinMessages:        new(true),
+		CanManageTopics:       new(true),
+	}
+}
+
+// all administrator rights granted
+func allAdministratorRights(isAnonymous bool) ChatAdministratorRights {
+	return ChatAdministratorRights{
+		IsAnonymous:             isAnonymous,
+		CanManageChat:           true,
+		CanDeleteMessages:       true,
+		CanManageVideoChats:     true,
+		CanRestrictMembers:      true,
+		CanPromoteMembers:       true,
+		CanChangeInfo:           true,
+		CanInviteUsers:          true,
+		CanPostStories:          true,
+		CanEditStories:          true,
+		CanDeleteStories:        true,
+		CanPostMessages:         new(true),
+		CanEditMessages:         new(true),
+		CanPinMessages:          new(true),
+		CanManageTopics:         new(true),
+		CanManageDirectMessages: new(true),
+		CanManageTags:           new(true),
+	}
+}
+
+// ChatMemberOwner
+
+// GetStatus returns ChatMemberStatusCreator.
+func (m ChatMemberOwner) GetStatus() ChatMemberStatus { return ChatMemberStatusCreator }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberOwner) GetUser() User { return m.User }
+
+// IsInChat returns true, as owners are always in the chat.
+func (m ChatMemberOwner) IsInChat() bool { return true }
+
+// EffectivePermissions returns all permissions, regardless of the defaults of the chat.
+func (m ChatMemberOwner) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	return allChatPermissions()
+}
+
+// EffectiveRights returns all administrator rights.
+func (m ChatMemberOwner) EffectiveRights() ChatAdministratorRights {
+	return allAdministratorRights(m.IsAnonymous)
+}
+
+func (m ChatMemberOwner) chatMemberVariant() {}
+
+// ChatMemberAdministrator
+
+// GetStatus returns ChatMemberStatusAdministrator.
+func (m ChatMemberAdministrator) GetStatus() ChatMemberStatus { return ChatMemberStatusAdministrator }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberAdministrator) GetUser() User { return m.User }
+
+// IsInChat returns true, as administrators are always in the chat.
+func (m ChatMemberAdministrator) IsInChat() bool { return true }
+
+// EffectivePermissions returns all permissions, except the ones limited by the administrator rights.
+func (m ChatMemberAdministrator) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	permissions := allChatPermissions()
+	permissions.CanChangeInfo = new(m.CanChangeInfo)
+	permissions.CanInviteUsers = new(m.CanInviteUsers)
+	permissions.CanPinMessages = new(m.CanPinMessages != nil && *m.CanPinMessages)
+	permissions.CanManageTopics = new(m.CanManageTopics != nil && *m.CanManageTopics)
+	return permissions
+}
+
+// EffectiveRights returns the granted administrator rights.
+func (m ChatMemberAdministrator) EffectiveRights() ChatAdministratorRights {
+	return ChatAdministratorRights{
+		IsAnonymous:             m.IsAnonymous,
+		CanManageChat:           m.CanManageChat,
+		CanDeleteMessages:       m.CanDeleteMessages,
+		CanManageVideoChats:     m.CanManageVideoChats,
+		CanRestrictMembers:      m.CanRestrictMembers,
+		CanPromoteMembers:       m.CanPromoteMembers,
+		CanChangeInfo:           m.CanChangeInfo,
+		CanInviteUsers:          m.CanInviteUsers,
+		CanPostStories:          m.CanPostStories,
+		CanEditStories:          m.CanEditStories,
+		CanDeleteStories:        m.CanDeleteStories,
+		CanPostMessages:         m.CanPostMessages,
+		CanEditMessages:         m.CanEditMessages,
+		CanPinMessages:          m.CanPinMessages,
+		CanManageTopics:         m.CanManageTopics,
+		CanManageDirectMessages: m.CanManageDirectMessages,
+		CanManageTags:           m.CanManageTags,
+	}
+}
+
+func (m ChatMemberAdministrator) chatMemberVariant() {}
+
+// ChatMemberMember
+
+// GetStatus returns ChatMemberStatusMember.
+func (m ChatMemberMember) GetStatus() ChatMemberStatus { return ChatMemberStatusMember }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberMember) GetUser() User { return m.User }
+
+// IsInChat returns true.
+func (m ChatMemberMember) IsInChat() bool { return true }
+
+// EffectivePermissions returns given default permissions of the chat.
+func (m ChatMemberMember) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	return defaults
+}
+
+// EffectiveRights returns no administrator rights.
+func (m ChatMemberMember) EffectiveRights() ChatAdministratorRights {
+	return ChatAdministratorRights{}
+}
+
+func (m ChatMemberMember) chatMemberVariant() {}
+
+// ChatMemberRestricted
+
+// GetStatus returns ChatMemberStatusRestricted.
+func (m ChatMemberRestricted) GetStatus() ChatMemberStatus { return ChatMemberStatusRestricted }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberRestricted) GetUser() User { return m.User }
+
+// IsInChat checks if the restricted user is still a member of the chat.
+func (m ChatMemberRestricted) IsInChat() bool { return m.IsMember }
+
+// EffectivePermissions returns the permissions allowed both by the restrictions and given defaults of the chat.
+func (m ChatMemberRestricted) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	if !m.IsMember {
+		return ChatPermissions{}
+	}
+
+	// restrictions are applied on top of the default permissions of the chat
+	permission := func(own bool, fallback *bool) *bool {
+		return new(own && (fallback == nil || *fallback))
+	}
+	return ChatPermissions{
+		CanSendMessages:       permission(m.CanSendMessages, defaults.CanSendMessages),
+		CanSendAudios:         permission(m.CanSendAudios, defaults.CanSendAudios),
+		CanSendDocuments:      permission(m.CanSendDocuments, defaults.CanSendDocuments),
+		CanSendPhotos:         permission(m.CanSendPhotos, defaults.CanSendPhotos),
+		CanSendVideos:         permission(m.CanSendVideos, defaults.CanSendVideos),
+		CanSendVideoNotes:     permission(m.CanSendVideoNotes, defaults.CanSendVideoNotes),
+		CanSendVoiceNotes:     permission(m.CanSendVoiceNotes, defaults.CanSendVoiceNotes),
+		CanSendPolls:          permission(m.CanSendPolls, defaults.CanSendPolls),
+		CanSendOtherMessages:  permission(m.CanSendOtherMessages, defaults.CanSendOtherMessages),
+		CanAddWebPagePreviews: permission(m.CanAddWebPagePreviews, defaults.CanAddWebPagePreviews),
+		CanReactToMessages:    permission(m.CanReactToMessages, defaults.CanReactToMessages),
+		CanEditTag:            permission(m.CanEditTag, defaults.CanEditTag),
+		CanChangeInfo:         permission(m.CanChangeInfo, defaults.CanChangeInfo),
+		CanInviteUsers:        permission(m.CanInviteUsers, defaults.CanInviteUsers),
+		CanPinMessages:        permission(m.CanPinMessages, defaults.CanPinMessages),
+		CanManageTopics:       permission(m.CanManageTopics, defaults.CanManageTopics),
+	}
+}
+
+// EffectiveRights returns no administrator rights.
+func (m ChatMemberRestricted) EffectiveRights() ChatAdministratorRights {
+	return ChatAdministratorRights{}
+}
+
+func (m ChatMemberRestricted) chatMemberVariant() {}
+
+// ChatMemberLeft
+
+// GetStatus returns ChatMemberStatusLeft.
+func (m ChatMemberLeft) GetStatus() ChatMemberStatus { return ChatMemberStatusLeft }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberLeft) GetUser() User { return m.User }
+
+// IsInChat returns false.
+func (m ChatMemberLeft) IsInChat() bool { return false }
+
+// EffectivePermissions returns no permissions.
+func (m ChatMemberLeft) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	return ChatPermissions{}
+}
+
+// EffectiveRights returns no administrator rights.
+func (m ChatMemberLeft) EffectiveRights() ChatAdministratorRights {
+	return ChatAdministratorRights{}
+}
+
+func (m ChatMemberLeft) chatMemberVariant() {}
+
+// ChatMemberBanned
+
+// GetStatus returns ChatMemberStatusBanned.
+func (m ChatMemberBanned) GetStatus() ChatMemberStatus { return ChatMemberStatusBanned }
+
+// GetUser returns the user of the chat member.
+func (m ChatMemberBanned) GetUser() User { return m.User }
+
+// IsInChat returns false.
+func (m ChatMemberBanned) IsInChat() bool { return false }
+
+// EffectivePermissions returns no permissions.
+func (m ChatMemberBanned) EffectivePermissions(defaults ChatPermissions) ChatPermissions {
+	return ChatPermissions{}
+}
+
+// EffectiveRights returns no administrator rights.
+func (m ChatMemberBanned) EffectiveRights() ChatAdministratorRights {
+	return ChatAdministratorRights{}
+}
+
+func (m ChatMemberBanned) chatMemberVariant() {}
+
+////////////////////////////////
+// Differences of chat members
+//
+
+// ChatMemberDiff describes what changed between two states of a chat member.
+//
+// Rights and permissions are named after their json fields, eg. `can_delete_messages`.
+type ChatMemberDiff struct {
+	OldStatus ChatMemberStatus
+	NewStatus ChatMemberStatus
+
+	Joined bool // the user joined the chat
+	Left   bool // the user left, or was removed from the chat
+
+	GrantedRights []string // administrator rights which were granted
+	RevokedRights []string // administrator rights which were revoked
+
+	// permissions which were granted or revoked while the user stayed in the chat
+	// (default permissions of the chat are considered to allow everything, as they are not included in updates)
+	GrantedPermissions []string
+	RevokedPermissions []string
+}
+
+// DiffChatMembers returns the difference between given old and new states of a chat member.
+func DiffChatMembers(oldMember, newMember ChatMemberVariant) (diff ChatMemberDiff) {
+	diff.OldStatus = oldMember.GetStatus()
+	diff.NewStatus = newMember.GetStatus()
+	diff.Joined = !oldMember.IsInChat() && newMember.IsInChat()
+	diff.Left = oldMember.IsInChat() && !newMember.IsInChat()
+
+	diff.GrantedRights, diff.RevokedRights = diffFlags(
+		grantedFlags(oldMember.EffectiveRights()),
+		grantedFlags(newMember.EffectiveRights()),
+	)
+
+	if oldMember.IsInChat() && newMember.IsInChat() {
+		defaults := allChatPermissions()
+		diff.GrantedPermissions, diff.RevokedPermissions = diffFlags(
+			grantedFlags(oldMember.EffectivePermissions(defaults)),
+			grantedFlags(newMember.EffectivePermissions(defaults)),
+		)
+	}
+	return diff
+}
+
+// Diff returns the difference between the old and new states of the chat member.
+func (u ChatMemberUpdated) Diff() (diff ChatMemberDiff, err error) {
+	oldMember, err := u.OldChatMember.Variant()
+	if err != nil {
+		return diff, fmt.Errorf("failed to decode old chat member: %w", err)
+	}
+	newMember, err := u.NewChatMember.Variant()
+	if err != nil {
+		return diff, fmt.Errorf("failed to decode new chat member: %w", err)
+	}
+	return DiffChatMembers(oldMember, newMember), nil
+}
+
+// Changed checks if anything has changed.
+func (d ChatMemberDiff) Changed() bool {
+	return d.OldStatus != d.NewStatus ||
+		len(d.GrantedRights) > 0 || len(d.RevokedRights) > 0 ||
+		len(d.GrantedPermissions) > 0 || len(d.RevokedPermissions) > 0
+}
+
+// String returns a human-readable description of the difference.
+//
+//	member -> administrator (+can_delete_messages, +can_pin_messages)
+func (d ChatMemberDiff) String() string {
+	changes := []string{}
+	for _, granted := range slices.Concat(d.GrantedRights, d.GrantedPermissions) {
+		changes = append(changes, "+"+granted)
+	}
+	for _, revoked := range slices.Concat(d.RevokedRights, d.RevokedPermissions) {
+		changes = append(changes, "-"+revoked)
+	}
+
+	description := string(d.OldStatus)
+	if d.OldStatus != d.NewStatus {
+		description += " -> " + string(d.NewStatus)
+	}
+	if len(changes) > 0 {
+		description += " (" + strings.Join(changes, ", ") + ")"
+	}
+	return description
+}
+
+// json names of `bool` and `*bool` fields of given struct which are true
+func grantedFlags(v any) (flags []string) {
+	value := reflect.ValueOf(v)
+	for field := range value.Type().Fields() {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "is_anonymous" {
+			continue
+		}
+
+		fieldValue := value.FieldByIndex(field.Index)
+		switch {
+		case fieldValue.Kind() == reflect.Bool && fieldValue.Bool(),
+			fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Bool && fieldValue.Elem().Bool():
+			flags = append(flags, name)
+		}
+	}
+	return flags
+}
+
+// flags which were granted or revoked
+func diffFlags(oldFlags, newFlags []string) (granted, revoked []string) {
+	for _, flag := range newFlags {
+		if !slices.Contains(oldFlags, flag) {
+			granted = append(granted, flag)
+		}
+	}
+	for _, flag := range oldFlags {
+		if !slices.Contains(newFlags, flag) {
+			revoked = append(revoked, flag)
+		}
+	}
+	return granted, revoked
+}
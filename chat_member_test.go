@@ -0,0 +1,128 @@
+// chat_member_test.go
+//
+// offline tests of chat member variants and differences
+
+package telegrambot
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+// chat members should be decoded into their concrete variants, with their current fields.
+func TestChatMemberVariant(t *testing.T) {
+	slog.Info("testing variants of chat members...")
+
+	var member ChatMember
+	if err := json.Unmarshal([]byte(`{
+		"status": "administrator",
+		"user": {"id": 1, "is_bot": false, "first_name": "Admin"},
+		"can_be_edited": true,
+		"can_post_stories": true,
+		"can_delete_messages": true,
+		"can_pin_messages": false
+	}`), &member); err != nil {
+		t.Fatalf("failed to unmarshal chat member: %s", err)
+	}
+
+	variant, err := member.Variant()
+	if err != nil {
+		t.Fatalf("failed to get variant: %s", err)
+	}
+	switch m := variant.(type) {
+	case *ChatMemberAdministrator:
+		if !m.CanPostStories || !m.CanDeleteMessages || m.User.ID != 1 {
+			t.Errorf("unexpected administrator: %+v", m)
+		}
+	default:
+		t.Errorf("unexpected variant: %T", variant)
+	}
+	if rights := variant.EffectiveRights(); !rights.CanPostStories || rights.CanRestrictMembers {
+		t.Errorf("unexpected rights: %+v", rights)
+	}
+
+	// (modified after unmarshalled)
+	member.CanDeleteMessages = new(false)
+	if variant, err := member.Variant(); err != nil {
+		t.Errorf("failed to get variant of a modified chat member: %s", err)
+	} else if variant.EffectiveRights().CanDeleteMessages {
+		t.Errorf("expected the modified field to be reflected: %+v", variant)
+	}
+
+	// (constructed without json)
+	variant, err = ChatMember{Status: ChatMemberStatusBanned, User: User{ID: 2}}.Variant()
+	if err != nil {
+		t.Errorf("failed to get variant of a constructed chat member: %s", err)
+	} else if banned, ok := variant.(*ChatMemberBanned); !ok || banned.User.ID != 2 || banned.IsInChat() {
+		t.Errorf("unexpected variant: %+v", variant)
+	}
+
+	if _, err := DecodeChatMember([]byte(`{"status":"unknown","user":{"id":1}}`)); err == nil {
+		t.Errorf("unknown status should fail to decode")
+	}
+}
+
+// effective permissions of restricted members should be combined with the defaults of the chat.
+func TestChatMemberEffectivePermissions(t *testing.T) {
+	slog.Info("testing effective permissions of chat members...")
+
+	variant, err := DecodeChatMember([]byte(`{
+		"status": "restricted",
+		"user": {"id": 1, "is_bot": false, "first_name": "User"},
+		"is_member": true,
+		"can_send_messages": true,
+		"can_send_photos": true,
+		"until_date": 0
+	}`))
+	if err != nil {
+		t.Fatalf("failed to decode restricted member: %s", err)
+	}
+
+	defaults := ChatPermissions{CanSendMessages: new(true), CanSendPhotos: new(false)}
+	permissions := variant.EffectivePermissions(defaults)
+	if !*permissions.CanSendMessages || *permissions.CanSendPhotos || *permissions.CanSendPolls {
+		t.Errorf("unexpected effective permissions: %+v", permissions)
+	}
+
+	member := &ChatMemberMember{Status: string(ChatMemberStatusMember)}
+	if permissions := member.EffectivePermissions(defaults); permissions.CanSendPhotos == nil || *permissions.CanSendPhotos {
+		t.Errorf("members should have the default permissions, got: %+v", permissions)
+	}
+}
+
+// differences of chat member updates should describe changed statuses, rights and permissions.
+func TestChatMemberUpdatedDiff(t *testing.T) {
+	slog.Info("testing differences of chat member updates...")
+
+	var updated ChatMemberUpdated
+	if err := json.Unmarshal([]byte(`{
+		"chat": {"id": -100, "type": "supergroup"},
+		"from": {"id": 9, "is_bot": false, "first_name": "Owner"},
+		"date": 1,
+		"old_chat_member": {"status": "member", "user": {"id": 1, "is_bot": false, "first_name": "User"}},
+		"new_chat_member": {"status": "administrator", "user": {"id": 1, "is_bot": false, "first_name": "User"}, "can_delete_messages": true, "can_pin_messages": true}
+	}`), &updated); err != nil {
+		t.Fatalf("failed to unmarshal chat member update: %s", err)
+	}
+
+	diff, err := updated.Diff()
+	if err != nil {
+		t.Fatalf("failed to diff chat members: %s", err)
+	}
+	if diff.OldStatus != ChatMemberStatusMember || diff.NewStatus != ChatMemberStatusAdministrator || diff.Joined || diff.Left || !diff.Changed() {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+	if !slices.Equal(diff.GrantedRights, []string{"can_delete_messages", "can_pin_messages"}) || len(diff.RevokedRights) != 0 {
+		t.Errorf("unexpected rights in diff: %+v", diff)
+	}
+	if expected := "member -> administrator (+can_delete_messages, +can_pin_messages, -can_change_info, -can_invite_users, -can_manage_topics)"; diff.String() != expected {
+		t.Errorf("unexpected description of diff: %s", diff)
+	}
+
+	left := DiffChatMembers(&ChatMemberMember{User: User{ID: 1}}, &ChatMemberLeft{User: User{ID: 1}})
+	if !left.Left || left.Joined || len(left.RevokedPermissions) != 0 {
+		t.Errorf("unexpected diff of leaving: %+v", left)
+	}
+}
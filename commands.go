@@ -0,0 +1,405 @@
+package telegrambot
+
+// Declarative commands, synchronized with the bot's command lists per scope and language
+//
+// https://core.telegram.org/bots/features#commands
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////
+// CommandScope
+//
+
+// CommandScope is a scope where a command is visible in the command list.
+//
+// Chat ids of scopes are compared after normalized: integers and numeric strings into int64.
+//
+// https://core.telegram.org/bots/api#botcommandscope
+type CommandScope struct {
+	Type   BotCommandScopeType
+	ChatID ChatID // for BotCommandScopeTypeChat, BotCommandScopeTypeChatAdministrators, and BotCommandScopeTypeChatMember
+	UserID int64  // for BotCommandScopeTypeChatMember
+}
+
+// CommandScope values for global scopes
+var (
+	CommandScopeDefault               = CommandScope{Type: BotCommandScopeTypeDefault}
+	CommandScopeAllPrivateChats       = CommandScope{Type: BotCommandScopeTypeAllPrivateChats}
+	CommandScopeAllGroupChats         = CommandScope{Type: BotCommandScopeTypeAllGroupChats}
+	CommandScopeAllChatAdministrators = CommandScope{Type: BotCommandScopeTypeAllChatAdministrators}
+)
+
+// NewCommandScopeChat returns a CommandScope of a specific chat.
+func NewCommandScopeChat(chatID ChatID) CommandScope {
+	return CommandScope{Type: BotCommandScopeTypeChat, ChatID: normalizeScopeChatID(chatID)}
+}
+
+// NewCommandScopeChatAdministrators returns a CommandScope of administrators of a specific chat.
+func NewCommandScopeChatAdministrators(chatID ChatID) CommandScope {
+	return CommandScope{Type: BotCommandScopeTypeChatAdministrators, ChatID: normalizeScopeChatID(chatID)}
+}
+
+// NewCommandScopeChatMember returns a CommandScope of a specific member of a chat.
+func NewCommandScopeChatMember(chatID ChatID, userID int64) CommandScope {
+	return CommandScope{Type: BotCommandScopeTypeChatMember, ChatID: normalizeScopeChatID(chatID), UserID: userID}
+}
+
+// normalize given chat id of a scope (integers and numeric strings into int64, eg. 1, int32(1), and "1"),
+// so that scopes of the same chat are equal
+func normalizeScopeChatID(chatID ChatID) ChatID {
+	v := reflect.ValueOf(chatID)
+	switch {
+	case v.CanInt():
+		return v.Int()
+	case v.CanUint():
+		return int64(v.Uint())
+	case v.Kind() == reflect.String:
+		if id, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return id
+		}
+		return v.String()
+	}
+	return chatID
+}
+
+// String returns the scope as a string.
+func (s CommandScope) String() string {
+	switch s.Type {
+	case BotCommandScopeTypeChat, BotCommandScopeTypeChatAdministrators:
+		return fmt.Sprintf("%s(%v)", s.Type, s.ChatID)
+	case BotCommandScopeTypeChatMember:
+		return fmt.Sprintf("%s(%v, %d)", s.Type, s.ChatID, s.UserID)
+	}
+	return string(s.Type)
+}
+
+// key of the scope for comparisons
+//
+// (chat ids may be of non-comparable types, so scopes cannot be compared with `==`)
+func (s CommandScope) key() string {
+	return fmt.Sprintf("%s:%v:%d", s.Type, normalizeScopeChatID(s.ChatID), s.UserID)
+}
+
+// value of the scope for API requests
+func (s CommandScope) apiScope() BotCommandScope {
+	scope := BotCommandScopeDefault{Type: s.Type}
+	switch s.Type {
+	case BotCommandScopeTypeChat:
+		return BotCommandScopeChat{BotCommandScopeDefault: scope, ChatID: s.ChatID}
+	case BotCommandScopeTypeChatAdministrators:
+		return BotCommandScopeChatAdministrators{BotCommandScopeDefault: scope, ChatID: s.ChatID}
+	case BotCommandScopeTypeChatMember:
+		return BotCommandScopeChatMember{BotCommandScopeDefault: scope, ChatID: s.ChatID, UserID: s.UserID}
+	}
+	return scope
+}
+
+////////////////////////////////
+// Command
+//
+
+// Command is a declaration of a command, with its handler and descriptions.
+type Command struct {
+	Name         string            // name of the command (without the leading '/')
+	Description  string            // default description
+	Descriptions map[string]string // descriptions by two-letter ISO 639-1 language codes
+
+	Scopes      []CommandScope // scopes where the command is listed (default: CommandScopeDefault)
+	IsEphemeral bool           // whether the command is ephemeral
+
+	Policy *Policy // access policy of the handler (nil for no restriction)
+
+	Handler func(b *Bot, update Update, args string)
+}
+
+// description of the command in given language
+func (c Command) description(languageCode string) string {
+	if description, exists := c.Descriptions[languageCode]; exists {
+		return description
+	}
+	return c.Description
+}
+
+// scopes of the command
+func (c Command) scopes() []CommandScope {
+	if len(c.Scopes) == 0 {
+		return []CommandScope{CommandScopeDefault}
+	}
+
+	// (scopes may be declared without constructors)
+	scopes := make([]CommandScope, 0, len(c.Scopes))
+	for _, scope := range c.Scopes {
+		scope.ChatID = normalizeScopeChatID(scope.ChatID)
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// check if the command is listed in given scope
+func (c Command) listedIn(scope CommandScope) bool {
+	key := scope.key()
+	return slices.ContainsFunc(c.scopes(), func(s CommandScope) bool { return s.key() == key })
+}
+
+// AddCommand declares given commands, and adds their handlers with `AddCommandHandler`.
+//
+// Declared commands are listed with `SyncCommands`, and in `HelpText`.
+func (b *Bot) AddCommand(commands ...Command) {
+	for _, command := range commands {
+		command.Name = strings.TrimPrefix(command.Name, "/")
+
+		b.commands = slices.DeleteFunc(b.commands, func(c Command) bool { return c.Name == command.Name })
+		b.commands = append(b.commands, command)
+
+		if command.Handler != nil {
+			handler := command.Handler
+			if command.Policy != nil {
+				handler = command.Policy.Command(handler)
+			}
+			b.AddCommandHandler(command.Name, handler)
+		}
+	}
+}
+
+// HelpCommand returns a command which replies with the help text of declared commands,
+// in the language of the user, and of the scopes which apply to the chat and the user.
+func HelpCommand(description string, scopes ...CommandScope) Command {
+	return Command{
+		Name:        "help",
+		Description: description,
+		Scopes:      scopes,
+		Handler: func(b *Bot, update Update, args string) {
+			message, _ := update.GetMessage()
+			if message == nil {
+				return
+			}
+
+			languageCode := ""
+			if message.From != nil && message.From.LanguageCode != nil {
+				languageCode = *message.From.LanguageCode
+			}
+
+			ctx := context.Background()
+			if _, err := replyToMessage(ctx, b, *message, b.HelpText(languageCode, b.commandScopesOf(ctx, *message)...)); err != nil {
+				b.error("failed to send help text: %s", err)
+			}
+		},
+	}
+}
+
+// reply to given message with text, in the same thread
+func replyToMessage(ctx context.Context, b *Bot, message Message, text string) (APIResponse[Message], error) {
+	options := OptionsSendMessage{}.SetReplyParameters(NewReplyParameters(message.MessageID))
+	if message.MessageThreadID != nil && message.IsTopicMessage != nil && *message.IsTopicMessage {
+		options = options.SetMessageThreadID(*message.MessageThreadID)
+	}
+	return b.SendMessage(ctx, message.Chat.ID, text, options)
+}
+
+// HelpText returns a help text of declared commands in given language (empty for the default one).
+//
+// With `scopes` (in the order of priority), only the commands of the first scope which has any declared command are listed,
+// as Telegram shows the command list of the narrowest scope. Without them, all declared commands are listed.
+//
+//	/start - start the bot
+//	/help - show this help
+func (b *Bot) HelpText(languageCode string, scopes ...CommandScope) string {
+	commands := b.commands
+	for _, scope := range scopes {
+		if commands = slices.DeleteFunc(slices.Clone(b.commands), func(c Command) bool { return !c.listedIn(scope) }); len(commands) > 0 {
+			break
+		}
+	}
+
+	lines := []string{}
+	for _, command := range commands {
+		lines = append(lines, "/"+command.Name+" - "+command.description(languageCode))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// scopes which apply to the chat and the sender of given message, in the order of priority
+//
+// https://core.telegram.org/bots/api#determining-list-of-commands
+func (b *Bot) commandScopesOf(ctx context.Context, message Message) (scopes []CommandScope) {
+	chatID := message.Chat.ID
+
+	if message.Chat.Type == ChatTypePrivate {
+		return []CommandScope{NewCommandScopeChat(chatID), CommandScopeAllPrivateChats, CommandScopeDefault}
+	}
+
+	// (administrators are fetched only when any command is listed for them)
+	isAdmin := false
+	if message.From != nil && slices.ContainsFunc(b.commands, func(c Command) bool {
+		return c.listedIn(NewCommandScopeChatAdministrators(chatID)) || c.listedIn(CommandScopeAllChatAdministrators)
+	}) {
+		rights, err := b.AdministratorRights(ctx, chatID, message.From.ID)
+		if err != nil {
+			b.error("failed to get administrator rights for help text: %s", err)
+		}
+		isAdmin = rights != nil
+	}
+
+	if message.From != nil {
+		scopes = append(scopes, NewCommandScopeChatMember(chatID, message.From.ID))
+	}
+	if isAdmin {
+		scopes = append(scopes, NewCommandScopeChatAdministrators(chatID))
+	}
+	scopes = append(scopes, NewCommandScopeChat(chatID))
+	if isAdmin {
+		scopes = append(scopes, CommandScopeAllChatAdministrators)
+	}
+	return append(scopes, CommandScopeAllGroupChats, CommandScopeDefault)
+}
+
+////////////////////////////////
+// Synchronization
+//
+
+// CommandSyncChange is a change applied by SyncCommands.
+type CommandSyncChange struct {
+	Scope        CommandScope
+	LanguageCode string
+	Commands     []BotCommand // new commands (empty when deleted)
+}
+
+// commandList is a desired command list of a scope in a language
+type commandList struct {
+	scope        CommandScope
+	languageCode string
+	commands     []BotCommand
+}
+
+// commandListKey is a key of a command list
+type commandListKey struct {
+	scope        string // key of the scope
+	languageCode string
+}
+
+// desired command lists of declared commands, by scopes and languages
+func (b *Bot) commandLists() (lists []commandList) {
+	scopes := []CommandScope{}
+	languages := []string{""}
+	for _, command := range b.commands {
+		for _, scope := range command.scopes() {
+			if !slices.ContainsFunc(scopes, func(s CommandScope) bool { return s.key() == scope.key() }) {
+				scopes = append(scopes, scope)
+			}
+		}
+		for languageCode := range command.Descriptions {
+			if !slices.Contains(languages, languageCode) {
+				languages = append(languages, languageCode)
+			}
+		}
+	}
+	slices.Sort(languages[1:])
+
+	listed := map[commandListKey]bool{}
+	for _, scope := range scopes {
+		for _, languageCode := range languages {
+			list := []BotCommand{}
+			dedicated := languageCode == "" // lists of languages are needed only with dedicated descriptions
+			for _, command := range b.commands {
+				if !command.listedIn(scope) {
+					continue
+				}
+				if _, exists := command.Descriptions[languageCode]; exists {
+					dedicated = true
+				}
+
+				botCommand := BotCommand{
+					Command:     command.Name,
+					Description: command.description(languageCode),
+				}
+				if command.IsEphemeral {
+					botCommand.IsEphemeral = new(true)
+				}
+				list = append(list, botCommand)
+			}
+
+			if dedicated {
+				listed[commandListKey{scope: scope.key(), languageCode: languageCode}] = true
+				lists = append(lists, commandList{scope: scope, languageCode: languageCode, commands: list})
+			}
+		}
+	}
+
+	// global scopes without declared commands should be emptied
+	for _, scope := range []CommandScope{CommandScopeDefault, CommandScopeAllPrivateChats, CommandScopeAllGroupChats, CommandScopeAllChatAdministrators} {
+		for _, languageCode := range languages {
+			if !listed[commandListKey{scope: scope.key(), languageCode: languageCode}] {
+				lists = append(lists, commandList{scope: scope, languageCode: languageCode})
+			}
+		}
+	}
+
+	return lists
+}
+
+// SyncCommands compares command lists of declared commands with the current ones (fetched with `GetMyCommands`)
+// for each scope and language, and applies only the changes with `SetMyCommands` or `DeleteMyCommands`.
+//
+// Command lists of global scopes without declared commands are deleted,
+// but lists of other scopes (eg. specific chats) or languages which are not declared anymore cannot be detected.
+//
+// https://core.telegram.org/bots/api#setmycommands
+func (b *Bot) SyncCommands(ctx context.Context) (changes []CommandSyncChange, err error) {
+	for _, list := range b.commandLists() {
+		desired := list.commands
+
+		getOptions := OptionsGetMyCommands{}.SetScope(list.scope.apiScope())
+		if list.languageCode != "" {
+			getOptions = getOptions.SetLanguageCode(list.languageCode)
+		}
+		current, err := b.GetMyCommands(ctx, getOptions)
+		if err != nil {
+			return changes, fmt.Errorf("failed to get commands of %s (language: '%s'): %w", list.scope, list.languageCode, err)
+		}
+		if current.Result != nil && sameBotCommands(*current.Result, desired) || current.Result == nil && len(desired) == 0 {
+			continue
+		}
+
+		if len(desired) == 0 {
+			deleteOptions := OptionsDeleteMyCommands{}.SetScope(list.scope.apiScope())
+			if list.languageCode != "" {
+				deleteOptions = deleteOptions.SetLanguageCode(list.languageCode)
+			}
+			if _, err := b.DeleteMyCommands(ctx, deleteOptions); err != nil {
+				return changes, fmt.Errorf("failed to delete commands of %s (language: '%s'): %w", list.scope, list.languageCode, err)
+			}
+		} else {
+			setOptions := OptionsSetMyCommands{}.SetScope(list.scope.apiScope())
+			if list.languageCode != "" {
+				setOptions = setOptions.SetLanguageCode(list.languageCode)
+			}
+			if _, err := b.SetMyCommands(ctx, desired, setOptions); err != nil {
+				return changes, fmt.Errorf("failed to set commands of %s (language: '%s'): %w", list.scope, list.languageCode, err)
+			}
+		}
+
+		changes = append(changes, CommandSyncChange{
+			Scope:        list.scope,
+			LanguageCode: list.languageCode,
+			Commands:     desired,
+		})
+	}
+
+	return changes, nil
+}
+
+// check if given command lists are the same
+func sameBotCommands(a, b []BotCommand) bool {
+	return slices.EqualFunc(a, b, func(x, y BotCommand) bool {
+		return x.Command == y.Command &&
+			x.Description == y.Description &&
+			(x.IsEphemeral != nil && *x.IsEphemeral) == (y.IsEphemeral != nil && *y.IsEphemeral)
+	})
+}
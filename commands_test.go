@@ -0,0 +1,213 @@
+// commands_test.go
+//
+// offline tests of declarative commands
+
+package telegrambot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// a fake server which keeps command lists by scopes and languages
+type testCommandsServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	lists map[string]string // json arrays of commands by scopes and languages
+	sets  int
+	dels  int
+}
+
+// returns a new testCommandsServer
+func newTestCommandsServer() *testCommandsServer {
+	s := &testCommandsServer{
+		lists: map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		key := r.FormValue("scope") + "/" + r.FormValue("language_code")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch method {
+		case "getMyCommands":
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":%s}`, cmpOrEmptyArray(s.lists[key]))
+		case "setMyCommands":
+			s.lists[key] = r.FormValue("commands")
+			s.sets++
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		case "deleteMyCommands":
+			delete(s.lists, key)
+			s.dels++
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		}
+	}))
+	return s
+}
+
+// returns given json array, or an empty one
+func cmpOrEmptyArray(array string) string {
+	if array == "" {
+		return "[]"
+	}
+	return array
+}
+
+// declared commands should be synchronized per scope and language, applying only the changes.
+func TestSyncCommands(t *testing.T) {
+	slog.Info("testing synchronization of declared commands...")
+
+	server := newTestCommandsServer()
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	client.AddCommand(
+		Command{
+			Name:         "/start",
+			Description:  "start the bot",
+			Descriptions: map[string]string{"ko": "봇 시작"},
+			Scopes:       []CommandScope{CommandScopeAllPrivateChats},
+		},
+		Command{
+			Name:        "ban",
+			Description: "ban a user",
+			Scopes:      []CommandScope{CommandScopeAllChatAdministrators, NewCommandScopeChat("@group")},
+			IsEphemeral: true,
+		},
+		HelpCommand("show this help", CommandScopeAllPrivateChats, CommandScopeAllChatAdministrators),
+	)
+
+	// (global scopes with stale commands should be emptied)
+	server.lists[`{"type":"all_group_chats"}/`] = `[{"command":"old","description":"stale"}]`
+
+	changes, err := client.SyncCommands(context.TODO())
+	if err != nil {
+		t.Fatalf("failed to sync commands: %s", err)
+	}
+	if len(changes) != 5 || server.sets != 4 || server.dels != 1 {
+		t.Errorf("unexpected changes: %+v (sets: %d, dels: %d)", changes, server.sets, server.dels)
+	}
+
+	var commands []BotCommand
+	if err := json.Unmarshal([]byte(server.lists[`{"type":"all_private_chats"}/ko`]), &commands); err != nil {
+		t.Errorf("failed to decode commands in korean: %s", err)
+	} else if len(commands) != 2 || commands[0].Command != "start" || commands[0].Description != "봇 시작" || commands[1].Description != "show this help" {
+		t.Errorf("unexpected commands in korean: %+v", commands)
+	}
+	commands = nil
+	if err := json.Unmarshal([]byte(server.lists[`{"type":"all_chat_administrators"}/`]), &commands); err != nil {
+		t.Errorf("failed to decode commands of administrators: %s", err)
+	} else if len(commands) != 2 || commands[0].IsEphemeral == nil || !*commands[0].IsEphemeral {
+		t.Errorf("unexpected commands of administrators: %+v", commands)
+	}
+	if _, exists := server.lists[`{"type":"all_group_chats"}/`]; exists {
+		t.Errorf("stale commands of group chats should be deleted")
+	}
+
+	// nothing should be changed on the second sync
+	changes, err = client.SyncCommands(context.TODO())
+	if err != nil {
+		t.Fatalf("failed to sync commands again: %s", err)
+	}
+	if len(changes) != 0 || server.sets != 4 || server.dels != 1 {
+		t.Errorf("expected no changes, got: %+v", changes)
+	}
+}
+
+// help texts should be generated in the language of users.
+func TestHelpText(t *testing.T) {
+	slog.Info("testing help texts of declared commands...")
+
+	client := NewClient(testToken)
+	client.AddCommand(
+		Command{Name: "start", Description: "start the bot", Descriptions: map[string]string{"ko": "봇 시작"}},
+		HelpCommand("show this help"),
+	)
+
+	if text := client.HelpText(""); text != "/start - start the bot\n/help - show this help" {
+		t.Errorf("unexpected help text: %q", text)
+	}
+	if text := client.HelpText("ko"); text != "/start - 봇 시작\n/help - show this help" {
+		t.Errorf("unexpected help text in korean: %q", text)
+	}
+}
+
+// help texts should list only the commands of the narrowest scope which applies to the chat.
+func TestHelpTextOfScopes(t *testing.T) {
+	slog.Info("testing help texts of declared commands in scopes...")
+
+	client := NewClient(testToken)
+	client.AddCommand(
+		Command{Name: "start", Description: "start the bot"},
+		Command{Name: "settings", Description: "change settings", Scopes: []CommandScope{CommandScopeAllPrivateChats}},
+		Command{Name: "ban", Description: "ban a user", Scopes: []CommandScope{{Type: BotCommandScopeTypeChat, ChatID: "-100123"}}},
+		HelpCommand("show this help"),
+	)
+
+	private := Message{Chat: Chat{ID: 1, Type: ChatTypePrivate}, From: &User{ID: 1}}
+	if text := client.HelpText("", client.commandScopesOf(context.TODO(), private)...); text != "/settings - change settings" {
+		t.Errorf("unexpected help text in a private chat: %q", text)
+	}
+	group := Message{Chat: Chat{ID: -100123, Type: ChatTypeSupergroup}, From: &User{ID: 1}}
+	if text := client.HelpText("", client.commandScopesOf(context.TODO(), group)...); text != "/ban - ban a user" {
+		t.Errorf("unexpected help text in a group chat: %q", text)
+	}
+	other := Message{Chat: Chat{ID: -100456, Type: ChatTypeSupergroup}, From: &User{ID: 1}}
+	if text := client.HelpText("", client.commandScopesOf(context.TODO(), other)...); text != "/start - start the bot\n/help - show this help" {
+		t.Errorf("unexpected help text in another group chat: %q", text)
+	}
+}
+
+// scopes with chat ids of non-comparable types should not panic.
+func TestCommandScopeNonComparableChatID(t *testing.T) {
+	slog.Info("testing command scopes with non-comparable chat ids...")
+
+	client := NewClient(testToken)
+	client.AddCommand(
+		Command{Name: "start", Description: "start the bot", Scopes: []CommandScope{{Type: BotCommandScopeTypeChat, ChatID: []int{1}}}},
+		Command{Name: "stop", Description: "stop the bot", Scopes: []CommandScope{{Type: BotCommandScopeTypeChat, ChatID: []int{1}}}},
+	)
+
+	lists := client.commandLists()
+	if len(lists) != 5 || len(lists[0].commands) != 2 {
+		t.Errorf("unexpected command lists: %+v", lists)
+	}
+	if text := client.HelpText("", CommandScope{Type: BotCommandScopeTypeChat, ChatID: []int{1}}); text != "/start - start the bot\n/stop - stop the bot" {
+		t.Errorf("unexpected help text: %q", text)
+	}
+}
+
+// scopes of the same chat should be equal, regardless of types of their chat ids.
+func TestCommandScopeChatID(t *testing.T) {
+	slog.Info("testing normalization of chat ids of command scopes...")
+
+	if scope := NewCommandScopeChat(int32(-100123)); scope != NewCommandScopeChat(int64(-100123)) || scope != NewCommandScopeChat("-100123") {
+		t.Errorf("expected scopes of the same chat to be equal: %+v", scope)
+	}
+	if scope := NewCommandScopeChatMember("@group", 1); scope.ChatID != "@group" {
+		t.Errorf("expected the username to be kept: %+v", scope)
+	}
+
+	command := Command{Name: "ban", Scopes: []CommandScope{
+		{Type: BotCommandScopeTypeChatAdministrators, ChatID: 1},
+		NewCommandScopeChatAdministrators(uint8(1)),
+	}}
+	if scopes := command.scopes(); scopes[0] != scopes[1] {
+		t.Errorf("expected scopes declared without constructors to be normalized: %+v", scopes)
+	}
+}
@@ -0,0 +1,658 @@
+package telegrambot
+
+// Multi-step conversations as finite-state machines, with pluggable storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	conversationLockStripes      = 64 // number of mutexes for serializing updates of conversations
+	maxConversationTransitions   = 10 // max number of chained transitions in a single update
+	defaultConversationCancelCmd = "/cancel"
+)
+
+// ErrConversationNotAdded is returned when a conversation is begun before it is added to a bot.
+var ErrConversationNotAdded = errors.New("conversation is not added to a bot")
+
+////////////////////////////////
+// ConversationKey & ConversationSession
+//
+
+// ConversationKey identifies a conversation with a user in a chat
+// (and in a forum topic, or through a business connection).
+type ConversationKey struct {
+	ChatID               int64
+	UserID               int64
+	ThreadID             int64  // id of the forum topic (0 if none)
+	BusinessConnectionID string // id of the business connection (empty if none)
+}
+
+// String returns the key as a string, for storing sessions.
+func (k ConversationKey) String() string {
+	return strings.Join([]string{
+		strconv.FormatInt(k.ChatID, 10),
+		strconv.FormatInt(k.UserID, 10),
+		strconv.FormatInt(k.ThreadID, 10),
+		k.BusinessConnectionID,
+	}, ":")
+}
+
+// ConversationKeyFromUpdate returns the conversation key of given update.
+//
+// Only messages, business messages, and callback queries on messages have keys.
+func ConversationKeyFromUpdate(update Update) (key ConversationKey, ok bool) {
+	var message *Message
+	var from *User
+	if update.HasMessage() {
+		message, from = update.Message, update.Message.From
+	} else if update.BusinessMessage != nil {
+		message, from = update.BusinessMessage, update.BusinessMessage.From
+	} else if update.HasCallbackQuery() && update.CallbackQuery.Message != nil {
+		message, from = (*Message)(update.CallbackQuery.Message), &update.CallbackQuery.From
+	}
+	if message == nil || from == nil {
+		return key, false
+	}
+
+	key = ConversationKey{
+		ChatID: message.Chat.ID,
+		UserID: from.ID,
+	}
+	if message.MessageThreadID != nil && message.IsTopicMessage != nil && *message.IsTopicMessage {
+		key.ThreadID = *message.MessageThreadID
+	}
+	if message.BusinessConnectionID != nil {
+		key.BusinessConnectionID = *message.BusinessConnectionID
+	}
+	return key, true
+}
+
+// ConversationSession is a stored state of a conversation.
+type ConversationSession struct {
+	Key       ConversationKey   `json:"key"`
+	State     string            `json:"state"`
+	Data      map[string]string `json:"data,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+// check if the session is expired
+func (s ConversationSession) expired(now time.Time) bool {
+	return s.ExpiresAt != nil && now.After(*s.ExpiresAt)
+}
+
+////////////////////////////////
+// ConversationStore
+//
+
+// ConversationStore is a storage of conversation sessions.
+type ConversationStore interface {
+	// returns the session of given key, or nil if there is none
+	Load(ctx context.Context, key string) (*ConversationSession, error)
+
+	// saves the session with given key
+	Save(ctx context.Context, key string, session ConversationSession) error
+
+	// deletes the session of given key
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryConversationStore is an in-memory ConversationStore.
+type MemoryConversationStore struct {
+	mu       sync.Mutex
+	sessions map[string]ConversationSession
+}
+
+// NewMemoryConversationStore returns a new MemoryConversationStore.
+func NewMemoryConversationStore() *MemoryConversationStore {
+	return &MemoryConversationStore{
+		sessions: map[string]ConversationSession{},
+	}
+}
+
+// Load returns the session of given key.
+func (s *MemoryConversationStore) Load(_ context.Context, key string) (*ConversationSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, exists := s.sessions[key]; exists {
+		return &session, nil
+	}
+	return nil, nil
+}
+
+// Save saves the session with given key.
+func (s *MemoryConversationStore) Save(_ context.Context, key string, session ConversationSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[key] = session
+	return nil
+}
+
+// Delete deletes the session of given key.
+func (s *MemoryConversationStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, key)
+	return nil
+}
+
+// FileConversationStore is a ConversationStore which persists sessions in a JSON file,
+// so that conversations survive restarts.
+type FileConversationStore struct {
+	MemoryConversationStore
+
+	path string
+}
+
+// NewFileConversationStore returns a new FileConversationStore with given file path,
+// loading sessions from the file if it exists.
+func NewFileConversationStore(path string) (*FileConversationStore, error) {
+	s := &FileConversationStore{
+		MemoryConversationStore: MemoryConversationStore{
+			sessions: map[string]ConversationSession{},
+		},
+		path: path,
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read conversations file: %w", err)
+	}
+	if err := json.Unmarshal(bytes, &s.sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse conversations file: %w", err)
+	}
+	return s, nil
+}
+
+// Save saves the session with given key, and writes all sessions to the file.
+func (s *FileConversationStore) Save(_ context.Context, key string, session ConversationSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[key] = session
+	return s.persist()
+}
+
+// Delete deletes the session of given key, and writes all sessions to the file.
+func (s *FileConversationStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.sessions[key]; !exists {
+		return nil
+	}
+	delete(s.sessions, key)
+	return s.persist()
+}
+
+// write all sessions to the file atomically (should be called with the lock held)
+func (s *FileConversationStore) persist() error {
+	bytes, err := json.Marshal(s.sessions)
+	if err != nil {
+		return fmt.Errorf("failed to serialize conversations: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bytes); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write conversations: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write conversations: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+////////////////////////////////
+// Conversation
+//
+
+// ConversationHandler is a function for handling an update in a conversation.
+type ConversationHandler func(ctx context.Context, c *ConversationContext) error
+
+// ConversationState is a declared state of a Conversation.
+type ConversationState struct {
+	// called when the conversation enters this state (eg. for prompting)
+	OnEnter ConversationHandler
+
+	// called with messages in this state
+	OnMessage ConversationHandler
+
+	// called with callback queries in this state
+	OnCallback ConversationHandler
+
+	// the conversation times out if no update arrives within this duration in this state (0 for no timeout)
+	//
+	// NOTE: timeouts of sessions restored from a store after restarts are detected when the next update arrives.
+	Timeout time.Duration
+}
+
+// Conversation is a multi-step dialog with users, as a finite-state machine.
+//
+//	conv := NewConversation(NewMemoryConversationStore())
+//	conv.EntryCommands = []string{"/order"}
+//	conv.Initial = "name"
+//	conv.AddState("name", ConversationState{
+//		OnEnter: func(ctx context.Context, c *ConversationContext) error {
+//			_, err := c.Reply(ctx, "What's your name?", nil)
+//			return err
+//		},
+//		OnMessage: func(ctx context.Context, c *ConversationContext) error {
+//			c.Set("name", *c.Message().Text)
+//			c.Transition("confirm")
+//			return nil
+//		},
+//		Timeout: 5 * time.Minute,
+//	})
+//	bot.AddConversation(conv)
+type Conversation struct {
+	Initial       string   // state which entry commands start with
+	EntryCommands []string // commands which start the conversation (eg. "/order")
+
+	CancelCommands []string // commands which cancel the conversation (default: "/cancel")
+
+	OnCancel  ConversationHandler // called when the conversation is cancelled
+	OnTimeout ConversationHandler // called when the conversation times out (with a zero-valued update)
+	Fallback  ConversationHandler // called with updates which the current state does not handle
+
+	bot    *Bot
+	store  ConversationStore
+	states map[string]ConversationState
+	locks  [conversationLockStripes]sync.Mutex
+}
+
+// NewConversation returns a new Conversation with given store.
+func NewConversation(store ConversationStore) *Conversation {
+	return &Conversation{
+		CancelCommands: []string{defaultConversationCancelCmd},
+
+		store:  store,
+		states: map[string]ConversationState{},
+	}
+}
+
+// AddState declares a state with given name.
+func (c *Conversation) AddState(name string, state ConversationState) {
+	c.states[name] = state
+}
+
+// Begin starts a conversation with given key at given state, replacing the current one if any.
+//
+// The conversation should be added to a bot with `AddConversation` before, or ErrConversationNotAdded is returned.
+func (c *Conversation) Begin(ctx context.Context, key ConversationKey, state string, data map[string]string) error {
+	if c.bot == nil {
+		return ErrConversationNotAdded
+	}
+
+	lock := c.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	cc := c.newContext(Update{}, ConversationSession{Key: key, Data: data})
+	cc.Transition(state)
+	return c.finish(ctx, cc, nil)
+}
+
+// Session returns the current (unexpired) session of given key, or nil if there is none.
+func (c *Conversation) Session(ctx context.Context, key ConversationKey) (*ConversationSession, error) {
+	session, err := c.store.Load(ctx, key.String())
+	if err != nil || session == nil || session.expired(time.Now()) {
+		return nil, err
+	}
+	return session, nil
+}
+
+// lock for given key
+func (c *Conversation) lock(key ConversationKey) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.String()))
+	return &c.locks[h.Sum32()%conversationLockStripes]
+}
+
+// returns a handler for given update, if the conversation handles it
+func (c *Conversation) match(ctx context.Context, update Update) (handle func(), matched bool) {
+	key, ok := ConversationKeyFromUpdate(update)
+	if !ok {
+		return nil, false
+	}
+
+	command := ""
+	if message := conversationMessage(update); message != nil && message.Text != nil {
+		var username string
+		command, username = commandOf(*message.Text)
+
+		// commands addressed to other bots (eg. `/cancel@otherbot`) are ignored
+		if username != "" && !c.bot.isUsername(ctx, username) {
+			command = ""
+		}
+	}
+
+	session, err := c.store.Load(ctx, key.String())
+	if err != nil {
+		return nil, false
+	}
+	active := session != nil && !session.expired(time.Now())
+
+	switch {
+	case active && command != "" && containsCommand(c.CancelCommands, command):
+		return func() { c.cancel(ctx, update, key) }, true
+	case active && c.handlerFor(session.State, update) != nil:
+		return func() { c.handle(ctx, update, key) }, true
+	case command != "" && containsCommand(c.EntryCommands, command):
+		return func() { c.enter(ctx, update, key) }, true
+	case session != nil && !active:
+		return func() { c.expire(ctx, key, session.ExpiresAt) }, false // NOTE: the update is handled by others
+	}
+	return nil, false
+}
+
+// handler of given state for given update
+func (c *Conversation) handlerFor(state string, update Update) ConversationHandler {
+	var handler ConversationHandler
+	if s, exists := c.states[state]; exists {
+		if update.HasCallbackQuery() {
+			handler = s.OnCallback
+		} else {
+			handler = s.OnMessage
+		}
+	}
+	if handler == nil {
+		handler = c.Fallback
+	}
+	return handler
+}
+
+// handle an update in an active conversation
+func (c *Conversation) handle(ctx context.Context, update Update, key ConversationKey) {
+	c.withSession(ctx, key, func(session *ConversationSession) (*ConversationContext, error) {
+		if session == nil || session.expired(time.Now()) {
+			return nil, nil // (ended or expired while waiting for the lock)
+		}
+
+		cc := c.newContext(update, *session)
+		handler := c.handlerFor(session.State, update)
+		if handler == nil {
+			return nil, nil
+		}
+		return cc, handler(ctx, cc)
+	})
+}
+
+// start a conversation with an entry command
+func (c *Conversation) enter(ctx context.Context, update Update, key ConversationKey) {
+	c.withSession(ctx, key, func(*ConversationSession) (*ConversationContext, error) {
+		cc := c.newContext(update, ConversationSession{Key: key})
+		cc.Transition(c.Initial)
+		return cc, nil
+	})
+}
+
+// cancel a conversation
+func (c *Conversation) cancel(ctx context.Context, update Update, key ConversationKey) {
+	c.withSession(ctx, key, func(session *ConversationSession) (*ConversationContext, error) {
+		if session == nil {
+			return nil, nil
+		}
+
+		cc := c.newContext(update, *session)
+		cc.End()
+		if c.OnCancel != nil {
+			return cc, c.OnCancel(ctx, cc)
+		}
+		return cc, nil
+	})
+}
+
+// time out a conversation, if it is still expired at given time
+func (c *Conversation) expire(ctx context.Context, key ConversationKey, expiresAt *time.Time) {
+	c.withSession(ctx, key, func(session *ConversationSession) (*ConversationContext, error) {
+		if session == nil || !session.expired(time.Now()) || expiresAt == nil || session.ExpiresAt == nil || !session.ExpiresAt.Equal(*expiresAt) {
+			return nil, nil // (already ended, or updated since)
+		}
+
+		cc := c.newContext(Update{}, *session)
+		cc.End()
+		if c.OnTimeout != nil {
+			return cc, c.OnTimeout(ctx, cc)
+		}
+		return cc, nil
+	})
+}
+
+// run fn with the session of given key (with the lock held), and store the result
+func (c *Conversation) withSession(ctx context.Context, key ConversationKey, fn func(session *ConversationSession) (*ConversationContext, error)) {
+	lock := c.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	session, err := c.store.Load(ctx, key.String())
+	if err != nil {
+		c.bot.error("failed to load conversation (%s): %s", key, err)
+		return
+	}
+
+	cc, err := fn(session)
+	if cc == nil {
+		return
+	}
+	if err := c.finish(ctx, cc, err); err != nil {
+		c.bot.error("failed to handle conversation (%s): %s", key, err)
+	}
+}
+
+// run OnEnter handlers of transitioned states, and store the session
+func (c *Conversation) finish(ctx context.Context, cc *ConversationContext, handlerErr error) error {
+	errs := []error{handlerErr}
+
+	for range maxConversationTransitions {
+		if cc.ended || cc.next == "" {
+			break
+		}
+
+		state, exists := c.states[cc.next]
+		if !exists {
+			errs = append(errs, fmt.Errorf("no such state: %s", cc.next))
+			cc.ended = true
+			break
+		}
+
+		cc.session.State, cc.next = cc.next, ""
+		cc.session.ExpiresAt = nil
+		if state.Timeout > 0 {
+			cc.session.ExpiresAt = new(time.Now().Add(state.Timeout))
+		}
+
+		if state.OnEnter != nil {
+			errs = append(errs, state.OnEnter(ctx, cc))
+		}
+	}
+
+	key := cc.session.Key
+	if cc.ended {
+		errs = append(errs, c.store.Delete(ctx, key.String()))
+	} else {
+		if cc.session.ExpiresAt != nil {
+			c.scheduleTimeout(key, *cc.session.ExpiresAt)
+		}
+		errs = append(errs, c.store.Save(ctx, key.String(), cc.session))
+	}
+
+	return errors.Join(errs...)
+}
+
+// schedule a timeout of given key
+func (c *Conversation) scheduleTimeout(key ConversationKey, expiresAt time.Time) {
+	time.AfterFunc(time.Until(expiresAt)+time.Millisecond, func() {
+		c.expire(context.Background(), key, &expiresAt)
+	})
+}
+
+// returns a new context of given session
+func (c *Conversation) newContext(update Update, session ConversationSession) *ConversationContext {
+	if session.Data == nil {
+		session.Data = map[string]string{}
+	}
+	return &ConversationContext{
+		Bot:     c.bot,
+		Update:  update,
+		Key:     session.Key,
+		session: session,
+	}
+}
+
+// AddConversation adds a conversation for handling updates.
+//
+// Updates in active conversations (including cancel commands) and entry commands
+// are handled by conversations before command handlers and handlers by type.
+func (b *Bot) AddConversation(conversation *Conversation) {
+	conversation.bot = b
+	b.conversations = append(b.conversations, conversation)
+}
+
+// checks if given update is handled by any conversation and handle it (returns true if handled)
+func handleUpdateAsConversation(b *Bot, update Update) bool {
+	for _, conversation := range b.conversations {
+		handle, matched := conversation.match(context.Background(), update)
+		if handle != nil {
+			b.runHandler(handle)
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// message of given update for conversations
+func conversationMessage(update Update) *Message {
+	if update.HasMessage() {
+		return update.Message
+	}
+	return update.BusinessMessage
+}
+
+// returns the command of given text and the username it is addressed to (eg. `/order@mybot`),
+// or "" if it is not a command
+func commandOf(text string) (command, username string) {
+	if !strings.HasPrefix(text, "/") {
+		return "", ""
+	}
+	command, username, _ = strings.Cut(strings.Fields(text)[0], "@")
+	return command, username
+}
+
+// check if given username is the bot's
+func (b *Bot) isUsername(ctx context.Context, username string) bool {
+	botUsername, err := b.botUsername(ctx)
+	if err != nil {
+		b.error("failed to get the bot's username: %s", err)
+		return false
+	}
+	return strings.EqualFold(username, botUsername)
+}
+
+// check if given command is one of `commands`
+func containsCommand(commands []string, command string) bool {
+	for _, cmd := range commands {
+		if !strings.HasPrefix(cmd, "/") {
+			cmd = "/" + cmd
+		}
+		if cmd == command {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////
+// ConversationContext
+//
+
+// ConversationContext is a conversation being handled.
+type ConversationContext struct {
+	Bot    *Bot
+	Update Update // (zero value in OnTimeout handlers)
+	Key    ConversationKey
+
+	session ConversationSession
+	next    string
+	ended   bool
+}
+
+// State returns the current state.
+func (c *ConversationContext) State() string {
+	return c.session.State
+}
+
+// Transition moves the conversation to given state after the current handler returns.
+func (c *ConversationContext) Transition(state string) {
+	c.next = state
+}
+
+// End ends the conversation after the current handler returns.
+func (c *ConversationContext) End() {
+	c.ended = true
+}
+
+// Get returns the stored value of given key.
+func (c *ConversationContext) Get(key string) string {
+	return c.session.Data[key]
+}
+
+// Set stores a value with given key.
+func (c *ConversationContext) Set(key, value string) {
+	c.session.Data[key] = value
+}
+
+// Message returns the message of the update, or nil if there is none.
+func (c *ConversationContext) Message() *Message {
+	return conversationMessage(c.Update)
+}
+
+// CallbackQuery returns the callback query of the update, or nil if there is none.
+func (c *ConversationContext) CallbackQuery() *CallbackQuery {
+	return c.Update.CallbackQuery
+}
+
+// Reply sends a message to the chat (and the forum topic, or through the business connection) of the conversation.
+func (c *ConversationContext) Reply(ctx context.Context, text string, options OptionsSendMessage) (Message, error) {
+	return sendToConversation(ctx, c.Bot, c.Key, text, options)
+}
+
+// send a message to the chat (and the forum topic, or through the business connection) of given key
+func sendToConversation(ctx context.Context, b *Bot, key ConversationKey, text string, options OptionsSendMessage) (Message, error) {
+	options = OptionsSendMessage(cloneOptions(options))
+	if key.ThreadID != 0 {
+		options = options.SetMessageThreadID(key.ThreadID)
+	}
+	if key.BusinessConnectionID != "" {
+		options = options.SetBusinessConnectionID(key.BusinessConnectionID)
+	}
+
+	res, err := b.SendMessage(ctx, key.ChatID, text, options)
+	if err != nil {
+		return Message{}, err
+	}
+	return *res.Result, nil
+}
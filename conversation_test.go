@@ -0,0 +1,224 @@
+// conversation_test.go
+//
+// offline tests of conversations
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// returns a conversation for ordering, which records its results
+func newTestOrderConversation(store ConversationStore, results *sync.Map) *Conversation {
+	conv := NewConversation(store)
+	conv.EntryCommands = []string{"/order"}
+	conv.Initial = "name"
+	conv.AddState("name", ConversationState{
+		OnEnter: func(ctx context.Context, c *ConversationContext) error {
+			_, err := c.Reply(ctx, "name?", nil)
+			return err
+		},
+		OnMessage: func(ctx context.Context, c *ConversationContext) error {
+			c.Set("name", *c.Message().Text)
+			c.Transition("confirm")
+			return nil
+		},
+	})
+	conv.AddState("confirm", ConversationState{
+		OnEnter: func(ctx context.Context, c *ConversationContext) error {
+			_, err := c.Reply(ctx, "confirm "+c.Get("name")+"?", nil)
+			return err
+		},
+		OnCallback: func(ctx context.Context, c *ConversationContext) error {
+			results.Store(c.Key, c.Get("name")+":"+*c.CallbackQuery().Data)
+			c.End()
+			return nil
+		},
+		Timeout: 100 * time.Millisecond,
+	})
+	conv.Fallback = func(ctx context.Context, c *ConversationContext) error {
+		_, err := c.Reply(ctx, "please press a button", nil)
+		return err
+	}
+	conv.OnCancel = func(ctx context.Context, c *ConversationContext) error {
+		results.Store(c.Key, "cancelled")
+		return nil
+	}
+	conv.OnTimeout = func(ctx context.Context, c *ConversationContext) error {
+		results.Store(c.Key, "timeout in "+c.State())
+		return nil
+	}
+	return conv
+}
+
+// conversations should move between states with messages and callback queries.
+func TestConversation(t *testing.T) {
+	slog.Info("testing conversations...")
+
+	server := NewTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	results := &sync.Map{}
+	store := NewMemoryConversationStore()
+	client.AddConversation(newTestOrderConversation(store, results))
+
+	commands := 0
+	client.AddCommandHandler("/cancel", func(b *Bot, update Update, args string) {
+		commands++
+	})
+
+	user := telegrambottest.User()
+	chat := telegrambottest.PrivateChat(user)
+	key := ConversationKey{ChatID: chat.ID, UserID: user.ID}
+	send := func(update Update) {
+		client.DispatchUpdates([]Update{update})
+		client.WaitForHandlers()
+	}
+
+	// complete
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/order").Build())
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("pizza").Build())
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("not a button").Build())
+	message := telegrambottest.NewUpdate().Message().InChat(chat).Text("confirm pizza?").Message()
+	send(telegrambottest.NewUpdate().CallbackQuery().From(user).Data("yes").OnMessage(message).Build())
+
+	if result, _ := results.Load(key); result != "pizza:yes" {
+		t.Errorf("unexpected result: %v", result)
+	}
+	texts := []string{}
+	for _, call := range server.CallsTo("sendMessage") {
+		texts = append(texts, call["text"])
+	}
+	if len(texts) != 3 || texts[0] != "name?" || texts[1] != "confirm pizza?" || texts[2] != "please press a button" {
+		t.Errorf("unexpected replies: %v", texts)
+	}
+
+	// cancel
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/order").Build())
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/cancel").Build())
+	if result, _ := results.Load(key); result != "cancelled" {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if session, _ := store.Load(context.TODO(), key.String()); session != nil {
+		t.Errorf("expected the session to be deleted: %+v", session)
+	}
+
+	// /cancel without a conversation goes to command handlers
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/cancel").Build())
+	if commands != 1 {
+		t.Errorf("expected the command handler to be called once, got %d", commands)
+	}
+
+	// timeout
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/order").Build())
+	send(telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("salad").Build())
+	time.Sleep(200 * time.Millisecond)
+	client.WaitForHandlers()
+	if result, _ := results.Load(key); result != "timeout in confirm" {
+		t.Errorf("unexpected result: %v", result)
+	}
+
+	// begin without being added to a bot
+	if err := NewConversation(store).Begin(context.TODO(), key, "name", nil); !errors.Is(err, ErrConversationNotAdded) {
+		t.Errorf("expected ErrConversationNotAdded, got: %v", err)
+	}
+}
+
+// commands addressed to other bots should not enter or cancel conversations.
+func TestConversationCommandsOfOtherBots(t *testing.T) {
+	slog.Info("testing conversations with commands addressed to other bots...")
+
+	server := NewTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	results := &sync.Map{}
+	store := NewMemoryConversationStore()
+	client.AddConversation(newTestOrderConversation(store, results))
+	client.SetUpdateHandler(func(b *Bot, update Update, err error) {})
+
+	user := telegrambottest.User()
+	chat := telegrambottest.GroupChat()
+	key := ConversationKey{ChatID: chat.ID, UserID: user.ID}
+	send := func(text string) {
+		client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text(text).Build()})
+		client.WaitForHandlers()
+	}
+
+	send("/order@someotherbot")
+	if session, _ := store.Load(context.TODO(), key.String()); session != nil {
+		t.Errorf("expected no session with a command of another bot: %+v", session)
+	}
+
+	send("/order@TestBot")
+	if session, _ := store.Load(context.TODO(), key.String()); session == nil || session.State != "name" {
+		t.Errorf("expected a session with a command of the bot: %+v", session)
+	}
+
+	send("/cancel@someotherbot")
+	if result, _ := results.Load(key); result == "cancelled" {
+		t.Errorf("expected the conversation not to be cancelled with a command of another bot")
+	}
+
+	send("/cancel@testbot")
+	if result, _ := results.Load(key); result != "cancelled" {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+// sessions in JSON files should survive restarts.
+func TestFileConversationStore(t *testing.T) {
+	slog.Info("testing conversations stored in files...")
+
+	server := NewTestRecordingServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "conversations.json")
+	user := telegrambottest.User()
+	chat := telegrambottest.PrivateChat(user)
+	key := ConversationKey{ChatID: chat.ID, UserID: user.ID}
+
+	// before restart
+	store, err := NewFileConversationStore(path)
+	if err != nil {
+		t.Fatalf("failed to create store: %s", err)
+	}
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+	client.AddConversation(newTestOrderConversation(store, &sync.Map{}))
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/order").Build()})
+	client.WaitForHandlers()
+
+	// after restart
+	store, err = NewFileConversationStore(path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %s", err)
+	}
+	conv := newTestOrderConversation(store, &sync.Map{})
+	client = NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+	client.AddConversation(conv)
+
+	if session, err := conv.Session(context.TODO(), key); err != nil || session == nil || session.State != "name" {
+		t.Fatalf("expected a restored session, got: %+v, %v", session, err)
+	}
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("soup").Build()})
+	client.WaitForHandlers()
+	if session, _ := conv.Session(context.TODO(), key); session == nil || session.State != "confirm" || session.Data["name"] != "soup" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+}
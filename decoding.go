@@ -0,0 +1,266 @@
+package telegrambot
+
+// Strict decoding of responses and updates, and raw json of updates and messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// UnknownField is a field of a response or an update which is not decoded into any struct field,
+// usually because it was newly added to the Bot API and is not supported by this library yet.
+type UnknownField struct {
+	Source string // name of the method, or "webhook"
+	Type   string // name of the struct which does not have the field (eg. "Message")
+	Field  string // name of the field in json (eg. "passport_data")
+	Path   string // path of the field from the root of the response (eg. "result[0].message.passport_data")
+}
+
+// String returns a readable form of the unknown field.
+func (f UnknownField) String() string {
+	return fmt.Sprintf("%s: unknown field '%s' of %s (at %s)", f.Source, f.Field, f.Type, f.Path)
+}
+
+// SetUnknownFieldHandler sets a function for handling unknown fields in responses and webhook updates (strict decoding).
+//
+// It is called once for each unknown field, so that API drift can be logged or counted.
+// Unknown fields are still ignored while decoding, and can be reached with `RawJSON()` of updates and messages
+// when raw json is kept with `SetKeepRawJSON`.
+//
+// Set nil for disabling strict decoding (default).
+func (b *Bot) SetUnknownFieldHandler(handler func(b *Bot, field UnknownField)) {
+	b.unknownFieldHandler = handler
+}
+
+// report unknown fields of given json which was decoded into `v`, if strict decoding is enabled
+func (b *Bot) reportUnknownFields(source string, data []byte, v any) {
+	if b.unknownFieldHandler == nil {
+		return
+	}
+
+	fields, err := FindUnknownFields(data, v)
+	if err != nil {
+		b.error("failed to find unknown fields of %s: %s", source, err)
+		return
+	}
+	for _, field := range fields {
+		field.Source = source
+		b.unknownFieldHandler(b, field)
+	}
+}
+
+// FindUnknownFields returns fields of given json which are not decoded into the struct fields of `v`.
+//
+// Values which are decoded with custom unmarshallers (eg. RichText) or into interfaces are not inspected.
+func FindUnknownFields(data []byte, v any) (fields []UnknownField, err error) {
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return findUnknownFields(value, reflect.TypeOf(v), "", fields), nil
+}
+
+// types which implement json.Unmarshaler, but are decoded as structs of their own fields
+var typesDecodedAsStructs = map[reflect.Type]bool{
+	reflect.TypeFor[PaidMediaInfo]():      true,
+	reflect.TypeFor[TransactionPartner](): true,
+}
+
+// find unknown fields of a decoded json value with given type recursively
+func findUnknownFields(value any, typ reflect.Type, path string, fields []UnknownField) []UnknownField {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || value == nil {
+		return fields
+	}
+	if !typesDecodedAsStructs[typ] && reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		return fields
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fields
+		}
+		known := jsonFieldsOf(typ)
+		for key, v := range object {
+			field, exists := known[key]
+			if !exists {
+				field, exists = known[strings.ToLower(key)]
+			}
+			if !exists {
+				fields = append(fields, UnknownField{
+					Type:  typ.Name(),
+					Field: key,
+					Path:  joinJSONPath(path, key),
+				})
+				continue
+			}
+			fields = findUnknownFields(v, field.Type, joinJSONPath(path, key), fields)
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]any)
+		if !ok {
+			return fields
+		}
+		for i, v := range array {
+			fields = findUnknownFields(v, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	}
+	return fields
+}
+
+// join a path of json fields
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// cached json fields of struct types
+var jsonFields sync.Map // reflect.Type => map[string]reflect.StructField
+
+// json fields of given struct type, keyed by their names (and lowercased names)
+func jsonFieldsOf(typ reflect.Type) map[string]reflect.StructField {
+	if cached, exists := jsonFields.Load(typ); exists {
+		return cached.(map[string]reflect.StructField)
+	}
+
+	fields := map[string]reflect.StructField{}
+	for field := range typ.Fields() {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for n, f := range jsonFieldsOf(embedded) {
+					if _, exists := fields[n]; !exists {
+						fields[n] = f
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+		if lowered := strings.ToLower(name); lowered != name {
+			if _, exists := fields[lowered]; !exists {
+				fields[lowered] = field
+			}
+		}
+	}
+
+	jsonFields.Store(typ, fields)
+	return fields
+}
+
+////////////////////////////////
+// raw json
+//
+
+// SetKeepRawJSON sets whether to keep the original json of updates and messages (including nested ones)
+// in responses and webhook updates, for `RawJSON()`.
+//
+// It is disabled by default, as it retains a copy of every decoded payload.
+func (b *Bot) SetKeepRawJSON(keep bool) {
+	b.keepRawJSON = keep
+}
+
+// keep the original json of updates and messages in `v` which was decoded from given json, if enabled
+func (b *Bot) keepRawJSONOf(data []byte, v any) {
+	if !b.keepRawJSON {
+		return
+	}
+
+	keepRawJSON(data, reflect.ValueOf(v))
+}
+
+// keep the original json of updates and messages in a decoded value recursively
+func keepRawJSON(data []byte, value reflect.Value) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	typ := value.Type()
+	if !value.CanAddr() || !typesDecodedAsStructs[typ] && reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return
+		}
+		switch v := value.Addr().Interface().(type) {
+		case *Update:
+			v.raw = string(data)
+		case *Message:
+			v.raw = string(data)
+		case *MaybeInaccessibleMessage:
+			v.raw = string(data)
+		}
+
+		known := jsonFieldsOf(typ)
+		for key, raw := range object {
+			field, exists := known[key]
+			if !exists {
+				field, exists = known[strings.ToLower(key)]
+			}
+			if !exists {
+				continue
+			}
+			if fieldValue, err := value.FieldByIndexErr(field.Index); err == nil {
+				keepRawJSON(raw, fieldValue)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var array []json.RawMessage
+		if err := json.Unmarshal(data, &array); err != nil {
+			return
+		}
+		for i := 0; i < len(array) && i < value.Len(); i++ {
+			keepRawJSON(array[i], value.Index(i))
+		}
+	}
+}
+
+// RawJSON returns the original json of the update,
+// or nil if it was not decoded from json by a bot with `SetKeepRawJSON(true)`.
+//
+// It can be used for reaching fields which are not supported by this library yet, or archiving exact payloads.
+func (u Update) RawJSON() json.RawMessage {
+	if u.raw == "" {
+		return nil
+	}
+	return json.RawMessage(u.raw)
+}
+
+// RawJSON returns the original json of the message,
+// or nil if it was not decoded from json by a bot with `SetKeepRawJSON(true)`.
+//
+// It can be used for reaching fields which are not supported by this library yet (eg. `passport_data`).
+func (m Message) RawJSON() json.RawMessage {
+	if m.raw == "" {
+		return nil
+	}
+	return json.RawMessage(m.raw)
+}
@@ -0,0 +1,152 @@
+// decoding_test.go
+//
+// offline tests of strict decoding and raw json
+
+package telegrambot_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+const testUpdateWithUnknownFields = `{
+	"update_id": 1,
+	"message": {
+		"message_id": 2,
+		"date": 1,
+		"chat": {"id": 12345, "type": "private", "first_name": "Test"},
+		"from": {"id": 12345, "is_bot": false, "first_name": "Test"},
+		"text": "hello",
+		"entities": [{"type": "bold", "offset": 0, "length": 5, "new_entity_field": 1}],
+		"reply_to_message": {"message_id": 1, "date": 1, "chat": {"id": 12345, "type": "private"}, "photo": [{"file_id": "a", "file_unique_id": "b", "width": 1, "height": 1}]},
+		"passport_data": {"data": [], "credentials": {}}
+	}
+}`
+
+// unknown fields should be found with their types and paths, without false positives.
+func TestFindUnknownFields(t *testing.T) {
+	slog.Info("testing finding unknown fields...")
+
+	var update Update
+	fields, err := FindUnknownFields([]byte(testUpdateWithUnknownFields), &update)
+	if err != nil {
+		t.Fatalf("failed to find unknown fields: %s", err)
+	}
+	paths := []string{}
+	for _, field := range fields {
+		paths = append(paths, field.Type+" "+field.Path)
+	}
+	slices.Sort(paths)
+	if expected := []string{
+		"Message message.passport_data",
+		"MessageEntity message.entities[0].new_entity_field",
+	}; !slices.Equal(paths, expected) {
+		t.Errorf("expected unknown fields %v, got %v", expected, paths)
+	}
+}
+
+// updates and messages should keep their original json only when enabled.
+func TestRawJSON(t *testing.T) {
+	slog.Info("testing raw json of updates and messages...")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"ok":true,"result":[%s]}`, testUpdateWithUnknownFields)
+	}))
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	// not kept by default
+	res, err := client.GetUpdates(context.TODO(), nil)
+	if err != nil || res.Result == nil || len(*res.Result) != 1 {
+		t.Fatalf("failed to get updates: %v", err)
+	}
+	if raw := (*res.Result)[0].RawJSON(); raw != nil {
+		t.Errorf("raw json should not be kept by default, got: %s", raw)
+	}
+
+	// kept when enabled
+	client.SetKeepRawJSON(true)
+	res, err = client.GetUpdates(context.TODO(), nil)
+	if err != nil || res.Result == nil || len(*res.Result) != 1 {
+		t.Fatalf("failed to get updates: %v", err)
+	}
+	update := (*res.Result)[0]
+	if string(update.RawJSON()) != testUpdateWithUnknownFields {
+		t.Errorf("raw json of the update differs: %s", update.RawJSON())
+	}
+
+	var message struct {
+		PassportData map[string]any `json:"passport_data"`
+	}
+	if err := json.Unmarshal(update.Message.RawJSON(), &message); err != nil {
+		t.Errorf("failed to unmarshal raw json of the message: %s", err)
+	} else if message.PassportData == nil {
+		t.Errorf("raw json of the message should have an unsupported field")
+	}
+	if update.Message.ReplyToMessage.RawJSON() == nil {
+		t.Errorf("nested message should also keep its raw json")
+	}
+
+	// not kept when decoded without a bot
+	var decoded Update
+	if err := json.Unmarshal([]byte(testUpdateWithUnknownFields), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal update: %s", err)
+	} else if raw := decoded.RawJSON(); raw != nil {
+		t.Errorf("raw json should not be kept without a bot, got: %s", raw)
+	}
+
+	if raw := telegrambottest.NewUpdate().Message().Text("hi").Build().RawJSON(); raw != nil {
+		t.Errorf("built update should not have raw json, got: %s", raw)
+	}
+}
+
+// unknown fields in responses should be reported to the handler in strict decoding mode.
+func TestUnknownFieldHandler(t *testing.T) {
+	slog.Info("testing handler of unknown fields...")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"Bot","has_new_feature":true}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	var reported []UnknownField
+	client.SetUnknownFieldHandler(func(b *Bot, field UnknownField) {
+		reported = append(reported, field)
+	})
+	if _, err := client.GetMe(context.TODO()); err != nil {
+		t.Errorf("failed to get me: %s", err)
+	}
+	if len(reported) != 1 || reported[0] != (UnknownField{
+		Source: "getMe",
+		Type:   "User",
+		Field:  "has_new_feature",
+		Path:   "result.has_new_feature",
+	}) {
+		t.Errorf("unexpected unknown fields: %v", reported)
+	}
+
+	client.SetUnknownFieldHandler(nil)
+	reported = nil
+	if _, err := client.GetMe(context.TODO()); err != nil {
+		t.Errorf("failed to get me: %s", err)
+	}
+	if len(reported) != 0 {
+		t.Errorf("unknown fields should not be reported when disabled: %v", reported)
+	}
+}
@@ -0,0 +1,591 @@
+package telegrambot
+
+// Streaming of incrementally generated replies (eg. answers of LLMs, logs of long-running jobs)
+// with message drafts
+//
+// https://core.telegram.org/bots/api#sendmessagedraft
+// https://core.telegram.org/bots/api#sendrichmessagedraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDraftInterval     = 1 * time.Second  // default interval between draft updates
+	defaultDraftCloseTimeout = 30 * time.Second // timeout of sending the final message(s) on close
+)
+
+// ErrDraftStreamClosed is returned when writing to a closed draft stream.
+var ErrDraftStreamClosed = errors.New("draft stream is already closed")
+
+// draftLoop pushes drafts periodically, coalescing writes between them
+type draftLoop struct {
+	ctx      context.Context
+	interval time.Duration
+	push     func(ctx context.Context) error // sends the current draft
+
+	mu        sync.Mutex
+	dirty     bool      // whether there are writes which are not pushed yet
+	notBefore time.Time // for respecting flood limits
+	closed    bool
+
+	start   sync.Once
+	wake    chan struct{}
+	quit    chan struct{}
+	stopped chan struct{}
+}
+
+// initialize the loop
+func (l *draftLoop) init(ctx context.Context, interval time.Duration, push func(ctx context.Context) error) {
+	if interval <= 0 {
+		interval = defaultDraftInterval
+	}
+
+	l.ctx = ctx
+	l.interval = interval
+	l.push = push
+	l.wake = make(chan struct{}, 1)
+	l.quit = make(chan struct{})
+	l.stopped = make(chan struct{})
+}
+
+// mark that there are new writes, and start the loop if it is not started yet
+func (l *draftLoop) touch() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return ErrDraftStreamClosed
+	}
+	l.dirty = true
+	l.mu.Unlock()
+
+	l.start.Do(func() {
+		go l.run()
+	})
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// run the loop
+func (l *draftLoop) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	var lastPushed time.Time
+	for {
+		select {
+		case <-l.quit:
+			return
+		case <-l.ctx.Done():
+			return
+		case <-l.wake:
+			if time.Since(lastPushed) < l.interval {
+				continue // will be pushed on next tick
+			}
+		case <-ticker.C:
+		}
+
+		if l.pushIfDirty() {
+			lastPushed = time.Now()
+		}
+	}
+}
+
+// push a draft if there are new writes (and not flood-limited)
+func (l *draftLoop) pushIfDirty() bool {
+	l.mu.Lock()
+	if !l.dirty || time.Now().Before(l.notBefore) {
+		l.mu.Unlock()
+		return false
+	}
+	l.dirty = false
+	l.mu.Unlock()
+
+	// NOTE: drafts are best-effort, so errors other than flood limits are ignored
+	if err := l.push(l.ctx); err != nil {
+		if _, ok := errors.AsType[ErrTooManyRequests](err); ok {
+			l.mu.Lock()
+			l.dirty = true // retry later
+			if notBefore := time.Now().Add(l.interval); notBefore.After(l.notBefore) {
+				l.notBefore = notBefore
+			}
+			l.mu.Unlock()
+		}
+	}
+	return true
+}
+
+// check if the loop is closed
+func (l *draftLoop) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.closed
+}
+
+// back off for given seconds (from `retry_after` of API responses)
+func (l *draftLoop) backOff(params *APIResponseParameters) {
+	if params != nil && params.RetryAfter != nil {
+		l.mu.Lock()
+		l.notBefore = time.Now().Add(time.Duration(*params.RetryAfter) * time.Second)
+		l.mu.Unlock()
+	}
+}
+
+// stop the loop, and return whether it was already closed
+func (l *draftLoop) stop() (alreadyClosed bool) {
+	l.mu.Lock()
+	alreadyClosed = l.closed
+	l.closed = true
+	l.mu.Unlock()
+	if alreadyClosed {
+		return true
+	}
+
+	// NOTE: make sure the loop is started for closing it
+	l.start.Do(func() {
+		go l.run()
+	})
+	close(l.quit)
+	<-l.stopped
+
+	return false
+}
+
+// context for sending the final message(s) on close,
+// which is not canceled with the context of the stream (but times out)
+func (l *draftLoop) closeContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(l.ctx), defaultDraftCloseTimeout)
+}
+
+// clone given options (never nil)
+func cloneOptions(options map[string]any) map[string]any {
+	if options == nil {
+		return map[string]any{}
+	}
+	return maps.Clone(options)
+}
+
+// generate a random, non-zero draft id
+func newDraftID() int64 {
+	return rand.Int64N(1<<62) + 1
+}
+
+////////////////////////////////
+// DraftStream
+//
+
+// DraftStreamOptions is options for DraftStream.
+type DraftStreamOptions struct {
+	// minimum interval between draft updates (default: 1 second)
+	Interval time.Duration
+
+	// id of the draft (default: a random one)
+	DraftID int64
+
+	// converts the written text to a text with entities (eg. from Markdown) for drafts and the final message
+	Format func(text string) (string, []MessageEntity)
+
+	// options for drafts (eg. `message_thread_id`)
+	DraftOptions OptionsSendMessageDraft
+
+	// options for the final message(s)
+	MessageOptions OptionsSendMessage
+}
+
+// DraftStream is an io.Writer which streams the written text as a message draft,
+// and sends it as the final message when closed.
+//
+//	stream := b.NewDraftStream(ctx, chatID, nil)
+//	for token := range tokens {
+//		_, _ = stream.Write([]byte(token))
+//	}
+//	err := stream.Close()
+type DraftStream struct {
+	b       *Bot
+	chatID  ChatID
+	draftID int64
+	options DraftStreamOptions
+
+	loop draftLoop
+
+	mu       sync.Mutex
+	text     strings.Builder
+	messages []Message
+}
+
+// NewDraftStream returns a new DraftStream for given chat.
+func (b *Bot) NewDraftStream(ctx context.Context, chatID ChatID, options *DraftStreamOptions) *DraftStream {
+	s := &DraftStream{
+		b:      b,
+		chatID: chatID,
+	}
+	if options != nil {
+		s.options = *options
+	}
+	s.draftID = s.options.DraftID
+	if s.draftID == 0 {
+		s.draftID = newDraftID()
+	}
+	s.loop.init(ctx, s.options.Interval, s.pushDraft)
+
+	return s
+}
+
+// DraftID returns the id of the draft.
+func (s *DraftStream) DraftID() int64 {
+	return s.draftID
+}
+
+// Write appends given bytes to the text of the draft.
+func (s *DraftStream) Write(p []byte) (n int, err error) {
+	return s.WriteString(string(p))
+}
+
+// WriteString appends given string to the text of the draft.
+func (s *DraftStream) WriteString(str string) (n int, err error) {
+	s.mu.Lock()
+	if s.loop.isClosed() {
+		s.mu.Unlock()
+		return 0, ErrDraftStreamClosed
+	}
+	s.text.WriteString(str)
+	s.mu.Unlock()
+
+	if err = s.loop.touch(); err != nil {
+		return 0, err
+	}
+	return len(str), nil
+}
+
+// String returns the text written so far.
+func (s *DraftStream) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.text.String()
+}
+
+// formatted text and entities of the stream
+func (s *DraftStream) formatted() (text string, entities []MessageEntity) {
+	text = s.String()
+	if s.options.Format != nil {
+		return s.options.Format(text)
+	}
+	return text, nil
+}
+
+// send current text as a draft
+//
+// When the text is too long, only its last chunk is shown in the draft.
+func (s *DraftStream) pushDraft(ctx context.Context) error {
+	text, entities := s.formatted()
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	chunks := splitText(text, entities, maxMessageTextLength)
+	last := chunks[len(chunks)-1]
+	if len(last.Entities) > maxEntitiesPerMessage {
+		last.Entities = last.Entities[:maxEntitiesPerMessage]
+	}
+
+	options := OptionsSendMessageDraft(cloneOptions(s.options.DraftOptions))
+	if len(last.Entities) > 0 {
+		options = options.SetEntities(last.Entities)
+	}
+
+	res, err := s.b.SendMessageDraft(ctx, s.chatID, s.draftID, last.Text, options)
+	if err != nil {
+		s.loop.backOff(res.Parameters)
+	}
+	return err
+}
+
+// Flush sends the current text as a draft immediately.
+func (s *DraftStream) Flush() error {
+	return s.pushDraft(s.loop.ctx)
+}
+
+// Close stops updating the draft, and sends the final message(s).
+//
+// Texts longer than 4096 characters will be split into multiple messages.
+// Nothing will be sent if the text is empty.
+//
+// The final message(s) will be sent even if the context of the stream is already canceled.
+func (s *DraftStream) Close() error {
+	if alreadyClosed := s.loop.stop(); alreadyClosed {
+		return nil
+	}
+
+	text, entities := s.formatted()
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	options := OptionsSendMessage(cloneOptions(s.options.MessageOptions))
+	if len(entities) > 0 {
+		options = options.SetEntities(entities)
+	}
+
+	ctx, cancel := s.loop.closeContext()
+	defer cancel()
+
+	messages, err := s.b.SendLongMessage(ctx, s.chatID, text, options)
+
+	s.mu.Lock()
+	s.messages = messages
+	s.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to send the final message: %w", err)
+	}
+	return nil
+}
+
+// Messages returns the final messages sent on Close().
+func (s *DraftStream) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.messages
+}
+
+////////////////////////////////
+// RichDraftStream
+//
+
+// RichDraftStreamOptions is options for RichDraftStream.
+type RichDraftStreamOptions struct {
+	// minimum interval between draft updates (default: 1 second)
+	Interval time.Duration
+
+	// id of the draft (default: a random one)
+	DraftID int64
+
+	// options for drafts (eg. `message_thread_id`)
+	DraftOptions OptionsSendRichMessageDraft
+
+	// options for the final message
+	MessageOptions OptionsSendRichMessage
+}
+
+// RichDraftStream streams rich message drafts, and sends the final rich message when closed.
+//
+// Written texts are treated as a Markdown document, and converted with `MarkdownToInputRichBlocks()`.
+// Blocks can also be appended directly with `AppendBlocks()` (after the written ones).
+type RichDraftStream struct {
+	b       *Bot
+	chatID  ChatID
+	draftID int64
+	options RichDraftStreamOptions
+
+	loop draftLoop
+
+	mu       sync.Mutex
+	markdown strings.Builder
+	blocks   []InputRichBlock
+	message  *Message
+
+	// blocks of the Markdown document which will not change with further writes,
+	// and the length of the document converted to them (for converting only the pending part on each push)
+	settled       []InputRichBlock
+	settledLength int
+}
+
+// NewRichDraftStream returns a new RichDraftStream for given chat.
+func (b *Bot) NewRichDraftStream(ctx context.Context, chatID ChatID, options *RichDraftStreamOptions) *RichDraftStream {
+	s := &RichDraftStream{
+		b:      b,
+		chatID: chatID,
+	}
+	if options != nil {
+		s.options = *options
+	}
+	s.draftID = s.options.DraftID
+	if s.draftID == 0 {
+		s.draftID = newDraftID()
+	}
+	s.loop.init(ctx, s.options.Interval, s.pushDraft)
+
+	return s
+}
+
+// DraftID returns the id of the draft.
+func (s *RichDraftStream) DraftID() int64 {
+	return s.draftID
+}
+
+// Write appends given bytes to the Markdown document of the draft.
+func (s *RichDraftStream) Write(p []byte) (n int, err error) {
+	return s.WriteString(string(p))
+}
+
+// WriteString appends given string to the Markdown document of the draft.
+func (s *RichDraftStream) WriteString(str string) (n int, err error) {
+	s.mu.Lock()
+	if s.loop.isClosed() {
+		s.mu.Unlock()
+		return 0, ErrDraftStreamClosed
+	}
+	s.markdown.WriteString(str)
+	s.mu.Unlock()
+
+	if err = s.loop.touch(); err != nil {
+		return 0, err
+	}
+	return len(str), nil
+}
+
+// AppendBlocks appends given blocks to the draft.
+func (s *RichDraftStream) AppendBlocks(blocks ...InputRichBlock) error {
+	s.mu.Lock()
+	if s.loop.isClosed() {
+		s.mu.Unlock()
+		return ErrDraftStreamClosed
+	}
+	s.blocks = append(s.blocks, blocks...)
+	s.mu.Unlock()
+
+	return s.loop.touch()
+}
+
+// current blocks of the stream
+//
+// Only the pending part of the Markdown document is converted, after its complete blocks are settled.
+//
+// NOTE: problems of incomplete Markdown documents (eg. unclosed code blocks) are ignored.
+func (s *RichDraftStream) currentBlocks() []InputRichBlock {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := s.markdown.String()[s.settledLength:]
+	if length := settledMarkdownLength(pending); length > 0 {
+		settled, _ := MarkdownToInputRichBlocks(pending[:length])
+		s.settled = append(s.settled, settled...)
+		s.settledLength += length
+		pending = pending[length:]
+	}
+	blocks, _ := MarkdownToInputRichBlocks(pending)
+
+	return slices.Concat(s.settled, blocks, s.blocks)
+}
+
+// length of the leading part of given Markdown document which consists of complete blocks only,
+// so that it is converted to the same blocks regardless of further writes
+//
+// The document is cut before an unindented line which follows a blank line,
+// outside of code blocks, math blocks, and `<details>`, and not starting a list item (which may continue a list).
+func settledMarkdownLength(markdown string) (length int) {
+	var fence string // (opening fence of the current code block)
+	inMath, details, blank := false, 0, false
+
+	offset := 0
+	for {
+		end := strings.IndexByte(markdown[offset:], '\n')
+		if end < 0 {
+			return length // (the last line is not complete yet)
+		}
+		line := expandTabs(strings.TrimSuffix(markdown[offset:offset+end], "\r"))
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case fence != "":
+			if leadingSpaces(line) < 4 && strings.HasPrefix(trimmed, fence[:1]) && strings.Trim(trimmed, fence[:1]) == "" && len(trimmed) >= len(fence) {
+				fence = ""
+			}
+		case inMath:
+			inMath = !strings.HasSuffix(trimmed, "$$")
+		default:
+			if blank && details == 0 && line != "" && line[0] != ' ' && !mdListItem.MatchString(line) {
+				length = offset
+			}
+
+			if m := mdFence.FindStringSubmatch(line); m != nil {
+				fence = m[2]
+			} else if rest, found := strings.CutPrefix(trimmed, "$$"); found {
+				inMath = !(strings.HasSuffix(rest, "$$") && len(rest) >= 2)
+			} else if mdDetailsOpen.MatchString(line) {
+				details++
+			} else if mdDetailsClose.MatchString(line) && details > 0 {
+				details--
+			}
+		}
+		blank = trimmed == ""
+
+		offset += end + 1
+	}
+}
+
+// send current blocks as a draft
+func (s *RichDraftStream) pushDraft(ctx context.Context) error {
+	blocks := s.currentBlocks()
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	options := OptionsSendRichMessageDraft(cloneOptions(s.options.DraftOptions))
+	res, err := s.b.SendRichMessageDraft(ctx, s.chatID, s.draftID, InputRichMessage{Blocks: blocks}, options)
+	if err != nil {
+		s.loop.backOff(res.Parameters)
+	}
+	return err
+}
+
+// Flush sends the current blocks as a draft immediately.
+func (s *RichDraftStream) Flush() error {
+	return s.pushDraft(s.loop.ctx)
+}
+
+// Close stops updating the draft, and sends the final rich message.
+//
+// Nothing will be sent if there is no block.
+//
+// The final rich message will be sent even if the context of the stream is already canceled.
+func (s *RichDraftStream) Close() error {
+	if alreadyClosed := s.loop.stop(); alreadyClosed {
+		return nil
+	}
+
+	blocks := s.currentBlocks()
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	options := OptionsSendRichMessage(cloneOptions(s.options.MessageOptions))
+	ctx, cancel := s.loop.closeContext()
+	defer cancel()
+
+	res, err := s.b.SendRichMessage(ctx, s.chatID, InputRichMessage{Blocks: blocks}, options)
+	if err != nil {
+		return fmt.Errorf("failed to send the final rich message: %w", err)
+	}
+
+	s.mu.Lock()
+	s.message = res.Result
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Message returns the final rich message sent on Close().
+func (s *RichDraftStream) Message() *Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.message
+}
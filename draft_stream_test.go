@@ -0,0 +1,213 @@
+// draft_stream_test.go
+//
+// offline tests of streaming message drafts
+
+package telegrambot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// a fake server which records params of requests by methods
+type testRecordingServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	calls map[string][]map[string]string
+}
+
+// returns a new testRecordingServer
+func newTestRecordingServer() *testRecordingServer {
+	s := &testRecordingServer{
+		calls: map[string][]map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		params := map[string]string{}
+		for key, values := range r.Form {
+			params[key] = values[0]
+		}
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		s.mu.Lock()
+		s.calls[method] = append(s.calls[method], params)
+		messageID := len(s.calls[method])
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if method == "getMe" {
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":{"id":12345,"is_bot":true,"first_name":"test","username":"testbot"}}`)
+		} else if strings.HasPrefix(method, "send") && !strings.HasSuffix(method, "Draft") {
+			_, _ = fmt.Fprintf(w, `{"ok":true,"result":{"message_id":%d,"date":1,"chat":{"id":12345,"type":"private"},"text":%q}}`, messageID, params["text"])
+		} else {
+			_, _ = fmt.Fprint(w, `{"ok":true,"result":true}`)
+		}
+	}))
+	return s
+}
+
+// recorded calls to given method
+func (s *testRecordingServer) callsTo(method string) []map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+// written texts should be pushed as drafts, and sent as the final messages on close.
+func TestDraftStream(t *testing.T) {
+	slog.Info("testing streaming of message drafts...")
+
+	server := newTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	stream := client.NewDraftStream(context.TODO(), 12345, &DraftStreamOptions{
+		Interval: 20 * time.Millisecond,
+	})
+	for range 10 {
+		if _, err := fmt.Fprint(stream, "token "); err != nil {
+			t.Fatalf("failed to write to stream: %s", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	drafts := server.callsTo("sendMessageDraft")
+	if len(drafts) == 0 || len(drafts) > 10 {
+		t.Errorf("expected coalesced drafts, got %d", len(drafts))
+	} else if last := drafts[len(drafts)-1]; last["text"] != strings.TrimSpace(strings.Repeat("token ", 10)) || last["draft_id"] != fmt.Sprint(stream.DraftID()) {
+		t.Errorf("unexpected last draft: %+v", last)
+	}
+
+	// long text should be split on close
+	_, _ = stream.WriteString(strings.Repeat("word ", 1000))
+	if err := stream.Close(); err != nil {
+		t.Fatalf("failed to close stream: %s", err)
+	}
+	if messages := stream.Messages(); len(messages) != 2 {
+		t.Errorf("expected 2 final messages, got %d", len(messages))
+	}
+	for _, call := range server.callsTo("sendMessage") {
+		if len([]rune(call["text"])) > maxMessageTextLength {
+			t.Errorf("expected messages to be split, got %d characters", len([]rune(call["text"])))
+		}
+	}
+
+	if _, err := stream.WriteString("more"); err == nil {
+		t.Errorf("expected an error when writing to a closed stream")
+	}
+}
+
+// written Markdown should be sent as rich message drafts and the final rich message.
+func TestRichDraftStream(t *testing.T) {
+	slog.Info("testing streaming of rich message drafts...")
+
+	server := newTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	stream := client.NewRichDraftStream(context.TODO(), 12345, &RichDraftStreamOptions{
+		Interval: 10 * time.Millisecond,
+	})
+	_, _ = stream.WriteString("# Title\n\n```go\nunclosed")
+	if err := stream.Flush(); err != nil {
+		t.Fatalf("failed to flush: %s", err)
+	}
+	_, _ = stream.WriteString("\n```\n")
+	if err := stream.Close(); err != nil {
+		t.Fatalf("failed to close stream: %s", err)
+	}
+
+	if drafts := server.callsTo("sendRichMessageDraft"); len(drafts) == 0 || !strings.Contains(drafts[0]["rich_message"], `"type":"heading"`) {
+		t.Errorf("unexpected drafts: %+v", drafts)
+	}
+	if final := server.callsTo("sendRichMessage"); len(final) != 1 || !strings.Contains(final[0]["rich_message"], `"language":"go"`) {
+		t.Errorf("unexpected final message: %+v", final)
+	}
+	if stream.Message() == nil {
+		t.Errorf("expected the final message")
+	}
+}
+
+// blocks of rich drafts converted incrementally should be the same as the ones of the whole document.
+func TestRichDraftStreamIncremental(t *testing.T) {
+	slog.Info("testing incremental conversion of rich message drafts...")
+
+	documents := append([]string{
+		testMarkdown,
+		"para\n\n- a\n\n- b\n\ntext\n\n```\ncode\n\nmore\n```\n\nafter\n\n$$\nx\n\ny\n$$\n\n<details>\n<summary>s</summary>\n\nbody\n\n</details>\n\nend\n",
+	}, testMarkdownHangs...)
+	for _, document := range documents {
+		expected, _ := MarkdownToInputRichBlocks(document)
+
+		stream := &RichDraftStream{}
+		for chunk := range slices.Chunk([]byte(document), 7) {
+			stream.markdown.Write(chunk)
+			_ = stream.currentBlocks()
+		}
+
+		got, _ := json.Marshal(stream.currentBlocks())
+		want, _ := json.Marshal(expected)
+		if string(got) != string(want) {
+			t.Errorf("failed to convert incrementally %q:\n got %s\nwant %s", document, got, want)
+		}
+	}
+}
+
+// texts with whitespaces before quotes should be streamed,
+// and the final rich message should be sent even after the context is canceled.
+func TestRichDraftStreamCanceled(t *testing.T) {
+	slog.Info("testing closing of a canceled rich draft stream...")
+
+	server := newTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	stream := client.NewRichDraftStream(ctx, 12345, &RichDraftStreamOptions{
+		Interval: 10 * time.Millisecond,
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		for _, text := range testMarkdownHangs {
+			_, _ = stream.WriteString(text + "\n")
+			if err := stream.Flush(); err != nil {
+				done <- err
+				return
+			}
+		}
+		cancel()
+		done <- stream.Close()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("failed to close stream: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to stream texts with whitespaces before quotes: timed out")
+	}
+
+	if final := server.callsTo("sendRichMessage"); len(final) != 1 {
+		t.Errorf("expected the final message after cancellation, got: %+v", final)
+	}
+}
@@ -0,0 +1,138 @@
+package telegrambot
+
+// Extraction of texts from message entities, and conversion of them to parse mode strings
+//
+// NOTE: Offsets and lengths of entities are in UTF-16 code units, not in bytes of Go strings.
+
+// TextLink is a text with a link, extracted from a `text_link` entity.
+type TextLink struct {
+	Text string
+	URL  string
+}
+
+// TextMention is a text with a mentioned user, extracted from a `text_mention` entity.
+type TextMention struct {
+	Text string
+	User User
+}
+
+// EntityText returns the substring of given text for given entity.
+func EntityText(text string, entity MessageEntity) string {
+	return utf16Substring(text, entity.Offset, entity.Length)
+}
+
+// EntitiesToHTML converts given text with entities to a string for `ParseModeHTML`.
+func EntitiesToHTML(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, &htmlRenderer{})
+}
+
+// EntitiesToMarkdownV2 converts given text with entities to a string for `ParseModeMarkdownV2`.
+func EntitiesToMarkdownV2(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, &markdownV2Renderer{})
+}
+
+////////////////////////////////
+// Helper functions for entities of Message
+//
+
+// EntityText returns the substring of Message's text for given entity.
+func (m *Message) EntityText(entity MessageEntity) string {
+	if m.Text == nil {
+		return ""
+	}
+	return EntityText(*m.Text, entity)
+}
+
+// CaptionEntityText returns the substring of Message's caption for given entity.
+func (m *Message) CaptionEntityText(entity MessageEntity) string {
+	if m.Caption == nil {
+		return ""
+	}
+	return EntityText(*m.Caption, entity)
+}
+
+// TextWithEntities returns Message's text and its entities,
+// or caption and its entities when it has no text.
+func (m *Message) TextWithEntities() (text string, entities []MessageEntity) {
+	if m.Text != nil {
+		return *m.Text, m.Entities
+	}
+	if m.Caption != nil {
+		return *m.Caption, m.CaptionEntities
+	}
+	return "", nil
+}
+
+// HTML returns Message's text (or caption) with its formatting as a string for `ParseModeHTML`.
+func (m *Message) HTML() string {
+	return EntitiesToHTML(m.TextWithEntities())
+}
+
+// MarkdownV2 returns Message's text (or caption) with its formatting as a string for `ParseModeMarkdownV2`.
+func (m *Message) MarkdownV2() string {
+	return EntitiesToMarkdownV2(m.TextWithEntities())
+}
+
+// URLs returns all urls (`url` entities) in Message's text (or caption).
+func (m *Message) URLs() []string {
+	return m.entityTexts(MessageEntityTypeURL)
+}
+
+// TextLinks returns all texts with links (`text_link` entities) in Message's text (or caption).
+func (m *Message) TextLinks() (links []TextLink) {
+	text, entities := m.TextWithEntities()
+	for _, entity := range entities {
+		if entity.Type == MessageEntityTypeTextLink && entity.URL != nil {
+			links = append(links, TextLink{
+				Text: EntityText(text, entity),
+				URL:  *entity.URL,
+			})
+		}
+	}
+	return links
+}
+
+// Mentions returns all mentions (`mention` entities, eg. "@username") in Message's text (or caption).
+func (m *Message) Mentions() []string {
+	return m.entityTexts(MessageEntityTypeMention)
+}
+
+// TextMentions returns all mentions of users without usernames (`text_mention` entities) in Message's text (or caption).
+func (m *Message) TextMentions() (mentions []TextMention) {
+	text, entities := m.TextWithEntities()
+	for _, entity := range entities {
+		if entity.Type == MessageEntityTypeTextMention && entity.User != nil {
+			mentions = append(mentions, TextMention{
+				Text: EntityText(text, entity),
+				User: *entity.User,
+			})
+		}
+	}
+	return mentions
+}
+
+// Hashtags returns all hashtags (eg. "#hashtag") in Message's text (or caption).
+func (m *Message) Hashtags() []string {
+	return m.entityTexts(MessageEntityTypeHashTag)
+}
+
+// Cashtags returns all cashtags (eg. "$USD") in Message's text (or caption).
+func (m *Message) Cashtags() []string {
+	return m.entityTexts(MessageEntityTypeCashTag)
+}
+
+// BotCommands returns all bot commands (eg. "/start", "/help@bot") in Message's text (or caption).
+func (m *Message) BotCommands() []string {
+	return m.entityTexts(MessageEntityTypeBotCommand)
+}
+
+// get the texts of entities with given type
+func (m *Message) entityTexts(typ MessageEntityType) (texts []string) {
+	text, entities := m.TextWithEntities()
+	for _, entity := range entities {
+		if entity.Type == typ {
+			texts = append(texts, EntityText(text, entity))
+		}
+	}
+	return texts
+}
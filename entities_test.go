@@ -0,0 +1,76 @@
+// entities_test.go
+//
+// offline tests of extracting texts from message entities
+
+package telegrambot_test
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// entity texts should be extracted with UTF-16 offsets.
+func TestExtractEntities(t *testing.T) {
+	slog.Info("testing extraction of entity texts...")
+
+	// "🇰🇷" is 4, "😀" is 2, and "é" is 1 UTF-16 code units long
+	text := "🇰🇷 /start@bot 😀 @café #tag $USD https://example.com here"
+	message := telegrambottest.NewUpdate().Message().Text(text).Entities(
+		NewMessageEntity(MessageEntityTypeMention, 19, 5),
+		NewMessageEntity(MessageEntityTypeHashTag, 25, 4),
+		NewMessageEntity(MessageEntityTypeCashTag, 30, 4),
+		NewMessageEntity(MessageEntityTypeURL, 35, 19),
+		NewMessageEntity(MessageEntityTypeTextLink, 55, 4).SetURL("https://example.com/here"),
+	).Message()
+
+	if commands := message.BotCommands(); !slices.Equal(commands, []string{"/start@bot"}) {
+		t.Errorf("unexpected bot commands: %v", commands)
+	}
+	if mentions := message.Mentions(); !slices.Equal(mentions, []string{"@café"}) {
+		t.Errorf("unexpected mentions: %v", mentions)
+	}
+	if hashtags := message.Hashtags(); !slices.Equal(hashtags, []string{"#tag"}) {
+		t.Errorf("unexpected hashtags: %v", hashtags)
+	}
+	if cashtags := message.Cashtags(); !slices.Equal(cashtags, []string{"$USD"}) {
+		t.Errorf("unexpected cashtags: %v", cashtags)
+	}
+	if urls := message.URLs(); !slices.Equal(urls, []string{"https://example.com"}) {
+		t.Errorf("unexpected urls: %v", urls)
+	}
+	if links := message.TextLinks(); len(links) != 1 || links[0].Text != "here" || links[0].URL != "https://example.com/here" {
+		t.Errorf("unexpected text links: %+v", links)
+	}
+
+	// out-of-range entities
+	if substr := message.EntityText(NewMessageEntity(MessageEntityTypeBold, 100, 5)); substr != "" {
+		t.Errorf("expected an empty string, got %q", substr)
+	}
+
+	// caption
+	photo := telegrambottest.NewUpdate().Message().Photo("photo").Caption("😀 /help").Message()
+	if commands := photo.BotCommands(); !slices.Equal(commands, []string{"/help"}) {
+		t.Errorf("unexpected bot commands in caption: %v", commands)
+	}
+}
+
+// formatted messages should be converted back to parse mode strings.
+func TestMessageToParseModes(t *testing.T) {
+	slog.Info("testing conversion of formatted messages...")
+
+	message := telegrambottest.NewUpdate().Message().Text("😀 bold & <code>").Entities(
+		NewMessageEntity(MessageEntityTypeBold, 3, 4),
+		NewMessageEntity(MessageEntityTypeCode, 10, 6),
+	).Message()
+
+	if html := message.HTML(); html != "😀 <b>bold</b> &amp; <code>&lt;code&gt;</code>" {
+		t.Errorf("unexpected html: %s", html)
+	}
+	if md := message.MarkdownV2(); md != "😀 *bold* & `<code>`" {
+		t.Errorf("unexpected markdown v2: %s", md)
+	}
+}
@@ -0,0 +1,63 @@
+// export_test.go
+//
+// exports of unexported identifiers for external tests (package telegrambot_test),
+// which build updates with package telegrambottest
+
+package telegrambot
+
+// TestToken is a fake bot token for tests.
+const TestToken = testToken
+
+// test helpers and unexported functions
+var (
+	NewTestRecordingServer = newTestRecordingServer
+	HandleUpdateAsCommand  = handleUpdateAsCommand
+	ValidateFormAnswer     = validateFormAnswer
+	FormTargetType         = formTargetType
+)
+
+// TestCallbackAction is a value of callback data for tests.
+type TestCallbackAction = testCallbackAction
+
+// CallsTo returns recorded calls to given method.
+func (s *testRecordingServer) CallsTo(method string) []map[string]string {
+	return s.callsTo(method)
+}
+
+// DispatchUpdates dispatches given updates to handlers.
+func (b *Bot) DispatchUpdates(updates []Update) {
+	b.dispatchUpdates(updates)
+}
+
+// WaitForHandlers waits for running handlers to finish.
+func (b *Bot) WaitForHandlers() {
+	b.waitForHandlers()
+}
+
+// CommandHandler returns the handler of given command.
+func (b *Bot) CommandHandler(command string) func(b *Bot, update Update, args string) {
+	return b.commandHandlers[command]
+}
+
+// SetUpdateHandler sets the handler of updates which are not dispatched (eg. with errors).
+func (b *Bot) SetUpdateHandler(handler func(b *Bot, update Update, err error)) {
+	b.updateHandler = handler
+}
+
+// NumWaiters returns the number of registered waiters.
+func (b *Bot) NumWaiters() int {
+	b.waiters.mu.Lock()
+	defer b.waiters.mu.Unlock()
+
+	return len(b.waiters.waiters)
+}
+
+// GrantedTo checks if given chat member has the right.
+func (r AdminRight) GrantedTo(member ChatMember) bool {
+	return r.grantedTo(member)
+}
+
+// ValidateSchema checks if all fields can be stored in T.
+func (f Form[T]) ValidateSchema() error {
+	return f.validateSchema()
+}
@@ -0,0 +1,458 @@
+package telegrambot
+
+// Multi-step forms which ask fields in turn, with validation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFormTimeout    = 5 * time.Minute
+	defaultFormDateLayout = time.DateOnly
+)
+
+var (
+	// ErrFormCancelled is returned when a form is cancelled by the user.
+	ErrFormCancelled = errors.New("form is cancelled")
+
+	phoneNumberRegex = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,}[0-9]$`)
+)
+
+// FormFieldKind is a kind of a form field.
+type FormFieldKind string
+
+// FormFieldKind values
+const (
+	FormFieldText     FormFieldKind = "text"     // a text (string)
+	FormFieldNumber   FormFieldKind = "number"   // a number (int*, uint*, float*, or string)
+	FormFieldEmail    FormFieldKind = "email"    // an email address (string)
+	FormFieldDate     FormFieldKind = "date"     // a date (time.Time or string)
+	FormFieldPhone    FormFieldKind = "phone"    // a phone number from a shared contact or a text (string, Contact, or *Contact)
+	FormFieldLocation FormFieldKind = "location" // a location (Location or *Location)
+	FormFieldChoice   FormFieldKind = "choice"   // one of choices (string)
+	FormFieldPhoto    FormFieldKind = "photo"    // a photo (file id as string, or []PhotoSize)
+)
+
+// FormField is a field of a Form.
+type FormField struct {
+	Name   string        // name of the struct field (or its `form` tag) to store the answer
+	Kind   FormFieldKind // kind of the field (default: FormFieldText)
+	Prompt string        // text of the prompt
+
+	Choices    []string // choices of FormFieldChoice
+	DateLayout string   // layout of FormFieldDate (default: "2006-01-02")
+	Optional   bool     // can be skipped
+
+	// validates the answer in text (eg. texts, numbers, phone numbers, or file ids) additionally
+	Validate func(value string) error
+}
+
+// Form is a declarative schema of a multi-step form, whose answers are stored in a struct T.
+//
+//	type signUp struct {
+//		Email string
+//		Birth time.Time
+//		Phone string
+//		Plan  string
+//	}
+//	form := Form[signUp]{Fields: []FormField{
+//		{Name: "Email", Kind: FormFieldEmail, Prompt: "Your email?"},
+//		{Name: "Birth", Kind: FormFieldDate, Prompt: "Your birthday? (YYYY-MM-DD)", Optional: true},
+//		{Name: "Phone", Kind: FormFieldPhone, Prompt: "Your phone number?"},
+//		{Name: "Plan", Kind: FormFieldChoice, Prompt: "Plan?", Choices: []string{"free", "pro"}},
+//	}}
+//	b.AddCommandHandler("/signup", func(b *Bot, update Update, args string) {
+//		key, _ := ConversationKeyFromUpdate(update)
+//		result, err := form.Run(context.Background(), b, key)
+//		...
+//	})
+type Form[T any] struct {
+	Fields []FormField
+
+	BackText   string // text of the back button (default: "⬅️ Back")
+	SkipText   string // text of the skip button (default: "Skip")
+	CancelText string // text of the cancel button (default: "Cancel")
+	PhoneText  string // text of the button for sharing a phone number (default: "📱 Share my phone number")
+	PlaceText  string // text of the button for sharing a location (default: "📍 Share my location")
+
+	// text sent when the form is completed or cancelled, removing the keyboard (empty for none)
+	DoneText      string
+	CancelledText string
+
+	// generates the text sent for an invalid answer (default: the error message)
+	InvalidText func(field FormField, err error) string
+
+	// max duration of waiting for each answer (default: 5 minutes)
+	Timeout time.Duration
+}
+
+// Run asks fields of the form in turn to the user of given key, in its chat (and forum topic),
+// and returns the answers stored in T.
+//
+// It should be called in a handler, as it blocks until the form is completed (with WaitForUpdate).
+// ErrFormCancelled is returned when the user cancels the form.
+func (f Form[T]) Run(ctx context.Context, b *Bot, key ConversationKey) (result T, err error) {
+	if err := f.validateSchema(); err != nil {
+		return result, err
+	}
+	target := reflect.ValueOf(&result).Elem()
+
+	answers := map[string]Message{}
+	var lastMessageID int64
+	for i := 0; i < len(f.Fields); {
+		field := f.Fields[i]
+
+		// ask
+		if _, err := sendToConversation(ctx, b, key, field.Prompt, f.promptOptions(key, i, lastMessageID)); err != nil {
+			return result, fmt.Errorf("failed to ask field %s: %w", field.Name, err)
+		}
+
+		// wait for an answer
+		message, err := f.waitForAnswer(ctx, b, key)
+		if err != nil {
+			return result, err
+		}
+		lastMessageID = message.MessageID
+
+		text := ""
+		if message.Text != nil {
+			text = strings.TrimSpace(*message.Text)
+		}
+		switch {
+		case text == cmp.Or(f.CancelText, "Cancel") || text == defaultConversationCancelCmd:
+			f.finish(ctx, b, key, f.CancelledText)
+			return result, ErrFormCancelled
+		case text == cmp.Or(f.BackText, "⬅️ Back") && i > 0:
+			i--
+			continue
+		case text == cmp.Or(f.SkipText, "Skip") && field.Optional:
+			delete(answers, field.Name)
+			i++
+			continue
+		}
+
+		targetType, _ := formTargetType(target, field.Name)
+		if err := validateFormAnswer(field, targetType, message); err != nil {
+			invalid := err.Error()
+			if f.InvalidText != nil {
+				invalid = f.InvalidText(field, err)
+			}
+			if _, err := sendToConversation(ctx, b, key, invalid, nil); err != nil {
+				return result, fmt.Errorf("failed to notify an invalid answer: %w", err)
+			}
+			continue
+		}
+
+		answers[field.Name] = message
+		i++
+	}
+
+	for _, field := range f.Fields {
+		if message, exists := answers[field.Name]; exists {
+			if err := setFormAnswer(&result, field, message); err != nil {
+				f.finish(ctx, b, key, f.CancelledText)
+				return result, err
+			}
+		}
+	}
+
+	f.finish(ctx, b, key, f.DoneText)
+	return result, nil
+}
+
+// check if all fields can be stored in T
+func (f Form[T]) validateSchema() error {
+	var result T
+	v := reflect.ValueOf(&result).Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("form result should be a struct, got %s", v.Kind())
+	}
+	for _, field := range f.Fields {
+		targetType, found := formTargetType(v, field.Name)
+		if !found {
+			return fmt.Errorf("no struct field for form field %s", field.Name)
+		}
+		if !formFieldAccepts(field.Kind, targetType) {
+			return fmt.Errorf("cannot store form field %s (%s) in %s", field.Name, cmp.Or(field.Kind, FormFieldText), targetType)
+		}
+		if field.Kind == FormFieldChoice && len(field.Choices) == 0 {
+			return fmt.Errorf("no choices for form field %s", field.Name)
+		}
+	}
+	return nil
+}
+
+// check if an answer of given kind can be stored in a struct field of given (dereferenced) type
+func formFieldAccepts(kind FormFieldKind, targetType reflect.Type) bool {
+	switch kind {
+	case FormFieldNumber:
+		switch targetType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String:
+			return true
+		}
+		return false
+	case FormFieldDate:
+		return targetType == reflect.TypeFor[time.Time]() || targetType.Kind() == reflect.String
+	case FormFieldPhone:
+		return targetType == reflect.TypeFor[Contact]() || targetType.Kind() == reflect.String
+	case FormFieldLocation:
+		return targetType == reflect.TypeFor[Location]()
+	case FormFieldPhoto:
+		return targetType == reflect.TypeFor[[]PhotoSize]() || targetType.Kind() == reflect.String
+	default:
+		return targetType.Kind() == reflect.String
+	}
+}
+
+// options of the prompt of i-th field, with a reply keyboard
+func (f Form[T]) promptOptions(key ConversationKey, i int, replyTo int64) OptionsSendMessage {
+	field := f.Fields[i]
+
+	keyboard := NewReplyKeyboardBuilder().AutoColumns(30)
+	switch field.Kind {
+	case FormFieldChoice:
+		keyboard.Text(field.Choices...)
+	case FormFieldPhone:
+		keyboard.RequestContact(cmp.Or(f.PhoneText, "📱 Share my phone number"))
+	case FormFieldLocation:
+		keyboard.RequestLocation(cmp.Or(f.PlaceText, "📍 Share my location"))
+	}
+	keyboard.Row().
+		ButtonIf(i > 0, NewKeyboardButton(cmp.Or(f.BackText, "⬅️ Back"))).
+		ButtonIf(field.Optional, NewKeyboardButton(cmp.Or(f.SkipText, "Skip"))).
+		Text(cmp.Or(f.CancelText, "Cancel"))
+
+	markup := keyboard.Build().SetResizeKeyboard(true).SetOneTimeKeyboard(true)
+	options := OptionsSendMessage{}
+
+	// show the keyboard only to the user in group chats
+	if key.ChatID != key.UserID && replyTo != 0 {
+		markup = markup.SetSelective(true)
+		options = options.SetReplyParameters(NewReplyParameters(replyTo).SetAllowSendingWithoutReply(true))
+	}
+
+	return options.SetReplyMarkup(markup)
+}
+
+// wait for an answer from the user of given key
+func (f Form[T]) waitForAnswer(ctx context.Context, b *Bot, key ConversationKey) (Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(f.Timeout, defaultFormTimeout))
+	defer cancel()
+
+	update, err := b.WaitForUpdate(ctx, func(update Update) bool {
+		k, ok := ConversationKeyFromUpdate(update)
+		return ok && k == key && !update.HasCallbackQuery()
+	})
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to wait for an answer: %w", err)
+	}
+	return *conversationMessage(update), nil
+}
+
+// send given text (if any) with the keyboard removed
+func (f Form[T]) finish(ctx context.Context, b *Bot, key ConversationKey, text string) {
+	if text == "" {
+		return
+	}
+	if _, err := sendToConversation(ctx, b, key, text, OptionsSendMessage{}.SetReplyMarkup(NewReplyKeyboardRemove(true))); err != nil {
+		b.error("failed to finish form: %s", err)
+	}
+}
+
+// validate an answer for given field, to be stored in a struct field of given (dereferenced) type
+func validateFormAnswer(field FormField, targetType reflect.Type, message Message) error {
+	text := ""
+	if message.Text != nil {
+		text = strings.TrimSpace(*message.Text)
+	}
+
+	switch field.Kind {
+	case FormFieldLocation:
+		if !message.HasLocation() {
+			return fmt.Errorf("please share a location")
+		}
+		return nil
+	case FormFieldPhoto:
+		if !message.HasPhoto() {
+			return fmt.Errorf("please send a photo")
+		}
+		text = largestPhoto(message.Photo).FileID
+	case FormFieldPhone:
+		if message.HasContact() {
+			text = message.Contact.PhoneNumber
+		} else if !phoneNumberRegex.MatchString(text) {
+			return fmt.Errorf("please send a valid phone number")
+		}
+	case FormFieldNumber:
+		if err := validateFormNumber(text, targetType); err != nil {
+			return err
+		}
+	case FormFieldEmail:
+		if address, err := mail.ParseAddress(text); err != nil || address.Address != text {
+			return fmt.Errorf("please send a valid email address")
+		}
+	case FormFieldDate:
+		layout := cmp.Or(field.DateLayout, defaultFormDateLayout)
+		if _, err := time.Parse(layout, text); err != nil {
+			return fmt.Errorf("please send a date in format: %s", layout)
+		}
+	case FormFieldChoice:
+		if !slices.Contains(field.Choices, text) {
+			return fmt.Errorf("please choose one of: %s", strings.Join(field.Choices, ", "))
+		}
+	default:
+		if text == "" {
+			return fmt.Errorf("please send a text")
+		}
+	}
+
+	if field.Validate != nil {
+		return field.Validate(text)
+	}
+	return nil
+}
+
+// validate a number to be stored in given type, without overflows
+func validateFormNumber(text string, targetType reflect.Type) error {
+	switch targetType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || targetType.OverflowInt(n) {
+			return fmt.Errorf("please send an integer between %d and %d", int64(math.MinInt64)>>(64-targetType.Bits()), int64(math.MaxInt64)>>(64-targetType.Bits()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil || targetType.OverflowUint(n) {
+			return fmt.Errorf("please send an integer between 0 and %d", uint64(math.MaxUint64)>>(64-targetType.Bits()))
+		}
+	default:
+		bits := 64
+		if targetType.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		n, err := strconv.ParseFloat(text, bits)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return fmt.Errorf("please send a number")
+		}
+	}
+	return nil
+}
+
+// store an answer into the struct field of given form field
+func setFormAnswer(ptr any, field FormField, message Message) error {
+	target, _ := formStructField(reflect.ValueOf(ptr).Elem(), field.Name)
+	targetType, _ := formTargetType(reflect.ValueOf(ptr).Elem(), field.Name)
+
+	var value any
+	text := ""
+	if message.Text != nil {
+		text = strings.TrimSpace(*message.Text)
+	}
+
+	switch field.Kind {
+	case FormFieldLocation:
+		value = *message.Location
+	case FormFieldPhoto:
+		value = largestPhoto(message.Photo).FileID
+		if targetType == reflect.TypeFor[[]PhotoSize]() {
+			value = message.Photo
+		}
+	case FormFieldPhone:
+		value = text
+		if message.HasContact() {
+			value = message.Contact.PhoneNumber
+			if targetType == reflect.TypeFor[Contact]() {
+				value = *message.Contact
+			}
+		}
+	case FormFieldDate:
+		value = text
+		if targetType == reflect.TypeFor[time.Time]() {
+			value, _ = time.Parse(cmp.Or(field.DateLayout, defaultFormDateLayout), text)
+		}
+	case FormFieldNumber:
+		value = text
+		switch targetType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to store field %s: %w", field.Name, err)
+			}
+			value = n
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(text, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to store field %s: %w", field.Name, err)
+			}
+			value = n
+		case reflect.Float32, reflect.Float64:
+			n, err := strconv.ParseFloat(text, targetType.Bits())
+			if err != nil {
+				return fmt.Errorf("failed to store field %s: %w", field.Name, err)
+			}
+			value = n
+		}
+	default:
+		value = text
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.Type().ConvertibleTo(targetType) ||
+		(v.CanInt() && targetType.OverflowInt(v.Int())) || (v.CanUint() && targetType.OverflowUint(v.Uint())) {
+		return fmt.Errorf("cannot store field %s (%s) in %s", field.Name, field.Kind, target.Type())
+	}
+	if target.Kind() == reflect.Pointer {
+		ptr := reflect.New(targetType)
+		ptr.Elem().Set(v.Convert(targetType))
+		target.Set(ptr)
+	} else {
+		target.Set(v.Convert(targetType))
+	}
+	return nil
+}
+
+// find a struct field by its `form` tag or name
+func formStructField(v reflect.Value, name string) (reflect.Value, bool) {
+	for field := range v.Type().Fields() {
+		if field.IsExported() && (field.Tag.Get("form") == name || field.Name == name) {
+			return v.FieldByIndex(field.Index), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// (dereferenced) type of the struct field by its `form` tag or name
+func formTargetType(v reflect.Value, name string) (reflect.Type, bool) {
+	target, found := formStructField(v, name)
+	if !found {
+		return nil, false
+	}
+	if target.Kind() == reflect.Pointer {
+		return target.Type().Elem(), true
+	}
+	return target.Type(), true
+}
+
+// the largest one of photo sizes
+func largestPhoto(photos []PhotoSize) PhotoSize {
+	largest := photos[0]
+	for _, photo := range photos[1:] {
+		if photo.Width*photo.Height > largest.Width*largest.Height {
+			largest = photo
+		}
+	}
+	return largest
+}
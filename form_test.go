@@ -0,0 +1,184 @@
+// form_test.go
+//
+// offline tests of multi-step forms
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+type testSignUp struct {
+	Email string
+	Birth *time.Time
+	Phone Contact
+	Plan  string `form:"plan"`
+	Age   int
+	Photo string
+}
+
+// forms should ask fields in turn, and return answers in a struct.
+func TestForm(t *testing.T) {
+	slog.Info("testing forms...")
+
+	server := NewTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(TestToken)
+	client.SetBaseURL(server.URL)
+
+	user := telegrambottest.User()
+	chat := telegrambottest.ForumChat()
+	key := ConversationKey{ChatID: chat.ID, UserID: user.ID, ThreadID: 7}
+
+	form := Form[testSignUp]{
+		Fields: []FormField{
+			{Name: "Email", Kind: FormFieldEmail, Prompt: "email?"},
+			{Name: "Birth", Kind: FormFieldDate, Prompt: "birth?", Optional: true},
+			{Name: "Phone", Kind: FormFieldPhone, Prompt: "phone?"},
+			{Name: "plan", Kind: FormFieldChoice, Prompt: "plan?", Choices: []string{"free", "pro"}},
+			{Name: "Age", Kind: FormFieldNumber, Prompt: "age?", Validate: func(value string) error {
+				if strings.HasPrefix(value, "-") {
+					return errors.New("age should be positive")
+				}
+				return nil
+			}},
+			{Name: "Photo", Kind: FormFieldPhoto, Prompt: "photo?"},
+		},
+		DoneText: "done",
+		Timeout:  time.Second,
+	}
+
+	type result struct {
+		value testSignUp
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		value, err := form.Run(context.Background(), client, key)
+		results <- result{value, err}
+	}()
+
+	reply := func(build func(*telegrambottest.MessageBuilder) *telegrambottest.MessageBuilder) {
+		time.Sleep(30 * time.Millisecond)
+		client.DispatchUpdates([]Update{build(telegrambottest.NewUpdate().Message().From(user).InChat(chat).InThread(7)).Build()})
+	}
+	text := func(text string) {
+		reply(func(m *telegrambottest.MessageBuilder) *telegrambottest.MessageBuilder { return m.Text(text) })
+	}
+
+	text("not an email")
+	text("user@example.com")
+	text("1999-01-02")
+	text("⬅️ Back")
+	text("Skip")
+	reply(func(m *telegrambottest.MessageBuilder) *telegrambottest.MessageBuilder {
+		return m.Contact("+821012345678", "Tester")
+	})
+	text("enterprise")
+	text("pro")
+	text("-1")
+	text("42")
+	reply(func(m *telegrambottest.MessageBuilder) *telegrambottest.MessageBuilder {
+		return m.Photo("photo-file-id")
+	})
+
+	r := <-results
+	if r.err != nil {
+		t.Fatalf("failed to run form: %s", r.err)
+	}
+	if r.value.Email != "user@example.com" || r.value.Birth != nil || r.value.Phone.PhoneNumber != "+821012345678" ||
+		r.value.Plan != "pro" || r.value.Age != 42 || r.value.Photo != "photo-file-id" {
+		t.Errorf("unexpected result: %+v", r.value)
+	}
+
+	texts := []string{}
+	for _, call := range server.CallsTo("sendMessage") {
+		if call["message_thread_id"] != "7" {
+			t.Errorf("expected messages in the thread: %+v", call)
+		}
+		texts = append(texts, call["text"])
+	}
+	expected := "email?|please send a valid email address|email?|birth?|phone?|birth?|phone?|plan?|please choose one of: free, pro|plan?|age?|age should be positive|age?|photo?|done"
+	if strings.Join(texts, "|") != expected {
+		t.Errorf("unexpected messages:\n%s\nexpected:\n%s", strings.Join(texts, "|"), expected)
+	}
+
+	// cancel
+	go func() {
+		_, err := form.Run(context.Background(), client, key)
+		results <- result{err: err}
+	}()
+	text("/cancel")
+	if r := <-results; !errors.Is(r.err, ErrFormCancelled) {
+		t.Errorf("expected ErrFormCancelled, got: %v", r.err)
+	}
+}
+
+// answers should be validated against types of their struct fields.
+func TestFormAnswerTypes(t *testing.T) {
+	slog.Info("testing validation of form answers against their types...")
+
+	type numbers struct {
+		Small  int8
+		Count  uint
+		Ratio  float32
+		Amount string
+	}
+	form := Form[numbers]{Fields: []FormField{
+		{Name: "Small", Kind: FormFieldNumber},
+		{Name: "Count", Kind: FormFieldNumber},
+		{Name: "Ratio", Kind: FormFieldNumber},
+		{Name: "Amount", Kind: FormFieldNumber},
+	}}
+	if err := form.ValidateSchema(); err != nil {
+		t.Fatalf("failed to validate schema: %s", err)
+	}
+
+	for _, tc := range []struct {
+		field string
+		text  string
+		valid bool
+	}{
+		{"Small", "127", true},
+		{"Small", "-128", true},
+		{"Small", "128", false},
+		{"Small", "3.5", false},
+		{"Count", "42", true},
+		{"Count", "-1", false},
+		{"Count", "3.5", false},
+		{"Ratio", "0.5", true},
+		{"Ratio", "1e39", false},
+		{"Ratio", "NaN", false},
+		{"Amount", "12.5", true},
+		{"Amount", "Inf", false},
+		{"Amount", "NaN", false},
+	} {
+		targetType, _ := FormTargetType(reflect.ValueOf(&numbers{}).Elem(), tc.field)
+		err := ValidateFormAnswer(FormField{Name: tc.field, Kind: FormFieldNumber}, targetType, Message{Text: &tc.text})
+		if (err == nil) != tc.valid {
+			t.Errorf("unexpected validation of %q for %s: %v", tc.text, tc.field, err)
+		}
+	}
+
+	// kinds of fields which cannot be stored in their struct fields
+	for _, field := range []FormField{
+		{Name: "Small", Kind: FormFieldText},
+		{Name: "Count", Kind: FormFieldDate},
+		{Name: "Ratio", Kind: FormFieldLocation},
+		{Name: "Amount", Kind: FormFieldLocation},
+	} {
+		if err := (Form[numbers]{Fields: []FormField{field}}).ValidateSchema(); err == nil {
+			t.Errorf("expected an error for storing %s in %s", field.Kind, field.Name)
+		}
+	}
+}
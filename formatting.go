@@ -0,0 +1,573 @@
+package telegrambot
+
+// Escaping of texts for parse modes, and building of formatted texts
+//
+// https://core.telegram.org/bots/api#formatting-options
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	markdownV2Escaper     = newBackslashEscaper("_*[]()~`>#+-=|{}.!\\")
+	markdownV2CodeEscaper = newBackslashEscaper("`\\")
+	markdownV2URLEscaper  = newBackslashEscaper(")\\")
+	markdownEscaper       = newBackslashEscaper("_*`[")
+	htmlEscaper           = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	htmlAttrEscaper       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// returns a replacer which prepends a backslash to each of given characters
+func newBackslashEscaper(chars string) *strings.Replacer {
+	pairs := []string{}
+	for _, c := range chars {
+		pairs = append(pairs, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// EscapeMarkdownV2 escapes given text for `ParseModeMarkdownV2`.
+//
+// https://core.telegram.org/bots/api#markdownv2-style
+func EscapeMarkdownV2(text string) string {
+	return markdownV2Escaper.Replace(text)
+}
+
+// EscapeMarkdownV2Code escapes given text inside `pre` or `code` entities for `ParseModeMarkdownV2`.
+func EscapeMarkdownV2Code(text string) string {
+	return markdownV2CodeEscaper.Replace(text)
+}
+
+// EscapeMarkdownV2URL escapes given url inside `(...)` of inline links for `ParseModeMarkdownV2`.
+func EscapeMarkdownV2URL(url string) string {
+	return markdownV2URLEscaper.Replace(url)
+}
+
+// EscapeMarkdown escapes given text for (legacy) `ParseModeMarkdown`.
+//
+// https://core.telegram.org/bots/api#markdown-style
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// EscapeHTML escapes given text for `ParseModeHTML`.
+//
+// https://core.telegram.org/bots/api#html-style
+func EscapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+// Escape escapes given text for given parse mode.
+func Escape(parseMode ParseMode, text string) string {
+	switch parseMode {
+	case ParseModeMarkdownV2:
+		return EscapeMarkdownV2(text)
+	case ParseModeMarkdown:
+		return EscapeMarkdown(text)
+	case ParseModeHTML:
+		return EscapeHTML(text)
+	}
+	return text
+}
+
+////////////////////////////////
+// TextBuilder
+//
+
+// TextBuilder builds a formatted text, as a plain text with entities,
+// or as a string for `ParseModeHTML` or `ParseModeMarkdownV2`.
+//
+//	text, entities := NewTextBuilder().Bold("Hello").Text(", ").Mention(user).Text("!").Build()
+type TextBuilder struct {
+	text     strings.Builder
+	length   int // length of text in UTF-16 code units
+	entities []MessageEntity
+}
+
+// NewTextBuilder returns a new TextBuilder.
+func NewTextBuilder() *TextBuilder {
+	return &TextBuilder{}
+}
+
+// Text appends a plain text.
+func (b *TextBuilder) Text(text string) *TextBuilder {
+	b.text.WriteString(text)
+	b.length += utf16Length(text)
+	return b
+}
+
+// Styled appends a text with given entity.
+//
+// Offset and length of the entity will be filled in automatically.
+func (b *TextBuilder) Styled(entity MessageEntity, text string) *TextBuilder {
+	return b.With(entity, func(b *TextBuilder) {
+		b.Text(text)
+	})
+}
+
+// With applies given entity to all texts appended in `build`,
+// so that entities can be nested.
+//
+//	NewTextBuilder().With(NewMessageEntity(MessageEntityTypeBlockquote, 0, 0), func(b *TextBuilder) {
+//		b.Bold("quoted").Text(" text")
+//	})
+func (b *TextBuilder) With(entity MessageEntity, build func(b *TextBuilder)) *TextBuilder {
+	offset := b.length
+	build(b)
+
+	if b.length > offset {
+		entity.Offset = offset
+		entity.Length = b.length - offset
+		b.entities = append(b.entities, entity)
+	}
+	return b
+}
+
+// Bold appends a bold text.
+func (b *TextBuilder) Bold(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeBold, 0, 0), text)
+}
+
+// Italic appends an italic text.
+func (b *TextBuilder) Italic(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeItalic, 0, 0), text)
+}
+
+// Underline appends an underlined text.
+func (b *TextBuilder) Underline(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeUnderline, 0, 0), text)
+}
+
+// Strikethrough appends a strikethrough text.
+func (b *TextBuilder) Strikethrough(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeStrikethrough, 0, 0), text)
+}
+
+// Spoiler appends a spoiler text.
+func (b *TextBuilder) Spoiler(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeSpoiler, 0, 0), text)
+}
+
+// Code appends an inline fixed-width code.
+func (b *TextBuilder) Code(code string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeCode, 0, 0), code)
+}
+
+// Pre appends a pre-formatted code block, with an optional programming language.
+func (b *TextBuilder) Pre(code, language string) *TextBuilder {
+	entity := NewMessageEntity(MessageEntityTypePre, 0, 0)
+	if language != "" {
+		entity = entity.SetLanguage(language)
+	}
+	return b.Styled(entity, code)
+}
+
+// Link appends a text with a link to given url.
+func (b *TextBuilder) Link(text, url string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeTextLink, 0, 0).SetURL(url), text)
+}
+
+// Mention appends a mention of given user (with the user's name).
+func (b *TextBuilder) Mention(user User) *TextBuilder {
+	name := user.FirstName
+	if user.LastName != nil {
+		name += " " + *user.LastName
+	}
+	return b.MentionWithText(name, user)
+}
+
+// MentionWithText appends a mention of given user with given text.
+func (b *TextBuilder) MentionWithText(text string, user User) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeTextMention, 0, 0).SetUser(user), text)
+}
+
+// Blockquote appends a block quotation.
+func (b *TextBuilder) Blockquote(text string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeBlockquote, 0, 0), text)
+}
+
+// CustomEmoji appends a custom emoji, with an alternative emoji.
+func (b *TextBuilder) CustomEmoji(emoji, customEmojiID string) *TextBuilder {
+	return b.Styled(NewMessageEntity(MessageEntityTypeCustomEmoji, 0, 0).SetCustomEmojiID(customEmojiID), emoji)
+}
+
+// Len returns the length of built text in UTF-16 code units.
+func (b *TextBuilder) Len() int {
+	return b.length
+}
+
+// String returns the built plain text.
+func (b *TextBuilder) String() string {
+	return b.text.String()
+}
+
+// Build returns the built plain text and its entities,
+// which can be sent with `OptionsSendMessage.SetEntities()`.
+func (b *TextBuilder) Build() (text string, entities []MessageEntity) {
+	entities = slices.Clone(b.entities)
+	sortEntities(entities)
+
+	return b.text.String(), entities
+}
+
+// HTML returns the built text for `ParseModeHTML`.
+func (b *TextBuilder) HTML() string {
+	return EntitiesToHTML(b.Build())
+}
+
+// MarkdownV2 returns the built text for `ParseModeMarkdownV2`.
+func (b *TextBuilder) MarkdownV2() string {
+	return EntitiesToMarkdownV2(b.Build())
+}
+
+////////////////////////////////
+// Rendering of text with entities
+//
+
+// entityRenderer renders texts and entities for a parse mode
+type entityRenderer interface {
+	open(entity MessageEntity) string
+	close(entity MessageEntity) string
+	text(text string, enclosing []MessageEntity) string
+}
+
+// sort entities by their offsets (outer ones first)
+func sortEntities(entities []MessageEntity) {
+	slices.SortStableFunc(entities, func(a, b MessageEntity) int {
+		if c := cmp.Compare(a.Offset, b.Offset); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Length, a.Length)
+	})
+}
+
+// render given text with entities (offsets and lengths in UTF-16 code units)
+//
+// Overlapping entities will be closed and reopened for being nested properly.
+func renderEntities(text string, entities []MessageEntity, r entityRenderer) string {
+	entities = slices.Clone(entities)
+	sortEntities(entities)
+
+	var out, segment strings.Builder
+	stack := []MessageEntity{}
+	next := 0
+
+	flush := func() {
+		if segment.Len() > 0 {
+			out.WriteString(r.text(segment.String(), stack))
+			segment.Reset()
+		}
+	}
+
+	// close entities which end at (or before) given position
+	closeEnded := func(pos int) {
+		lowest := -1
+		for i, e := range stack {
+			if e.Offset+e.Length <= pos {
+				lowest = i
+				break
+			}
+		}
+		if lowest < 0 {
+			return
+		}
+
+		flush()
+		reopen := []MessageEntity{}
+		for i := len(stack) - 1; i >= lowest; i-- {
+			out.WriteString(r.close(stack[i]))
+			if stack[i].Offset+stack[i].Length > pos {
+				reopen = append([]MessageEntity{stack[i]}, reopen...)
+			}
+		}
+		stack = stack[:lowest]
+		for _, e := range reopen {
+			out.WriteString(r.open(e))
+			stack = append(stack, e)
+		}
+	}
+
+	// open entities which start at (or before) given position
+	openStarted := func(pos int) {
+		for next < len(entities) && entities[next].Offset <= pos {
+			if e := entities[next]; e.Length > 0 && e.Offset+e.Length > pos {
+				flush()
+				out.WriteString(r.open(e))
+				stack = append(stack, e)
+			}
+			next++
+		}
+	}
+
+	pos := 0 // in UTF-16 code units
+	for _, c := range text {
+		closeEnded(pos)
+		openStarted(pos)
+
+		segment.WriteRune(c)
+		if c >= 0x10000 {
+			pos += 2
+		} else {
+			pos++
+		}
+	}
+	flush()
+	for i := len(stack) - 1; i >= 0; i-- {
+		out.WriteString(r.close(stack[i]))
+	}
+
+	return out.String()
+}
+
+// check if any of given entities is of given types
+func hasEntityOfType(entities []MessageEntity, types ...MessageEntityType) bool {
+	return slices.ContainsFunc(entities, func(e MessageEntity) bool {
+		return slices.Contains(types, e.Type)
+	})
+}
+
+// htmlRenderer renders entities for `ParseModeHTML`
+type htmlRenderer struct{}
+
+func (r *htmlRenderer) open(e MessageEntity) string {
+	switch e.Type {
+	case MessageEntityTypeBold:
+		return "<b>"
+	case MessageEntityTypeItalic:
+		return "<i>"
+	case MessageEntityTypeUnderline:
+		return "<u>"
+	case MessageEntityTypeStrikethrough:
+		return "<s>"
+	case MessageEntityTypeSpoiler:
+		return "<tg-spoiler>"
+	case MessageEntityTypeCode:
+		return "<code>"
+	case MessageEntityTypePre:
+		if e.Language != nil {
+			return `<pre><code class="language-` + htmlAttrEscaper.Replace(*e.Language) + `">`
+		}
+		return "<pre>"
+	case MessageEntityTypeBlockquote:
+		return "<blockquote>"
+	case MessageEntityTypeTextLink:
+		if e.URL != nil {
+			return `<a href="` + htmlAttrEscaper.Replace(*e.URL) + `">`
+		}
+	case MessageEntityTypeTextMention:
+		if e.User != nil {
+			return `<a href="tg://user?id=` + strconv.FormatInt(e.User.ID, 10) + `">`
+		}
+	case MessageEntityTypeCustomEmoji:
+		if e.CustomEmojiID != nil {
+			return `<tg-emoji emoji-id="` + htmlAttrEscaper.Replace(*e.CustomEmojiID) + `">`
+		}
+	case MessageEntityTypeDateTime:
+		if e.UnixTime != nil {
+			tag := `<tg-time unix="` + strconv.Itoa(*e.UnixTime) + `"`
+			if e.DateTimeFormat != nil {
+				tag += ` format="` + htmlAttrEscaper.Replace(*e.DateTimeFormat) + `"`
+			}
+			return tag + ">"
+		}
+	}
+	return "" // mentions, hashtags, urls, ... are detected automatically
+}
+
+func (r *htmlRenderer) close(e MessageEntity) string {
+	switch e.Type {
+	case MessageEntityTypeBold:
+		return "</b>"
+	case MessageEntityTypeItalic:
+		return "</i>"
+	case MessageEntityTypeUnderline:
+		return "</u>"
+	case MessageEntityTypeStrikethrough:
+		return "</s>"
+	case MessageEntityTypeSpoiler:
+		return "</tg-spoiler>"
+	case MessageEntityTypeCode:
+		return "</code>"
+	case MessageEntityTypePre:
+		if e.Language != nil {
+			return "</code></pre>"
+		}
+		return "</pre>"
+	case MessageEntityTypeBlockquote:
+		return "</blockquote>"
+	case MessageEntityTypeTextLink:
+		if e.URL != nil {
+			return "</a>"
+		}
+	case MessageEntityTypeTextMention:
+		if e.User != nil {
+			return "</a>"
+		}
+	case MessageEntityTypeCustomEmoji:
+		if e.CustomEmojiID != nil {
+			return "</tg-emoji>"
+		}
+	case MessageEntityTypeDateTime:
+		if e.UnixTime != nil {
+			return "</tg-time>"
+		}
+	}
+	return ""
+}
+
+func (r *htmlRenderer) text(text string, _ []MessageEntity) string {
+	return EscapeHTML(text)
+}
+
+// markdownV2Renderer renders entities for `ParseModeMarkdownV2`
+type markdownV2Renderer struct {
+	endsWithUnderscore bool // whether the last output ended with a '_' marker
+	midLine            bool // whether the last output did not end with a newline
+	quoteEnded         bool // whether a blockquote was closed in the middle of a line
+}
+
+// a newline for ending the closed blockquote, if the output does not continue with one
+//
+// NOTE: a blockquote in MarkdownV2 continues until the end of the line
+func (r *markdownV2Renderer) endQuote(output string) string {
+	if !r.quoteEnded {
+		return ""
+	}
+	r.quoteEnded = false
+	if strings.HasPrefix(output, "\n") {
+		return ""
+	}
+	return "\n"
+}
+
+// keep track of the end of given output
+func (r *markdownV2Renderer) output(output string) string {
+	if output != "" {
+		r.midLine = !strings.HasSuffix(output, "\n")
+	}
+	return output
+}
+
+func (r *markdownV2Renderer) marker(marker string) string {
+	// NOTE: '\r' separates ambiguous '_' markers (eg. italic + underline)
+	if r.endsWithUnderscore && strings.HasPrefix(marker, "_") {
+		marker = "\r" + marker
+	}
+	r.endsWithUnderscore = strings.HasSuffix(marker, "_")
+	return r.output(marker)
+}
+
+func (r *markdownV2Renderer) open(e MessageEntity) string {
+	if e.Type != MessageEntityTypeBlockquote {
+		if newline := r.endQuote(""); newline != "" {
+			return r.marker(newline) + r.opening(e)
+		}
+	}
+	return r.opening(e)
+}
+
+func (r *markdownV2Renderer) opening(e MessageEntity) string {
+	switch e.Type {
+	case MessageEntityTypeBold:
+		return r.marker("*")
+	case MessageEntityTypeItalic:
+		return r.marker("_")
+	case MessageEntityTypeUnderline:
+		return r.marker("__")
+	case MessageEntityTypeStrikethrough:
+		return r.marker("~")
+	case MessageEntityTypeSpoiler:
+		return r.marker("||")
+	case MessageEntityTypeCode:
+		return r.marker("`")
+	case MessageEntityTypePre:
+		if e.Language != nil {
+			return r.marker("```" + EscapeMarkdownV2Code(*e.Language) + "\n")
+		}
+		return r.marker("```\n")
+	case MessageEntityTypeBlockquote:
+		// NOTE: a blockquote which starts in the middle of a line is moved to the next line
+		r.quoteEnded = false
+		if r.midLine {
+			return r.marker("\n>")
+		}
+		return r.marker(">")
+	case MessageEntityTypeTextLink:
+		if e.URL != nil {
+			return r.marker("[")
+		}
+	case MessageEntityTypeTextMention:
+		if e.User != nil {
+			return r.marker("[")
+		}
+	case MessageEntityTypeCustomEmoji:
+		if e.CustomEmojiID != nil {
+			return r.marker("![")
+		}
+	case MessageEntityTypeDateTime:
+		if e.UnixTime != nil {
+			return r.marker("![")
+		}
+	}
+	return ""
+}
+
+func (r *markdownV2Renderer) close(e MessageEntity) string {
+	switch e.Type {
+	case MessageEntityTypeBold:
+		return r.marker("*")
+	case MessageEntityTypeItalic:
+		return r.marker("_")
+	case MessageEntityTypeUnderline:
+		return r.marker("__")
+	case MessageEntityTypeStrikethrough:
+		return r.marker("~")
+	case MessageEntityTypeSpoiler:
+		return r.marker("||")
+	case MessageEntityTypeCode:
+		return r.marker("`")
+	case MessageEntityTypePre:
+		return r.marker("\n```")
+	case MessageEntityTypeBlockquote:
+		r.quoteEnded = r.midLine
+		return ""
+	case MessageEntityTypeTextLink:
+		if e.URL != nil {
+			return r.marker("](" + EscapeMarkdownV2URL(*e.URL) + ")")
+		}
+	case MessageEntityTypeTextMention:
+		if e.User != nil {
+			return r.marker("](tg://user?id=" + strconv.FormatInt(e.User.ID, 10) + ")")
+		}
+	case MessageEntityTypeCustomEmoji:
+		if e.CustomEmojiID != nil {
+			return r.marker("](tg://emoji?id=" + EscapeMarkdownV2URL(*e.CustomEmojiID) + ")")
+		}
+	case MessageEntityTypeDateTime:
+		if e.UnixTime != nil {
+			url := "tg://time?unix=" + strconv.Itoa(*e.UnixTime)
+			if e.DateTimeFormat != nil {
+				url += "&format=" + *e.DateTimeFormat
+			}
+			return r.marker("](" + EscapeMarkdownV2URL(url) + ")")
+		}
+	}
+	return ""
+}
+
+func (r *markdownV2Renderer) text(text string, enclosing []MessageEntity) string {
+	r.endsWithUnderscore = false
+
+	if hasEntityOfType(enclosing, MessageEntityTypeCode, MessageEntityTypePre) {
+		text = EscapeMarkdownV2Code(text)
+	} else {
+		text = EscapeMarkdownV2(text)
+	}
+	if hasEntityOfType(enclosing, MessageEntityTypeBlockquote) {
+		text = strings.ReplaceAll(text, "\n", "\n>")
+	}
+	return r.output(r.endQuote(text) + text)
+}
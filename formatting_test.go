@@ -0,0 +1,144 @@
+// formatting_test.go
+//
+// offline tests of escaping and building of formatted texts
+
+package telegrambot
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// texts should be escaped for each parse mode.
+func TestEscape(t *testing.T) {
+	slog.Info("testing escaping of texts...")
+
+	for _, tc := range []struct {
+		parseMode ParseMode
+		text      string
+		expected  string
+	}{
+		{ParseModeMarkdownV2, "1+1=2. (really!) _*[]~`>#-|{}\\", `1\+1\=2\. \(really\!\) \_\*\[\]\~\` + "`" + `\>\#\-\|\{\}\\`},
+		{ParseModeMarkdown, "snake_case *bold* `code` [link]", "snake\\_case \\*bold\\* \\`code\\` \\[link]"},
+		{ParseModeHTML, `<b>"Tom & Jerry"</b>`, `&lt;b&gt;"Tom &amp; Jerry"&lt;/b&gt;`},
+	} {
+		if escaped := Escape(tc.parseMode, tc.text); escaped != tc.expected {
+			t.Errorf("expected %q for %s, got %q", tc.expected, tc.parseMode, escaped)
+		}
+	}
+}
+
+// built texts should have entities with UTF-16 offsets, and be rendered for each parse mode.
+func TestTextBuilder(t *testing.T) {
+	slog.Info("testing building of formatted texts...")
+
+	user := User{ID: 42, FirstName: "Jane", LastName: new("Doe")}
+
+	builder := NewTextBuilder().
+		Text("👋 ").
+		Bold("hi").
+		Text(", ").
+		Mention(user).
+		Text("! see ").
+		Link("docs (v2)", "https://example.com/a_(b)").
+		Text("\n").
+		Pre("fmt.Println(`x`)", "go").
+		Text("\n").
+		With(NewMessageEntity(MessageEntityTypeBlockquote, 0, 0), func(b *TextBuilder) {
+			b.Italic("a.").Text("\nb")
+		})
+
+	text, entities := builder.Build()
+	if text != "👋 hi, Jane Doe! see docs (v2)\nfmt.Println(`x`)\na.\nb" {
+		t.Errorf("unexpected text: %q", text)
+	}
+	if len(entities) != 6 {
+		t.Fatalf("expected 6 entities, got: %+v", entities)
+	}
+	if bold := entities[0]; bold.Type != MessageEntityTypeBold || bold.Offset != 3 || bold.Length != 2 {
+		t.Errorf("unexpected bold entity: %+v", bold)
+	}
+	if mention := entities[1]; mention.Type != MessageEntityTypeTextMention || mention.Offset != 7 || mention.Length != 8 || mention.User.ID != 42 {
+		t.Errorf("unexpected mention entity: %+v", mention)
+	}
+	if quote, italic := entities[4], entities[5]; quote.Type != MessageEntityTypeBlockquote || italic.Offset != quote.Offset || quote.Length != 4 {
+		t.Errorf("expected the blockquote to be sorted before the nested italic: %+v, %+v", quote, italic)
+	}
+
+	if html := builder.HTML(); html != `👋 <b>hi</b>, <a href="tg://user?id=42">Jane Doe</a>! see <a href="https://example.com/a_(b)">docs (v2)</a>
+<pre><code class="language-go">fmt.Println(`+"`x`"+`)</code></pre>
+<blockquote><i>a.</i>
+b</blockquote>` {
+		t.Errorf("unexpected html: %s", html)
+	}
+
+	if md := builder.MarkdownV2(); md != "👋 *hi*, [Jane Doe](tg://user?id=42)\\! see [docs \\(v2\\)](https://example.com/a_(b\\))\n"+
+		"```go\nfmt.Println(\\`x\\`)\n```\n"+
+		">_a\\._\n>b" {
+		t.Errorf("unexpected markdown v2: %s", md)
+	}
+}
+
+// partially overlapping entities should be closed and reopened when rendered.
+func TestRenderOverlappingEntities(t *testing.T) {
+	slog.Info("testing rendering of overlapping entities...")
+
+	entities := []MessageEntity{
+		NewMessageEntity(MessageEntityTypeBold, 0, 4),
+		NewMessageEntity(MessageEntityTypeItalic, 2, 4),
+	}
+	if html := renderEntities("abcdef", entities, &htmlRenderer{}); html != "<b>ab<i>cd</i></b><i>ef</i>" {
+		t.Errorf("unexpected html: %s", html)
+	}
+
+	// ambiguous underscores should be separated
+	entities = []MessageEntity{
+		NewMessageEntity(MessageEntityTypeItalic, 0, 3),
+		NewMessageEntity(MessageEntityTypeUnderline, 0, 3),
+	}
+	if md := renderEntities("abc", entities, &markdownV2Renderer{}); md != "_\r__abc__\r_" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+}
+
+// blockquotes which start in the middle of a line should be moved to the next line in MarkdownV2.
+func TestRenderBlockquoteMidLine(t *testing.T) {
+	slog.Info("testing rendering of blockquotes in the middle of lines...")
+
+	entities := []MessageEntity{NewMessageEntity(MessageEntityTypeBlockquote, 4, 5)}
+	if md := renderEntities("see quote", entities, &markdownV2Renderer{}); md != "see \n>quote" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+
+	// (at the beginning of the text or a line)
+	entities = []MessageEntity{NewMessageEntity(MessageEntityTypeBlockquote, 0, 1), NewMessageEntity(MessageEntityTypeBlockquote, 2, 1)}
+	if md := renderEntities("a\nb", entities, &markdownV2Renderer{}); md != ">a\n>b" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+}
+
+// blockquotes followed by texts in the same line should be ended in MarkdownV2, like in HTML and entities.
+func TestRenderBlockquoteFollowedByText(t *testing.T) {
+	slog.Info("testing rendering of blockquotes followed by texts...")
+
+	builder := NewTextBuilder().Blockquote("quoted").Text(" after").Bold("!")
+
+	text, entities := builder.Build()
+	if text != "quoted after!" || len(entities) != 2 || entities[0].Type != MessageEntityTypeBlockquote || entities[0].Length != 6 {
+		t.Errorf("unexpected text and entities: %q, %+v", text, entities)
+	}
+	if html := builder.HTML(); html != "<blockquote>quoted</blockquote> after<b>!</b>" {
+		t.Errorf("unexpected html: %s", html)
+	}
+	if md := builder.MarkdownV2(); md != ">quoted\n after*\\!*" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+
+	// (followed by an entity, or a newline)
+	if md := NewTextBuilder().Blockquote("quoted").Bold("bold").MarkdownV2(); md != ">quoted\n*bold*" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+	if md := NewTextBuilder().Blockquote("quoted").Text("\nnext").MarkdownV2(); md != ">quoted\nnext" {
+		t.Errorf("unexpected markdown v2: %q", md)
+	}
+}
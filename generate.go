@@ -0,0 +1,6 @@
+package telegrambot
+
+// Generation of types and methods from the Bot API spec (see internal/apigen)
+
+//go:generate go run ./internal/apigen -spec internal/apigen/botapi.json -out generated.go
+//go:generate go run ./internal/mockgen -src methods.go -interfaces capabilities.go -mock telegrambotmock/mock_generated.go
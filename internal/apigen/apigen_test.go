@@ -0,0 +1,172 @@
+// apigen_test.go
+//
+// offline tests of generating code from the Bot API spec
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// hand-written code for tests
+const testHandWritten = `package telegrambot
+
+import "context"
+
+type MethodOptions map[string]any
+
+type User struct {
+	ID        int64  ` + "`json:\"id\"`" + `
+	FirstName string ` + "`json:\"first_name\"`" + `
+	Stale     *bool  ` + "`json:\"stale,omitempty\"`" + `
+}
+
+type OptionsSendChatAction MethodOptions
+
+func (o OptionsSendChatAction) SetMessageThreadID(messageThreadID int) OptionsSendChatAction {
+	o["message_thread_id"] = messageThreadID
+	return o
+}
+
+func (b *Bot) SendChatAction(ctx context.Context, chatID ChatID, options OptionsSendChatAction) (result APIResponse[bool], err error) {
+	return
+}
+
+func (b *Bot) SetMyName(ctx context.Context, options OptionsSetMyName) (result APIResponse[bool], err error) {
+	return
+}
+`
+
+// test spec
+var testSpec = Spec{
+	Types: []Type{
+		{Name: "User", Fields: []Field{
+			{Name: "id", Type: "Integer"},
+			{Name: "first_name", Type: "String"},
+			{Name: "last_name", Type: "String", Optional: true},
+		}},
+		{Name: "Story", Description: "is a struct of a story", Fields: []Field{
+			{Name: "chat", Type: "Chat"},
+			{Name: "id", Type: "Integer"},
+		}},
+	},
+	Methods: []Method{
+		{Name: "sendChatAction", Returns: "True", Params: []Field{
+			{Name: "business_connection_id", Type: "String", Optional: true},
+			{Name: "chat_id", Type: "Integer or String"},
+			{Name: "message_thread_id", Type: "Integer", Optional: true},
+			{Name: "action", Type: "String", GoType: "ChatAction"},
+		}},
+		{Name: "setMyName", Returns: "True", Params: []Field{
+			{Name: "name", Type: "String", Optional: true},
+			{Name: "language_code", Type: "String", Optional: true},
+		}},
+		{Name: "getMe", Description: "gets info of this bot.", Returns: "User"},
+		{Name: "editMessageCaption", Description: "edits caption of a message.", Returns: "Message or True", Params: []Field{
+			{Name: "chat_id", Type: "Integer or String", Optional: true},
+			{Name: "caption", Type: "String", Optional: true},
+		}},
+		{Name: "deleteStory", Description: "deletes a story.", Returns: "True", Params: []Field{
+			{Name: "business_connection_id", Type: "String"},
+			{Name: "story_id", Type: "Integer"},
+		}},
+	},
+}
+
+// names should be converted with initialisms.
+func TestGoNames(t *testing.T) {
+	slog.Info("testing names of generated code...")
+
+	for name, expected := range map[string]string{
+		"message_thread_id": "MessageThreadID",
+		"url":               "URL",
+		"is_bot":            "IsBot",
+	} {
+		if converted := goFieldName(name); converted != expected {
+			t.Errorf("expected field name '%s' for '%s', got '%s'", expected, name, converted)
+		}
+	}
+	if converted := goParamName("icon_custom_emoji_id"); converted != "iconCustomEmojiID" {
+		t.Errorf("unexpected param name: %s", converted)
+	}
+	if converted := (Field{Type: "Array of Array of PhotoSize", Optional: true}).goType(); converted != "[][]PhotoSize" {
+		t.Errorf("unexpected type: %s", converted)
+	}
+}
+
+// differences should be reported, and only missing code should be generated.
+func TestGenerate(t *testing.T) {
+	slog.Info("testing generating code...")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(testHandWritten), 0o644); err != nil {
+		t.Fatalf("failed to write hand-written code: %s", err)
+	}
+	src, err := parseSource(dir, "generated.go")
+	if err != nil {
+		t.Fatalf("failed to parse hand-written code: %s", err)
+	}
+
+	p := compare(testSpec, src)
+	if expected := []string{
+		"method deleteStory: not implemented (generated)",
+		"method editMessageCaption: not implemented (generated)",
+		"method getMe: not implemented (generated)",
+		"method sendChatAction: missing param `action` (ChatAction)",
+		"method sendChatAction: missing setter OptionsSendChatAction.SetBusinessConnectionID (generated)",
+		"method sendChatAction: setter OptionsSendChatAction.SetMessageThreadID takes int, but int64 in spec",
+		"method setMyName: no options type OptionsSetMyName for optional params (generated)",
+		"type Story: not implemented (generated)",
+		"type User: field `stale` is not in spec",
+		"type User: missing field `last_name` (*string)",
+	}; !slices.Equal(p.reports, expected) {
+		t.Errorf("unexpected reports:\n%s", strings.Join(p.reports, "\n"))
+	}
+
+	generated, err := render("telegrambot", p)
+	if err != nil {
+		t.Fatalf("failed to render code: %s", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "generated.go", generated, 0); err != nil {
+		t.Errorf("failed to parse generated code: %s", err)
+	}
+	for _, expected := range []string{
+		generatedHeader,
+		"type Story struct {\n\tChat Chat  `json:\"chat\"`\n\tID   int64 `json:\"id\"`\n}",
+		"// https://core.telegram.org/bots/api#deletestory",
+		`return requestGeneric[User](ctx, b, "getMe", map[string]any{}) // no params`,
+		`return b.requestMessageOrBool(ctx, "editMessageCaption", options)`,
+		"// options include: `chat_id`, and `caption`.",
+		"func (o OptionsSendChatAction) SetBusinessConnectionID(businessConnectionID string) OptionsSendChatAction {",
+		"type OptionsSetMyName MethodOptions",
+		"func (o OptionsSetMyName) SetLanguageCode(languageCode string) OptionsSetMyName {",
+	} {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("generated code does not contain %q:\n%s", expected, generated)
+		}
+	}
+}
+
+// the checked-in spec should not differ from the hand-written code.
+func TestCheckedInSpec(t *testing.T) {
+	slog.Info("testing the checked-in spec...")
+
+	spec, err := readSpec("botapi.json")
+	if err != nil {
+		t.Fatalf("failed to read spec: %s", err)
+	}
+	src, err := parseSource(filepath.Join("..", ".."), "generated.go")
+	if err != nil {
+		t.Fatalf("failed to parse hand-written code: %s", err)
+	}
+	if p := compare(spec, src); len(p.reports) > 0 {
+		t.Errorf("hand-written code differs from the spec:\n%s", strings.Join(p.reports, "\n"))
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+// Parsing of hand-written code, and its differences from the spec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// source is a set of types and methods declared in hand-written code.
+type source struct {
+	structs map[string]map[string]string        // struct fields' Go types by json names, by struct names
+	types   map[string]bool                     // names of all declared types
+	methods map[string]map[string]*ast.FuncDecl // methods by names, by receiver type names
+}
+
+// parse hand-written go files in given directory (except tests and the generated file)
+func parseSource(dir, generated string) (src source, err error) {
+	src = source{
+		structs: map[string]map[string]string{},
+		types:   map[string]bool{},
+		methods: map[string]map[string]*ast.FuncDecl{},
+	}
+
+	var paths []string
+	if paths, err = filepath.Glob(filepath.Join(dir, "*.go")); err != nil {
+		return src, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || (generated != "" && filepath.Base(path) == filepath.Base(generated)) {
+			continue
+		}
+
+		var file *ast.File
+		if file, err = parser.ParseFile(fset, path, nil, parser.SkipObjectResolution); err != nil {
+			return src, err
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						src.types[spec.Name.Name] = true
+						if st, ok := spec.Type.(*ast.StructType); ok {
+							src.structs[spec.Name.Name] = jsonFieldsOf(st)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) == 0 {
+					continue
+				}
+				recv := strings.TrimPrefix(types.ExprString(decl.Recv.List[0].Type), "*")
+				if src.methods[recv] == nil {
+					src.methods[recv] = map[string]*ast.FuncDecl{}
+				}
+				src.methods[recv][decl.Name.Name] = decl
+			}
+		}
+	}
+	return src, nil
+}
+
+// Go types of struct fields by their json names
+func jsonFieldsOf(st *ast.StructType) map[string]string {
+	fields := map[string]string{}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = types.ExprString(field.Type)
+	}
+	return fields
+}
+
+// params' Go types by their names (except `ctx` and `options`)
+func paramsOf(decl *ast.FuncDecl) (params map[string]string) {
+	params = map[string]string{}
+	for _, field := range decl.Type.Params.List {
+		for _, name := range field.Names {
+			if name.Name != "ctx" && name.Name != "options" {
+				params[name.Name] = types.ExprString(field.Type)
+			}
+		}
+	}
+	return params
+}
+
+// result type of a method
+func resultOf(decl *ast.FuncDecl) string {
+	if decl.Type.Results == nil || len(decl.Type.Results.List) == 0 {
+		return ""
+	}
+	return types.ExprString(decl.Type.Results.List[0].Type)
+}
+
+// plan is what should be generated for the spec, with differences from hand-written code.
+type plan struct {
+	types   []Type             // types which are not declared
+	methods []Method           // methods which are not declared
+	options []Method           // declared methods whose options types are not declared
+	setters map[string][]Field // missing setters by names of options types
+	reports []string           // differences from the spec
+}
+
+// compare hand-written code with the spec
+func compare(spec Spec, src source) (p plan) {
+	p.setters = map[string][]Field{}
+	report := func(format string, args ...any) {
+		p.reports = append(p.reports, fmt.Sprintf(format, args...))
+	}
+
+	for _, typ := range spec.Types {
+		fields, exists := src.structs[typ.Name]
+		if !exists {
+			if src.types[typ.Name] {
+				report("type %s: not a struct", typ.Name)
+			} else {
+				report("type %s: not implemented (generated)", typ.Name)
+				p.types = append(p.types, typ)
+			}
+			continue
+		}
+
+		inSpec := map[string]bool{}
+		for _, field := range typ.Fields {
+			inSpec[field.Name] = true
+			if goType, exists := fields[field.Name]; !exists {
+				report("type %s: missing field `%s` (%s)", typ.Name, field.Name, field.goType())
+			} else if goType != field.goType() {
+				report("type %s: field `%s` is %s, but %s in spec", typ.Name, field.Name, goType, field.goType())
+			}
+		}
+		for name := range fields {
+			if !inSpec[name] {
+				report("type %s: field `%s` is not in spec", typ.Name, name)
+			}
+		}
+	}
+
+	for _, method := range spec.Methods {
+		decl, exists := src.methods["Bot"][method.goName()]
+		if !exists {
+			report("method %s: not implemented (generated)", method.Name)
+			p.methods = append(p.methods, method)
+			continue
+		}
+
+		if result := resultOf(decl); result != method.goResultType() {
+			report("method %s: returns %s, but %s in spec", method.Name, result, method.goResultType())
+		}
+		required, optional := method.splitParams()
+		params := paramsOf(decl)
+		for _, param := range required {
+			if goType, exists := params[goParamName(param.Name)]; !exists {
+				report("method %s: missing param `%s` (%s)", method.Name, param.Name, param.goParamType())
+			} else if goType != param.goParamType() {
+				report("method %s: param `%s` is %s, but %s in spec", method.Name, param.Name, goType, param.goParamType())
+			}
+		}
+		if len(optional) == 0 {
+			continue
+		}
+		setters, exists := src.methods[method.optionsName()]
+		if !src.types[method.optionsName()] {
+			report("method %s: no options type %s for optional params (generated)", method.Name, method.optionsName())
+			p.options = append(p.options, method)
+			continue
+		}
+		for _, param := range optional {
+			name := "Set" + goFieldName(param.Name)
+			setter, found := setters[name]
+			if !exists || !found {
+				report("method %s: missing setter %s.%s (generated)", method.Name, method.optionsName(), name)
+				p.setters[method.optionsName()] = append(p.setters[method.optionsName()], param)
+				continue
+			}
+			for _, goType := range paramsOf(setter) {
+				if goType != param.goParamType() {
+					report("method %s: setter %s.%s takes %s, but %s in spec", method.Name, method.optionsName(), name, goType, param.goParamType())
+				}
+			}
+		}
+	}
+
+	slices.Sort(p.reports)
+	return p
+}
+
+// whether anything should be generated
+func (p plan) isEmpty() bool {
+	return len(p.types) == 0 && len(p.methods) == 0 && len(p.options) == 0 && len(p.setters) == 0
+}
@@ -0,0 +1,76 @@
+// apigen generates types and methods from a machine-readable Bot API spec,
+// and reports differences between the spec and hand-written code.
+//
+// Hand-written code is the source of truth: only types, methods, and options' setters
+// which are not declared yet are generated into the output file, and others are just compared.
+//
+// NOTE: it is scaffolding for now: the checked-in spec (botapi.json) is written by hand,
+// and describes only a few types and methods, so drifts of the others from the Bot API are not caught.
+// A full spec should be produced from https://core.telegram.org/bots/api and checked in.
+//
+// Usage (from the root of the repository, or with `go generate`):
+//
+//	go run ./internal/apigen -spec internal/apigen/botapi.json -out generated.go [-check]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "internal/apigen/botapi.json", "path of the Bot API spec")
+	srcDir := flag.String("src", ".", "directory of the hand-written code")
+	out := flag.String("out", "generated.go", "name of the generated file in the source directory")
+	pkg := flag.String("pkg", "telegrambot", "name of the package")
+	check := flag.Bool("check", false, "exit with an error if there are any differences from the spec")
+	flag.Parse()
+
+	if err := run(*specPath, *srcDir, *out, *pkg, *check); err != nil {
+		fmt.Fprintf(os.Stderr, "apigen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate code and report differences
+func run(specPath, srcDir, out, pkg string, check bool) error {
+	spec, err := readSpec(specPath)
+	if err != nil {
+		return err
+	}
+	src, err := parseSource(srcDir, out)
+	if err != nil {
+		return err
+	}
+
+	p := compare(spec, src)
+	for _, report := range p.reports {
+		fmt.Println(report)
+	}
+	fmt.Printf("apigen: %d difference(s) in %d type(s) and %d method(s) of the spec\n", len(p.reports), len(spec.Types), len(spec.Methods))
+
+	path := filepath.Join(srcDir, out)
+	if p.isEmpty() {
+		// (nothing is missing, so the generated file is not needed anymore)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		generated, err := render(pkg, p)
+		if err != nil {
+			return fmt.Errorf("failed to render code: %w", err)
+		}
+		if err := os.WriteFile(path, generated, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("apigen: generated %s\n", path)
+	}
+
+	if check && len(p.reports) > 0 {
+		return fmt.Errorf("hand-written code differs from the spec")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+// Rendering of Go code for types and methods of the spec
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// header of generated files
+const generatedHeader = "// Code generated by apigen from the Bot API spec; DO NOT EDIT."
+
+// render Go code of the plan
+func render(pkg string, p plan) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%s\n\npackage %s\n\n", generatedHeader, pkg)
+	if len(p.methods) > 0 {
+		fmt.Fprint(&buf, "import \"context\"\n\n")
+	}
+
+	for _, typ := range p.types {
+		renderType(&buf, typ)
+	}
+	for _, method := range p.methods {
+		renderMethod(&buf, method)
+		if _, optional := method.splitParams(); len(optional) > 0 {
+			renderOptions(&buf, method, optional)
+			renderSetters(&buf, method.optionsName(), optional)
+		}
+	}
+	for _, method := range p.options {
+		_, optional := method.splitParams()
+		renderOptions(&buf, method, optional)
+		renderSetters(&buf, method.optionsName(), optional)
+	}
+	for _, options := range slices.Sorted(maps.Keys(p.setters)) {
+		renderSetters(&buf, options, p.setters[options])
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// render a struct of the type
+func renderType(buf *bytes.Buffer, typ Type) {
+	fmt.Fprintf(buf, "// %s %s\n//\n// %s\n", typ.Name, typ.Description, docLink(typ.Name))
+	fmt.Fprintf(buf, "type %s struct {\n", typ.Name)
+	for _, field := range typ.Fields {
+		tag := field.Name
+		if field.Optional {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`\n", goFieldName(field.Name), field.goType(), tag)
+	}
+	fmt.Fprint(buf, "}\n\n")
+}
+
+// render a method of Bot
+func renderMethod(buf *bytes.Buffer, method Method) {
+	required, optional := method.splitParams()
+
+	fmt.Fprintf(buf, "// %s %s\n//\n// %s\n", method.goName(), method.Description, docLink(method.Name))
+	fmt.Fprintf(buf, "func (b *Bot) %s(\n\tctx context.Context,\n", method.goName())
+	for _, param := range required {
+		fmt.Fprintf(buf, "\t%s %s,\n", goParamName(param.Name), param.goParamType())
+	}
+	if len(optional) > 0 {
+		fmt.Fprintf(buf, "\toptions %s,\n", method.optionsName())
+	}
+	fmt.Fprintf(buf, ") (result %s, err error) {\n", method.goResultType())
+
+	switch {
+	case len(required) == 0 && len(optional) == 0:
+		fmt.Fprintf(buf, "\treturn %s // no params\n", requestCall(method, "map[string]any{}"))
+	case len(optional) == 0:
+		fmt.Fprint(buf, "\toptions := map[string]any{\n")
+		for _, param := range required {
+			fmt.Fprintf(buf, "\t\t%q: %s,\n", param.Name, goParamName(param.Name))
+		}
+		fmt.Fprintf(buf, "\t}\n\n\treturn %s\n", requestCall(method, "options"))
+	case len(required) == 0:
+		fmt.Fprintf(buf, "\treturn %s\n", requestCall(method, "options"))
+	default:
+		fmt.Fprint(buf, "\tif options == nil {\n\t\toptions = map[string]any{}\n\t}\n\n\t// essential params\n")
+		for _, param := range required {
+			fmt.Fprintf(buf, "\toptions[%q] = %s\n", param.Name, goParamName(param.Name))
+		}
+		fmt.Fprintf(buf, "\n\treturn %s\n", requestCall(method, "options"))
+	}
+	fmt.Fprint(buf, "}\n\n")
+}
+
+// expression of the request for the method
+func requestCall(method Method, params string) string {
+	if method.returnsMessageOrBool() {
+		return fmt.Sprintf("b.requestMessageOrBool(ctx, %q, %s)", method.Name, params)
+	}
+	result := strings.TrimSuffix(strings.TrimPrefix(method.goResultType(), "APIResponse["), "]")
+	return fmt.Sprintf("requestGeneric[%s](ctx, b, %q, %s)", result, method.Name, params)
+}
+
+// render an options type of the method
+func renderOptions(buf *bytes.Buffer, method Method, optional []Field) {
+	names := make([]string, 0, len(optional))
+	for _, param := range optional {
+		names = append(names, "`"+param.Name+"`")
+	}
+	include := strings.Join(names, ", ")
+	if len(names) > 1 {
+		include = strings.Join(names[:len(names)-1], ", ") + ", and " + names[len(names)-1]
+	}
+
+	fmt.Fprintf(buf, "// %s struct for %s().\n//\n// options include: %s.\n//\n// %s\n", method.optionsName(), method.goName(), include, docLink(method.Name))
+	fmt.Fprintf(buf, "type %s MethodOptions\n\n", method.optionsName())
+}
+
+// render setters of an options type
+func renderSetters(buf *bytes.Buffer, options string, params []Field) {
+	for _, param := range params {
+		name := goParamName(param.Name)
+		fmt.Fprintf(buf, "// Set%s sets the `%s` value of %s.\n", goFieldName(param.Name), param.Name, options)
+		fmt.Fprintf(buf, "func (o %s) Set%s(%s %s) %s {\n", options, goFieldName(param.Name), name, param.goParamType(), options)
+		fmt.Fprintf(buf, "\to[%q] = %s\n\treturn o\n}\n\n", param.Name, name)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+// Bot API spec and mapping of its types to Go
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Spec is a machine-readable description of the Bot API.
+type Spec struct {
+	Source  string   `json:"source"`
+	Note    string   `json:"note,omitempty"`
+	Types   []Type   `json:"types"`
+	Methods []Method `json:"methods"`
+}
+
+// Type is an object of the Bot API.
+type Type struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"` // predicate of the doc comment (eg. "represents a Telegram user or bot.")
+	Fields      []Field `json:"fields"`
+}
+
+// Field is a field of an object, or a parameter of a method.
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // type in the Bot API documentation (eg. "Integer", "Array of String")
+	Optional bool   `json:"optional,omitempty"`
+	GoType   string `json:"go_type,omitempty"` // overrides the Go type mapped from `type`
+}
+
+// Method is a method of the Bot API.
+type Method struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"` // predicate of the doc comment (eg. "sends a chat action.")
+	Returns     string  `json:"returns"`     // type in the Bot API documentation (eg. "True", "Message or True")
+	Params      []Field `json:"params,omitempty"`
+}
+
+// read a spec from given file
+func readSpec(path string) (spec Spec, err error) {
+	var bytes []byte
+	if bytes, err = os.ReadFile(path); err != nil {
+		return spec, err
+	}
+	if err = json.Unmarshal(bytes, &spec); err != nil {
+		return spec, fmt.Errorf("failed to parse spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// required and optional params of the method
+func (m Method) splitParams() (required, optional []Field) {
+	for _, param := range m.Params {
+		if param.Optional {
+			optional = append(optional, param)
+		} else {
+			required = append(required, param)
+		}
+	}
+	return required, optional
+}
+
+// name of the method in Go (eg. "SendMessage")
+func (m Method) goName() string {
+	return strings.ToUpper(m.Name[:1]) + m.Name[1:]
+}
+
+// name of the options type of the method in Go (eg. "OptionsSendMessage")
+func (m Method) optionsName() string {
+	return "Options" + m.goName()
+}
+
+// whether the method returns a Message or True
+func (m Method) returnsMessageOrBool() bool {
+	return m.Returns == "Message or True"
+}
+
+// result type of the method in Go
+func (m Method) goResultType() string {
+	if m.returnsMessageOrBool() {
+		return "APIResponseMessageOrBool"
+	}
+	return "APIResponse[" + goTypeOf(m.Returns, "") + "]"
+}
+
+// link to the documentation of given type or method
+func docLink(name string) string {
+	return "https://core.telegram.org/bots/api#" + strings.ToLower(name)
+}
+
+// Go type of the field (pointers for optional ones)
+func (f Field) goType() string {
+	typ := f.GoType
+	if typ == "" {
+		typ = goTypeOf(f.Type, f.Name)
+	}
+	if f.Optional && !strings.HasPrefix(typ, "[]") && typ != "any" && !strings.HasPrefix(typ, "map[") {
+		return "*" + typ
+	}
+	return typ
+}
+
+// Go type of the param (no pointers, as optional params are set with setters)
+func (f Field) goParamType() string {
+	if f.GoType != "" {
+		return f.GoType
+	}
+	return goTypeOf(f.Type, f.Name)
+}
+
+// Go type mapped from given type of the Bot API documentation
+func goTypeOf(typ, name string) string {
+	if elem, found := strings.CutPrefix(typ, "Array of "); found {
+		return "[]" + goTypeOf(elem, name)
+	}
+
+	switch typ {
+	case "Integer":
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			return "int64"
+		}
+		return "int"
+	case "Float":
+		return "float64"
+	case "String":
+		return "string"
+	case "Boolean", "True":
+		return "bool"
+	case "Integer or String":
+		return "ChatID"
+	case "InputFile or String":
+		return "InputFile"
+	}
+	if strings.Contains(typ, " or ") {
+		return "any"
+	}
+	return typ
+}
+
+// initialisms which are written in upper cases in Go names
+var initialisms = []string{"api", "html", "http", "id", "ip", "json", "url"}
+
+// name of a field in Go (eg. "message_thread_id" => "MessageThreadID")
+func goFieldName(name string) string {
+	var builder strings.Builder
+	for word := range strings.SplitSeq(name, "_") {
+		if slices.Contains(initialisms, word) {
+			builder.WriteString(strings.ToUpper(word))
+		} else if word != "" {
+			builder.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return builder.String()
+}
+
+// name of a param in Go (eg. "message_thread_id" => "messageThreadID")
+func goParamName(name string) string {
+	first, rest, _ := strings.Cut(name, "_")
+	if rest == "" {
+		return first
+	}
+	return first + goFieldName(rest)
+}
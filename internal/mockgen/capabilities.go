@@ -0,0 +1,55 @@
+package main
+
+// Capabilities of the Bot API, and grouping of methods into them
+
+import (
+	"regexp"
+)
+
+// capability is a group of methods, generated as an interface.
+type capability struct {
+	Name        string
+	Description string         // predicate of the doc comment
+	Pattern     *regexp.Regexp // pattern of method names (nil for all methods which are not matched by others)
+}
+
+// capabilities in the order of matching (the first matching one takes the method)
+var capabilities = []capability{
+	{"UpdatesAPI", "is a set of methods for fetching updates and managing webhooks.", regexp.MustCompile(`^(GetUpdates|SetWebhook|DeleteWebhook|GetWebhookInfo)$`)},
+	{"BusinessAPI", "is a set of methods for managing business accounts and their messages.", regexp.MustCompile(`Business`)},
+	{"ForumTopicsAPI", "is a set of methods for managing forum topics.", regexp.MustCompile(`ForumTopic`)},
+	{"StoriesAPI", "is a set of methods for posting and managing stories.", regexp.MustCompile(`Story`)},
+	{"GiftsAPI", "is a set of methods for sending and managing gifts.", regexp.MustCompile(`Gift`)},
+	{"PaymentsAPI", "is a set of methods for payments, Telegram Stars, and paid media.", regexp.MustCompile(`Invoice|ShippingQuery|PreCheckoutQuery|Star|PaidMedia`)},
+	{"GamesAPI", "is a set of methods for games.", regexp.MustCompile(`Game`)},
+	{"BotSettingsAPI", "is a set of methods for the bot itself and its settings.", regexp.MustCompile(`^(GetMe|LogOut|Close)$|My|ChatMenuButton|ManagedBot`)},
+	{"ChatAdminAPI", "is a set of methods for administrating chats and their members.", regexp.MustCompile(`^(Ban|Unban|Restrict|Promote|Leave|Export|Create|Edit|Revoke|Approve|Decline|Answer|Set|Delete|Get)Chat|^SendChatJoinRequest|^(Pin|Unpin|UnpinAll)ChatMessage`)},
+	{"StickersAPI", "is a set of methods for sending and managing stickers.", regexp.MustCompile(`Sticker`)},
+	{"UsersAPI", "is a set of methods for users and verifications.", regexp.MustCompile(`^GetUser|^SetUserEmojiStatus$|Verif`)},
+	{"InlineAPI", "is a set of methods for inline queries, web apps, and guest queries.", regexp.MustCompile(`Inline|WebAppQuery|GuestQuery|PreparedKeyboardButton`)},
+	{"MessagingAPI", "is a set of methods for sending, editing, and deleting messages.", nil},
+}
+
+// group is a capability with its methods.
+type group struct {
+	capability
+	Methods []method
+}
+
+// group methods into capabilities
+func groupMethods(methods []method) (groups []group) {
+	groups = make([]group, len(capabilities))
+	for i, c := range capabilities {
+		groups[i].capability = c
+	}
+
+	for _, m := range methods {
+		for i, c := range capabilities {
+			if c.Pattern == nil || c.Pattern.MatchString(m.Name) {
+				groups[i].Methods = append(groups[i].Methods, m)
+				break
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,54 @@
+// mockgen generates capability interfaces of the Bot API methods (which *Bot satisfies),
+// and their mock implementations which record calls and return programmable results.
+//
+// Methods of *Bot in methods.go are grouped into capabilities by the rules in capabilities.go of this tool.
+//
+// Usage (from the root of the repository, or with `go generate`):
+//
+//	go run ./internal/mockgen -src methods.go -interfaces capabilities.go -mock telegrambotmock/mock_generated.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	src := flag.String("src", "methods.go", "path of the hand-written methods")
+	interfaces := flag.String("interfaces", "capabilities.go", "path of the generated interfaces")
+	mock := flag.String("mock", "telegrambotmock/mock_generated.go", "path of the generated mock")
+	flag.Parse()
+
+	if err := run(*src, *interfaces, *mock); err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate interfaces and mock
+func run(src, interfaces, mock string) error {
+	methods, err := parseMethods(src)
+	if err != nil {
+		return err
+	}
+	groups := groupMethods(methods)
+
+	generated, err := renderInterfaces(groups)
+	if err != nil {
+		return fmt.Errorf("failed to render interfaces: %w", err)
+	}
+	if err := os.WriteFile(interfaces, generated, 0o644); err != nil {
+		return err
+	}
+
+	if generated, err = renderMock(groups); err != nil {
+		return fmt.Errorf("failed to render mock: %w", err)
+	}
+	if err := os.WriteFile(mock, generated, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("mockgen: generated %d method(s) in %d capabilities\n", len(methods), len(groups))
+	return nil
+}
@@ -0,0 +1,47 @@
+// mockgen_test.go
+//
+// offline tests of generating interfaces and mock
+
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// every capability should have methods, and generated files should be in sync with methods.go.
+func TestGeneratedFiles(t *testing.T) {
+	slog.Info("testing generated interfaces and mock...")
+
+	root := filepath.Join("..", "..")
+	methods, err := parseMethods(filepath.Join(root, "methods.go"))
+	if err != nil {
+		t.Fatalf("failed to parse methods: %s", err)
+	}
+	groups := groupMethods(methods)
+	for _, g := range groups {
+		if len(g.Methods) == 0 {
+			t.Errorf("no methods in capability %s", g.Name)
+		}
+	}
+
+	for path, render := range map[string]func([]group) ([]byte, error){
+		filepath.Join(root, "capabilities.go"):                      renderInterfaces,
+		filepath.Join(root, "telegrambotmock", "mock_generated.go"): renderMock,
+	} {
+		generated, err := render(groups)
+		if err != nil {
+			t.Errorf("failed to render %s: %s", path, err)
+			continue
+		}
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("failed to read %s: %s", path, err)
+		} else if !bytes.Equal(generated, existing) {
+			t.Errorf("%s is out of sync with methods.go, run `go generate`", path)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+// Parsing of methods of *Bot
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// method is an exported method of *Bot.
+type method struct {
+	Name    string
+	Doc     string // first line of the doc comment
+	Params  []param
+	Results []param
+}
+
+// param is a param or a result of a method.
+type param struct {
+	Name string // (empty for unnamed results)
+	Type ast.Expr
+}
+
+// parse exported methods of *Bot in given file
+func parseMethods(path string) (methods []method, err error) {
+	var file *ast.File
+	if file, err = parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments|parser.SkipObjectResolution); err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || !fn.Name.IsExported() {
+			continue
+		}
+		if types.ExprString(fn.Recv.List[0].Type) != "*Bot" {
+			continue
+		}
+
+		m := method{
+			Name:    fn.Name.Name,
+			Params:  paramsOf(fn.Type.Params),
+			Results: paramsOf(fn.Type.Results),
+		}
+		if fn.Doc != nil {
+			m.Doc, _, _ = strings.Cut(fn.Doc.Text(), "\n")
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// params of given field list
+func paramsOf(fields *ast.FieldList) (params []param) {
+	if fields == nil {
+		return nil
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			params = append(params, param{Type: field.Type})
+			continue
+		}
+		for _, name := range field.Names {
+			params = append(params, param{Name: name.Name, Type: field.Type})
+		}
+	}
+	return params
+}
+
+// qualify exported identifiers of types in given expression with the package name
+func qualify(expr ast.Expr, pkg string) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if pkg != "" && expr.IsExported() && types.Universe.Lookup(expr.Name) == nil {
+			return pkg + "." + expr.Name
+		}
+		return expr.Name
+	case *ast.StarExpr:
+		return "*" + qualify(expr.X, pkg)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			return "[]" + qualify(expr.Elt, pkg)
+		}
+		return "[" + types.ExprString(expr.Len) + "]" + qualify(expr.Elt, pkg)
+	case *ast.Ellipsis:
+		return "..." + qualify(expr.Elt, pkg)
+	case *ast.MapType:
+		return "map[" + qualify(expr.Key, pkg) + "]" + qualify(expr.Value, pkg)
+	case *ast.IndexExpr:
+		return qualify(expr.X, pkg) + "[" + qualify(expr.Index, pkg) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, 0, len(expr.Indices))
+		for _, index := range expr.Indices {
+			indices = append(indices, qualify(index, pkg))
+		}
+		return qualify(expr.X, pkg) + "[" + strings.Join(indices, ", ") + "]"
+	}
+	return types.ExprString(expr) // (eg. `context.Context`)
+}
+
+// signature of the method, with types qualified with given package name
+func (m method) signature(pkg string) string {
+	params := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		params = append(params, strings.TrimSpace(p.Name+" "+qualify(p.Type, pkg)))
+	}
+	results := make([]string, 0, len(m.Results))
+	for _, r := range m.Results {
+		results = append(results, strings.TrimSpace(r.Name+" "+qualify(r.Type, pkg)))
+	}
+
+	signature := m.Name + "(" + strings.Join(params, ", ") + ")"
+	switch {
+	case len(results) == 1 && m.Results[0].Name == "":
+		signature += " " + results[0]
+	case len(results) > 0:
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	return signature
+}
+
+// arguments for calling the method with its params
+func (m method) arguments() string {
+	args := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+			args = append(args, p.Name+"...")
+		} else {
+			args = append(args, p.Name)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// arguments for recording a call (without `ctx`)
+func (m method) recordedArguments() string {
+	args := []string{}
+	for _, p := range m.Params {
+		if types.ExprString(p.Type) != "context.Context" {
+			args = append(args, p.Name)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// type of the method as a function, with types qualified with given package name
+func (m method) funcType(pkg string) string {
+	signature := m.signature(pkg)
+	return "func" + strings.TrimPrefix(signature, m.Name)
+}
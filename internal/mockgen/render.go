@@ -0,0 +1,104 @@
+package main
+
+// Rendering of interfaces and mock
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const (
+	// header of generated files
+	generatedHeader = "// Code generated by mockgen from methods.go; DO NOT EDIT."
+
+	// import path and name of the package of *Bot
+	botPackagePath = "github.com/meinside/telegram-bot-go"
+	botPackageName = "telegrambot"
+)
+
+// render capability interfaces in the package of *Bot
+func renderInterfaces(groups []group) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%s\n\npackage %s\n\nimport \"context\"\n\n", generatedHeader, botPackageName)
+	for _, g := range groups {
+		fmt.Fprintf(&buf, "// %s %s\ntype %s interface {\n", g.Name, g.Description, g.Name)
+		for i, m := range g.Methods {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if m.Doc != "" {
+				fmt.Fprintf(&buf, "\t// %s\n", m.Doc)
+			}
+			fmt.Fprintf(&buf, "\t%s\n", m.signature(""))
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	buf.WriteString("// API is a set of all methods of the Bot API, which is satisfied by *Bot.\n//\n// Depend on smaller capabilities (eg. MessagingAPI) where possible, so that they can be easily mocked.\ntype API interface {\n")
+	for _, g := range groups {
+		fmt.Fprintf(&buf, "\t%s\n", g.Name)
+	}
+	buf.WriteString("}\n\nvar _ API = (*Bot)(nil)\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// render the mock of all capabilities
+func renderMock(groups []group) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%s\n\npackage %smock\n\nimport (\n\t\"context\"\n\n\t%s %q\n)\n\n", generatedHeader, botPackageName, botPackageName, botPackagePath)
+
+	buf.WriteString("// Mock is a mock of telegrambot.API which records calls, and returns results of programmable functions.\n//\n// Methods whose functions are not set return zero results (with `OK` set to true for API responses).\ntype Mock struct {\n\trecorder\n")
+	for _, g := range groups {
+		fmt.Fprintf(&buf, "\n\t// %s\n", g.Name)
+		for _, m := range g.Methods {
+			fmt.Fprintf(&buf, "\t%sFunc %s\n", m.Name, m.mockMethod().funcType(botPackageName))
+		}
+	}
+	buf.WriteString("}\n\nvar _ telegrambot.API = (*Mock)(nil)\n\n")
+
+	for _, g := range groups {
+		for _, m := range g.Methods {
+			renderMockMethod(&buf, m.mockMethod())
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// the method with all results named, for naked returns in the mock
+func (m method) mockMethod() method {
+	named := m
+	named.Results = make([]param, len(m.Results))
+	for i, r := range m.Results {
+		named.Results[i] = r
+		if r.Name == "" {
+			named.Results[i].Name = fmt.Sprintf("r%d", i)
+		}
+	}
+	return named
+}
+
+// render a method of the mock
+func renderMockMethod(buf *bytes.Buffer, m method) {
+	fmt.Fprintf(buf, "// %s records the call, and returns the result of %sFunc.\n", m.Name, m.Name)
+	fmt.Fprintf(buf, "func (m *Mock) %s {\n", m.signature(botPackageName))
+	fmt.Fprintf(buf, "\tm.record(%q, %s)\n\n", m.Name, m.recordedArguments())
+
+	if len(m.Results) == 0 {
+		fmt.Fprintf(buf, "\tif m.%sFunc != nil {\n\t\tm.%sFunc(%s)\n\t}\n}\n\n", m.Name, m.Name, m.arguments())
+		return
+	}
+
+	fmt.Fprintf(buf, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", m.Name, m.Name, m.arguments())
+	for _, r := range m.Results {
+		if strings.HasPrefix(qualify(r.Type, botPackageName), botPackageName+".APIResponse") {
+			fmt.Fprintf(buf, "\t%s.OK = true\n", r.Name)
+		}
+	}
+	buf.WriteString("\treturn\n}\n\n")
+}
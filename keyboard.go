@@ -0,0 +1,426 @@
+package telegrambot
+
+// Building of keyboard layouts, and pagination of inline keyboards
+//
+// https://core.telegram.org/bots/features#keyboards
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	maxCallbackDataBytes        = 64  // max length of a callback data in bytes
+	maxInlineButtonsPerRow      = 8   // max number of buttons in a row of an inline keyboard
+	maxInlineButtonsPerKeyboard = 100 // max number of buttons in an inline keyboard
+
+	buttonPaddingWidth = 2 // extra width of a button in auto-sized layouts
+)
+
+var (
+	// ErrCallbackDataTooLong is returned when a callback data is longer than 64 bytes.
+	ErrCallbackDataTooLong = errors.New("callback data is longer than 64 bytes")
+
+	// ErrTooManyButtons is returned when a keyboard has too many buttons.
+	ErrTooManyButtons = errors.New("too many buttons in a keyboard")
+)
+
+////////////////////////////////
+// Layout
+//
+
+// keyboardLayout places buttons into rows
+type keyboardLayout[B any] struct {
+	rows    [][]B
+	current []B
+
+	columns  int // fixed number of columns (0 for no limit)
+	maxWidth int // max width of a row for auto-sized columns (0 for no limit)
+	maxCount int // max number of buttons in a row (0 for no limit)
+
+	text func(B) string
+}
+
+// width of a button in auto-sized layouts
+func (l *keyboardLayout[B]) width(button B) int {
+	return utf8.RuneCountInString(l.text(button)) + buttonPaddingWidth
+}
+
+// add a button, breaking the row if needed
+func (l *keyboardLayout[B]) add(button B) {
+	if len(l.current) > 0 {
+		full := (l.columns > 0 && len(l.current) >= l.columns) ||
+			(l.maxCount > 0 && len(l.current) >= l.maxCount)
+
+		if !full && l.maxWidth > 0 {
+			width := l.width(button)
+			for _, b := range l.current {
+				width += l.width(b)
+			}
+			full = width > l.maxWidth
+		}
+
+		if full {
+			l.breakRow()
+		}
+	}
+
+	l.current = append(l.current, button)
+}
+
+// end the current row
+func (l *keyboardLayout[B]) breakRow() {
+	if len(l.current) > 0 {
+		l.rows = append(l.rows, l.current)
+		l.current = nil
+	}
+}
+
+// all rows including the current one
+func (l *keyboardLayout[B]) build() [][]B {
+	rows := append([][]B{}, l.rows...)
+	if len(l.current) > 0 {
+		rows = append(rows, append([]B{}, l.current...))
+	}
+	return rows
+}
+
+////////////////////////////////
+// InlineKeyboardBuilder
+//
+
+// InlineKeyboardBuilder builds an InlineKeyboardMarkup.
+//
+//	markup, err := NewInlineKeyboardBuilder().
+//		Columns(2).
+//		Callback("Yes", "answer:yes").
+//		Callback("No", "answer:no").
+//		Row().
+//		URL("Help", "https://example.com/help").
+//		Build()
+type InlineKeyboardBuilder struct {
+	layout keyboardLayout[InlineKeyboardButton]
+	style  *KeyboardStyle
+}
+
+// NewInlineKeyboardBuilder returns a new InlineKeyboardBuilder.
+func NewInlineKeyboardBuilder() *InlineKeyboardBuilder {
+	return &InlineKeyboardBuilder{
+		layout: keyboardLayout[InlineKeyboardButton]{
+			maxCount: maxInlineButtonsPerRow,
+			text:     func(b InlineKeyboardButton) string { return b.Text },
+		},
+	}
+}
+
+// Columns places following buttons in rows of given number of columns (0 for no limit).
+func (k *InlineKeyboardBuilder) Columns(columns int) *InlineKeyboardBuilder {
+	k.layout.columns = columns
+	return k
+}
+
+// AutoColumns places following buttons in rows which are not wider than given number of characters (0 for no limit).
+func (k *InlineKeyboardBuilder) AutoColumns(maxWidth int) *InlineKeyboardBuilder {
+	k.layout.maxWidth = maxWidth
+	return k
+}
+
+// Style sets the style of following buttons which have no style of their own ("" for none).
+func (k *InlineKeyboardBuilder) Style(style KeyboardStyle) *InlineKeyboardBuilder {
+	k.style = nil
+	if style != "" {
+		k.style = &style
+	}
+	return k
+}
+
+// Button adds given buttons.
+func (k *InlineKeyboardBuilder) Button(buttons ...InlineKeyboardButton) *InlineKeyboardBuilder {
+	for _, button := range buttons {
+		if button.Style == nil && k.style != nil {
+			button = button.SetStyle(*k.style)
+		}
+		k.layout.add(button)
+	}
+	return k
+}
+
+// ButtonIf adds given buttons only when `condition` is true.
+func (k *InlineKeyboardBuilder) ButtonIf(condition bool, buttons ...InlineKeyboardButton) *InlineKeyboardBuilder {
+	if condition {
+		k.Button(buttons...)
+	}
+	return k
+}
+
+// Callback adds a button with callback data.
+func (k *InlineKeyboardBuilder) Callback(text, data string) *InlineKeyboardBuilder {
+	return k.Button(NewInlineKeyboardButton(text).SetCallbackData(data))
+}
+
+// URL adds a button with an url.
+func (k *InlineKeyboardBuilder) URL(text, url string) *InlineKeyboardBuilder {
+	return k.Button(NewInlineKeyboardButton(text).SetURL(url))
+}
+
+// WebApp adds a button which launches a web app.
+func (k *InlineKeyboardBuilder) WebApp(text, url string) *InlineKeyboardBuilder {
+	return k.Button(NewInlineKeyboardButton(text).SetWebApp(WebAppInfo{URL: url}))
+}
+
+// CopyText adds a button which copies given text to the clipboard.
+func (k *InlineKeyboardBuilder) CopyText(text, copied string) *InlineKeyboardBuilder {
+	button := NewInlineKeyboardButton(text)
+	button.CopyText = &CopyTextButton{Text: copied}
+	return k.Button(button)
+}
+
+// Row ends the current row, so that following buttons are placed in a new row.
+func (k *InlineKeyboardBuilder) Row() *InlineKeyboardBuilder {
+	k.layout.breakRow()
+	return k
+}
+
+// Rows adds given rows of buttons as they are.
+func (k *InlineKeyboardBuilder) Rows(rows ...[]InlineKeyboardButton) *InlineKeyboardBuilder {
+	k.layout.breakRow()
+	for _, row := range rows {
+		k.layout.rows = append(k.layout.rows, row)
+	}
+	return k
+}
+
+// Build validates and returns the built InlineKeyboardMarkup.
+func (k *InlineKeyboardBuilder) Build() (InlineKeyboardMarkup, error) {
+	markup := NewInlineKeyboardMarkup(k.layout.build())
+	return markup, validateInlineKeyboard(markup.InlineKeyboard)
+}
+
+// validate buttons of an inline keyboard
+func validateInlineKeyboard(rows [][]InlineKeyboardButton) error {
+	count := 0
+	for _, row := range rows {
+		for _, button := range row {
+			if button.CallbackData != nil && len(*button.CallbackData) > maxCallbackDataBytes {
+				return fmt.Errorf("%w: %q (%d bytes)", ErrCallbackDataTooLong, *button.CallbackData, len(*button.CallbackData))
+			}
+			count++
+		}
+		if len(row) > maxInlineButtonsPerRow {
+			return fmt.Errorf("%w: %d buttons in a row (max %d)", ErrTooManyButtons, len(row), maxInlineButtonsPerRow)
+		}
+	}
+	if count > maxInlineButtonsPerKeyboard {
+		return fmt.Errorf("%w: %d buttons (max %d)", ErrTooManyButtons, count, maxInlineButtonsPerKeyboard)
+	}
+	return nil
+}
+
+////////////////////////////////
+// ReplyKeyboardBuilder
+//
+
+// ReplyKeyboardBuilder builds a ReplyKeyboardMarkup.
+//
+//	markup := NewReplyKeyboardBuilder().
+//		AutoColumns(30).
+//		Text("Menu", "Settings", "Help").
+//		Row().
+//		RequestContact("Share my phone number").
+//		Build().
+//		SetResizeKeyboard(true)
+type ReplyKeyboardBuilder struct {
+	layout keyboardLayout[KeyboardButton]
+	style  *KeyboardStyle
+}
+
+// NewReplyKeyboardBuilder returns a new ReplyKeyboardBuilder.
+func NewReplyKeyboardBuilder() *ReplyKeyboardBuilder {
+	return &ReplyKeyboardBuilder{
+		layout: keyboardLayout[KeyboardButton]{
+			text: func(b KeyboardButton) string { return b.Text },
+		},
+	}
+}
+
+// Columns places following buttons in rows of given number of columns (0 for no limit).
+func (k *ReplyKeyboardBuilder) Columns(columns int) *ReplyKeyboardBuilder {
+	k.layout.columns = columns
+	return k
+}
+
+// AutoColumns places following buttons in rows which are not wider than given number of characters (0 for no limit).
+func (k *ReplyKeyboardBuilder) AutoColumns(maxWidth int) *ReplyKeyboardBuilder {
+	k.layout.maxWidth = maxWidth
+	return k
+}
+
+// Style sets the style of following buttons which have no style of their own ("" for none).
+func (k *ReplyKeyboardBuilder) Style(style KeyboardStyle) *ReplyKeyboardBuilder {
+	k.style = nil
+	if style != "" {
+		k.style = &style
+	}
+	return k
+}
+
+// Button adds given buttons.
+func (k *ReplyKeyboardBuilder) Button(buttons ...KeyboardButton) *ReplyKeyboardBuilder {
+	for _, button := range buttons {
+		if button.Style == nil && k.style != nil {
+			button = button.SetStyle(*k.style)
+		}
+		k.layout.add(button)
+	}
+	return k
+}
+
+// ButtonIf adds given buttons only when `condition` is true.
+func (k *ReplyKeyboardBuilder) ButtonIf(condition bool, buttons ...KeyboardButton) *ReplyKeyboardBuilder {
+	if condition {
+		k.Button(buttons...)
+	}
+	return k
+}
+
+// Text adds buttons with given texts.
+func (k *ReplyKeyboardBuilder) Text(texts ...string) *ReplyKeyboardBuilder {
+	return k.Button(NewKeyboardButtons(texts...)...)
+}
+
+// RequestContact adds a button which sends the user's phone number.
+func (k *ReplyKeyboardBuilder) RequestContact(text string) *ReplyKeyboardBuilder {
+	return k.Button(NewKeyboardButton(text).SetRequestContact(true))
+}
+
+// RequestLocation adds a button which sends the user's current location.
+func (k *ReplyKeyboardBuilder) RequestLocation(text string) *ReplyKeyboardBuilder {
+	return k.Button(NewKeyboardButton(text).SetRequestLocation(true))
+}
+
+// WebApp adds a button which launches a web app.
+func (k *ReplyKeyboardBuilder) WebApp(text, url string) *ReplyKeyboardBuilder {
+	return k.Button(NewKeyboardButton(text).SetWebApp(WebAppInfo{URL: url}))
+}
+
+// Row ends the current row, so that following buttons are placed in a new row.
+func (k *ReplyKeyboardBuilder) Row() *ReplyKeyboardBuilder {
+	k.layout.breakRow()
+	return k
+}
+
+// Build returns the built ReplyKeyboardMarkup.
+func (k *ReplyKeyboardBuilder) Build() ReplyKeyboardMarkup {
+	return NewReplyKeyboardMarkup(k.layout.build())
+}
+
+////////////////////////////////
+// Paginator
+//
+
+// PageSource returns `limit` items from `offset`, and the total number of items.
+type PageSource[T any] func(ctx context.Context, offset, limit int) (items []T, total int, err error)
+
+// SlicePageSource returns a PageSource of given items.
+func SlicePageSource[T any](items []T) PageSource[T] {
+	return func(_ context.Context, offset, limit int) ([]T, int, error) {
+		from := min(offset, len(items))
+		to := min(offset+limit, len(items))
+		return items[from:to], len(items), nil
+	}
+}
+
+// Page is a page generated by Paginator.
+type Page[T any] struct {
+	Items    []T
+	Number   int // 1-based number of this page
+	Total    int // total number of pages
+	Keyboard InlineKeyboardMarkup
+}
+
+// Paginator generates pages of items as inline keyboards with navigation buttons (eg. "◀ 2/7 ▶").
+//
+//	paginator := Paginator[Product]{
+//		PageSize: 5,
+//		Button: func(p Product) InlineKeyboardButton {
+//			return NewInlineKeyboardButton(p.Name).SetCallbackData("product:" + p.ID)
+//		},
+//		PageData: func(page int) string { return "products:" + strconv.Itoa(page) },
+//	}
+//	page, err := paginator.Page(ctx, SlicePageSource(products), 2)
+type Paginator[T any] struct {
+	PageSize int // number of items in a page (default: 5)
+	Columns  int // number of columns of item buttons (default: 1)
+
+	// generates a button for an item (required)
+	Button func(item T) InlineKeyboardButton
+
+	// generates the callback data of a navigation button for given page (required)
+	PageData func(page int) string
+
+	PreviousText string // text of the button to the previous page (default: "◀")
+	NextText     string // text of the button to the next page (default: "▶")
+
+	// generates the text of the current page button (default: "2/7")
+	CurrentText func(page, total int) string
+
+	// callback data of the current page button (default: "noop")
+	CurrentData string
+}
+
+// Page fetches items of given page (1-based) from `source`, and generates its keyboard.
+//
+// Out-of-range page numbers are clamped to the first or the last page.
+func (p Paginator[T]) Page(ctx context.Context, source PageSource[T], page int) (result Page[T], err error) {
+	if p.Button == nil || p.PageData == nil {
+		return result, fmt.Errorf("paginator needs both Button and PageData")
+	}
+
+	size := p.PageSize
+	if size <= 0 {
+		size = 5
+	}
+
+	result.Number = max(page, 1)
+	items, count, err := source(ctx, (result.Number-1)*size, size)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch items of page %d: %w", result.Number, err)
+	}
+	result.Total = max((count+size-1)/size, 1)
+
+	// fetch the last page again, if requested page was out of range
+	if result.Number > result.Total {
+		result.Number = result.Total
+		if items, _, err = source(ctx, (result.Number-1)*size, size); err != nil {
+			return result, fmt.Errorf("failed to fetch items of page %d: %w", result.Number, err)
+		}
+	}
+	result.Items = items
+
+	keyboard := NewInlineKeyboardBuilder().Columns(max(p.Columns, 1))
+	for _, item := range result.Items {
+		keyboard.Button(p.Button(item))
+	}
+	keyboard.Row().Columns(0)
+	if result.Total > 1 {
+		keyboard.
+			ButtonIf(result.Number > 1, NewInlineKeyboardButton(cmp.Or(p.PreviousText, "◀")).SetCallbackData(p.PageData(result.Number-1))).
+			Button(NewInlineKeyboardButton(p.currentText(result.Number, result.Total)).SetCallbackData(cmp.Or(p.CurrentData, "noop"))).
+			ButtonIf(result.Number < result.Total, NewInlineKeyboardButton(cmp.Or(p.NextText, "▶")).SetCallbackData(p.PageData(result.Number+1)))
+	}
+
+	result.Keyboard, err = keyboard.Build()
+	return result, err
+}
+
+// text of the current page button
+func (p Paginator[T]) currentText(page, total int) string {
+	if p.CurrentText != nil {
+		return p.CurrentText(page, total)
+	}
+	return strconv.Itoa(page) + "/" + strconv.Itoa(total)
+}
@@ -0,0 +1,127 @@
+// keyboard_test.go
+//
+// offline tests of keyboard builders and paginators
+
+package telegrambot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// buttons should be placed in rows of fixed or auto-sized columns.
+func TestInlineKeyboardBuilder(t *testing.T) {
+	slog.Info("testing building of inline keyboards...")
+
+	isAdmin := false
+	markup, err := NewInlineKeyboardBuilder().
+		Columns(2).
+		Callback("1", "one").
+		Callback("2", "two").
+		Callback("3", "three").
+		Row().
+		Columns(0).
+		AutoColumns(12).
+		URL("docs", "https://example.com").
+		WebApp("app", "https://example.com/app").
+		CopyText("copy", "copied").
+		ButtonIf(isAdmin, NewInlineKeyboardButton("admin").SetCallbackData("admin")).
+		Row().
+		AutoColumns(0).
+		Style(KeyboardStyleDanger).
+		Callback("delete", "delete").
+		Button(NewInlineKeyboardButton("keep").SetCallbackData("keep").SetStyle(KeyboardStyleSuccess)).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build keyboard: %s", err)
+	}
+
+	layout := []string{}
+	for _, row := range markup.InlineKeyboard {
+		texts := []string{}
+		for _, button := range row {
+			texts = append(texts, button.Text)
+		}
+		layout = append(layout, strings.Join(texts, ","))
+	}
+	if strings.Join(layout, "|") != "1,2|3|docs,app|copy|delete,keep" {
+		t.Errorf("unexpected layout: %v", layout)
+	}
+
+	row := markup.InlineKeyboard[4]
+	if *row[0].Style != KeyboardStyleDanger || *row[1].Style != KeyboardStyleSuccess {
+		t.Errorf("unexpected styles: %+v", row)
+	}
+
+	// too long callback data
+	if _, err := NewInlineKeyboardBuilder().Callback("long", strings.Repeat("x", 65)).Build(); !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Errorf("expected ErrCallbackDataTooLong, got: %v", err)
+	}
+}
+
+// reply keyboards should be built with the same layouts.
+func TestReplyKeyboardBuilder(t *testing.T) {
+	slog.Info("testing building of reply keyboards...")
+
+	markup := NewReplyKeyboardBuilder().
+		Columns(2).
+		Text("a", "b", "c").
+		Row().
+		RequestContact("phone").
+		RequestLocation("location").
+		Build().
+		SetResizeKeyboard(true)
+
+	if len(markup.Keyboard) != 3 || len(markup.Keyboard[0]) != 2 || len(markup.Keyboard[1]) != 1 || !*markup.Keyboard[2][0].RequestContact {
+		t.Errorf("unexpected keyboard: %+v", markup.Keyboard)
+	}
+}
+
+// pages should be generated with navigation buttons.
+func TestPaginator(t *testing.T) {
+	slog.Info("testing paginators...")
+
+	items := []int{}
+	for i := range 33 {
+		items = append(items, i+1)
+	}
+
+	paginator := Paginator[int]{
+		PageSize: 5,
+		Columns:  5,
+		Button: func(item int) InlineKeyboardButton {
+			return NewInlineKeyboardButton(strconv.Itoa(item)).SetCallbackData("item:" + strconv.Itoa(item))
+		},
+		PageData: func(page int) string {
+			return "page:" + strconv.Itoa(page)
+		},
+	}
+
+	page, err := paginator.Page(context.TODO(), SlicePageSource(items), 2)
+	if err != nil {
+		t.Fatalf("failed to generate page: %s", err)
+	}
+	if page.Number != 2 || page.Total != 7 || len(page.Items) != 5 || page.Items[0] != 6 {
+		t.Errorf("unexpected page: %+v", page)
+	}
+	nav := page.Keyboard.InlineKeyboard[1]
+	if len(nav) != 3 || nav[0].Text != "◀" || *nav[0].CallbackData != "page:1" || nav[1].Text != "2/7" || *nav[2].CallbackData != "page:3" {
+		t.Errorf("unexpected navigation buttons: %+v", nav)
+	}
+
+	// out-of-range pages are clamped
+	page, _ = paginator.Page(context.TODO(), SlicePageSource(items), 100)
+	if page.Number != 7 || len(page.Items) != 3 || len(page.Keyboard.InlineKeyboard[1]) != 2 {
+		t.Errorf("unexpected last page: %+v", page)
+	}
+
+	// callback data are validated
+	paginator.PageData = func(page int) string { return strings.Repeat("p", 64) + strconv.Itoa(page) }
+	if _, err := paginator.Page(context.TODO(), SlicePageSource(items), 1); !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Errorf("expected ErrCallbackDataTooLong, got: %v", err)
+	}
+}
@@ -0,0 +1,992 @@
+package telegrambot
+
+// Conversion of Markdown (CommonMark, with some GFM extensions) documents to InputRichBlocks
+//
+// Supported constructs:
+//
+//   - headings (ATX and setext), paragraphs, thematic breaks
+//   - bullet/ordered lists (with task list checkboxes `[ ]`, `[x]`)
+//   - tables (GFM)
+//   - fenced/indented code blocks (with language), math blocks (`$$ ... $$` or ```math)
+//   - block quotes, `<details><summary>...</summary> ... </details>`
+//   - images in their own paragraphs (with absolute urls)
+//   - footnotes (`[^name]`, `[^name]: ...`)
+//   - inline: **bold**, *italic*, ~~strikethrough~~, ||spoiler||, ==marked==, `code`, $math$,
+//     [links](https://...), [anchor links](#name), <https://autolinks>,
+//     <u>, <s>, <del>, <sub>, <sup>, <mark>, <b>, <i>, <br>, <a name="...">
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MarkdownProblem is a problem found while converting a Markdown document to InputRichBlocks,
+// eg. a construct which is not supported, or which will not be accepted by Telegram.
+type MarkdownProblem struct {
+	Line    int // (1-based) line number in the document
+	Message string
+}
+
+// Error returns the problem as an error message.
+func (p MarkdownProblem) Error() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// MarkdownToInputRichBlocks converts given Markdown document to InputRichBlocks.
+//
+// Unsupported constructs are converted to plain texts (or dropped), and reported as `problems`,
+// so that they can be checked before sending.
+func MarkdownToInputRichBlocks(markdown string) (blocks []InputRichBlock, problems []MarkdownProblem) {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	numbers := make([]int, len(lines))
+	for i := range lines {
+		numbers[i] = i + 1
+	}
+
+	p := &markdownParser{problems: &problems}
+	return p.blocks(lines, numbers), problems
+}
+
+// NewInputRichMessageFromMarkdown converts given Markdown document to an InputRichMessage.
+//
+// Returns an error (joined with all MarkdownProblems) if there was any problem while converting.
+func NewInputRichMessageFromMarkdown(markdown string) (InputRichMessage, error) {
+	blocks, problems := MarkdownToInputRichBlocks(markdown)
+	if len(problems) > 0 {
+		errs := []error{}
+		for _, problem := range problems {
+			errs = append(errs, problem)
+		}
+		return InputRichMessage{}, errors.Join(errs...)
+	}
+
+	return InputRichMessage{
+		Blocks: blocks,
+	}, nil
+}
+
+var (
+	mdATXHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdThematicBreak  = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdSetextUnder    = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdFence          = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*?)[ \t]*$")
+	mdListItem       = regexp.MustCompile(`^( *)([-*+]|(\d{1,9})[.)])([ \t]+|$)(.*)$`)
+	mdTaskCheckbox   = regexp.MustCompile(`^\[([ xX])\][ \t]+`)
+	mdTableDelimiter = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+	mdDetailsOpen    = regexp.MustCompile(`(?i)^ {0,3}<details(\s+open)?\s*>(.*)$`)
+	mdDetailsClose   = regexp.MustCompile(`(?i)^\s*</details>\s*$`)
+	mdSummary        = regexp.MustCompile(`(?i)^\s*<summary>(.*?)</summary>\s*$`)
+	mdFootnoteDef    = regexp.MustCompile(`^ {0,3}\[\^([^\]\s]+)\]:[ \t]*(.*)$`)
+	mdImageOnly      = regexp.MustCompile(`^!\[([^\]]*)\]\(\s*<?([^\s>)]+)>?(?:\s+"[^"]*")?\s*\)$`)
+	mdHTMLBlock      = regexp.MustCompile(`^ {0,3}</?[a-zA-Z][a-zA-Z0-9-]*[\s/>]`)
+	mdAnchorTag      = regexp.MustCompile(`^<a\s+(?:name|id)="([^"]+)"\s*>\s*</a>`)
+)
+
+// markdownParser parses Markdown lines into InputRichBlocks
+type markdownParser struct {
+	problems *[]MarkdownProblem
+}
+
+// report a problem at given line
+func (p *markdownParser) problem(line int, format string, args ...any) {
+	*p.problems = append(*p.problems, MarkdownProblem{
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// expand leading tabs of given line to spaces
+func expandTabs(line string) string {
+	var sb strings.Builder
+	column := 0
+	for i, c := range line {
+		switch c {
+		case ' ':
+			sb.WriteByte(' ')
+			column++
+		case '\t':
+			n := 4 - column%4
+			sb.WriteString(strings.Repeat(" ", n))
+			column += n
+		default:
+			sb.WriteString(line[i:])
+			return sb.String()
+		}
+	}
+	return sb.String()
+}
+
+// count leading spaces of given line
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// check if given line starts a block which can interrupt a paragraph
+func (p *markdownParser) interruptsParagraph(line string) bool {
+	if mdATXHeading.MatchString(line) ||
+		mdThematicBreak.MatchString(line) ||
+		mdFence.MatchString(line) ||
+		mdDetailsOpen.MatchString(line) ||
+		strings.HasPrefix(strings.TrimLeft(line, " "), ">") ||
+		strings.TrimSpace(line) == "$$" {
+		return true
+	}
+	if m := mdListItem.FindStringSubmatch(line); m != nil && len(m[1]) < 4 && strings.TrimSpace(m[5]) != "" {
+		return m[3] == "" || m[3] == "1"
+	}
+	return false
+}
+
+// parse given lines (with their line numbers) into blocks
+func (p *markdownParser) blocks(lines []string, numbers []int) (blocks []InputRichBlock) {
+	for i := range lines {
+		lines[i] = expandTabs(lines[i])
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		from := i
+
+		switch {
+		// blank line
+		case trimmed == "":
+			i++
+
+		// indented code block
+		case leadingSpaces(line) >= 4:
+			code := []string{}
+			for ; i < len(lines) && (leadingSpaces(lines[i]) >= 4 || strings.TrimSpace(lines[i]) == ""); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, newPreformattedBlock(strings.Join(code, "\n"), ""))
+
+		// fenced code block
+		case mdFence.MatchString(line):
+			m := mdFence.FindStringSubmatch(line)
+			indent, fence, info := len(m[1]), m[2], m[3]
+			start := numbers[i]
+
+			code := []string{}
+			closed := false
+			for i++; i < len(lines); i++ {
+				if t := strings.TrimSpace(lines[i]); leadingSpaces(lines[i]) < 4 &&
+					strings.HasPrefix(t, fence[:1]) && strings.Trim(t, fence[:1]) == "" && len(t) >= len(fence) {
+					closed = true
+					i++
+					break
+				}
+				code = append(code, strings.TrimPrefix(lines[i], strings.Repeat(" ", min(indent, leadingSpaces(lines[i])))))
+			}
+			if !closed {
+				p.problem(start, "unclosed code block")
+			}
+
+			language, _, _ := strings.Cut(info, " ")
+			if strings.EqualFold(language, "math") {
+				blocks = append(blocks, NewInputRichBlockMathematicalExpression(strings.Join(code, "\n")))
+			} else {
+				blocks = append(blocks, newPreformattedBlock(strings.Join(code, "\n"), language))
+			}
+
+		// math block
+		case strings.HasPrefix(trimmed, "$$"):
+			start := numbers[i]
+			if rest := strings.TrimPrefix(trimmed, "$$"); strings.HasSuffix(rest, "$$") && len(rest) >= 2 {
+				// single line: $$ ... $$
+				blocks = append(blocks, NewInputRichBlockMathematicalExpression(strings.TrimSpace(strings.TrimSuffix(rest, "$$"))))
+				i++
+				continue
+			}
+
+			expression := []string{}
+			if rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "$$")); rest != "" {
+				expression = append(expression, rest)
+			}
+			closed := false
+			for i++; i < len(lines); i++ {
+				if t := strings.TrimSpace(lines[i]); strings.HasSuffix(t, "$$") {
+					if t = strings.TrimSpace(strings.TrimSuffix(t, "$$")); t != "" {
+						expression = append(expression, t)
+					}
+					closed = true
+					i++
+					break
+				}
+				expression = append(expression, lines[i])
+			}
+			if !closed {
+				p.problem(start, "unclosed math block")
+			}
+			blocks = append(blocks, NewInputRichBlockMathematicalExpression(strings.Join(expression, "\n")))
+
+		// ATX heading
+		case mdATXHeading.MatchString(line):
+			m := mdATXHeading.FindStringSubmatch(line)
+			blocks = append(blocks, NewInputRichBlockSectionHeading(p.inline(m[2], numbers[i]), len(m[1])))
+			i++
+
+		// thematic break
+		case mdThematicBreak.MatchString(line):
+			blocks = append(blocks, NewInputRichBlockDivider())
+			i++
+
+		// block quote
+		case strings.HasPrefix(strings.TrimLeft(line, " "), ">") && leadingSpaces(line) < 4:
+			quoted, quotedNumbers := []string{}, []int{}
+			for ; i < len(lines); i++ {
+				t := strings.TrimLeft(lines[i], " ")
+				if !strings.HasPrefix(t, ">") {
+					// lazy continuation of a paragraph
+					if strings.TrimSpace(lines[i]) != "" && len(quoted) > 0 &&
+						strings.TrimSpace(quoted[len(quoted)-1]) != "" && !p.interruptsParagraph(lines[i]) {
+						quoted = append(quoted, lines[i])
+						quotedNumbers = append(quotedNumbers, numbers[i])
+						continue
+					}
+					break
+				}
+				t = strings.TrimPrefix(t, ">")
+				t = strings.TrimPrefix(t, " ")
+				quoted = append(quoted, t)
+				quotedNumbers = append(quotedNumbers, numbers[i])
+			}
+			blocks = append(blocks, NewInputRichBlockQuotation(p.blocks(quoted, quotedNumbers), nil))
+
+		// <details>
+		case mdDetailsOpen.MatchString(line):
+			var block InputRichBlock
+			block, i = p.details(lines, numbers, i)
+			blocks = append(blocks, block)
+
+		// table
+		case strings.Contains(line, "|") && i+1 < len(lines) && mdTableDelimiter.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			var block InputRichBlock
+			block, i = p.table(lines, numbers, i)
+			blocks = append(blocks, block)
+
+		// list
+		case mdListItem.MatchString(line):
+			var block InputRichBlock
+			block, i = p.list(lines, numbers, i)
+			blocks = append(blocks, block)
+
+		// footnote definition
+		case mdFootnoteDef.MatchString(line):
+			m := mdFootnoteDef.FindStringSubmatch(line)
+			text := []string{m[2]}
+			start := numbers[i]
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (leadingSpaces(lines[i]) >= 4 || !p.interruptsParagraph(lines[i])) && !mdFootnoteDef.MatchString(lines[i]); i++ {
+				text = append(text, strings.TrimSpace(lines[i]))
+			}
+			blocks = append(blocks, NewInputRichBlockFooter(NewRichTextWithRichTexts(
+				NewRichTextWithText("["+m[1]+"] "),
+				RichText{Value: &RichTextReference{
+					Type: "reference",
+					Text: p.inline(strings.Join(text, "\n"), start),
+					Name: m[1],
+				}},
+			)))
+
+		// HTML block (not supported)
+		case mdHTMLBlock.MatchString(line):
+			start := numbers[i]
+			html := []string{}
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				html = append(html, lines[i])
+			}
+			p.problem(start, "raw HTML blocks are not supported (converted to a plain text)")
+			blocks = append(blocks, NewInputRichBlockParagraph(NewRichTextWithText(strings.Join(html, "\n"))))
+
+		// paragraph (or setext heading)
+		default:
+			start := numbers[i]
+			paragraph := []string{strings.TrimLeft(line, " ")}
+			heading := 0
+			for i++; i < len(lines); i++ {
+				if strings.TrimSpace(lines[i]) == "" {
+					break
+				}
+				if m := mdSetextUnder.FindStringSubmatch(lines[i]); m != nil {
+					heading = 1
+					if strings.HasPrefix(m[1], "-") {
+						heading = 2
+					}
+					i++
+					break
+				}
+				if p.interruptsParagraph(lines[i]) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+			}
+			text := strings.TrimRight(strings.Join(paragraph, "\n"), " \t")
+
+			if heading > 0 {
+				blocks = append(blocks, NewInputRichBlockSectionHeading(p.inline(text, start), heading))
+			} else if m := mdImageOnly.FindStringSubmatch(text); m != nil {
+				blocks = append(blocks, p.image(m[1], m[2], start))
+			} else {
+				blocks = append(blocks, NewInputRichBlockParagraph(p.inline(text, start)))
+			}
+		}
+
+		// (a line which was not consumed by any block is taken as a paragraph, for always advancing)
+		if i == from {
+			blocks = append(blocks, NewInputRichBlockParagraph(p.inline(trimmed, numbers[i])))
+			i++
+		}
+	}
+
+	return blocks
+}
+
+// create a preformatted block (without `language` if it is empty)
+func newPreformattedBlock(code, language string) InputRichBlock {
+	block := NewInputRichBlockPreformatted(NewRichTextWithText(code), language)
+	if language == "" {
+		block.Language = nil
+	}
+	return block
+}
+
+// parse an image in its own paragraph
+func (p *markdownParser) image(alt, src string, line int) InputRichBlock {
+	if !isAbsoluteURL(src) {
+		p.problem(line, "image source must be an absolute url: %s (converted to a plain text)", src)
+		return NewInputRichBlockParagraph(NewRichTextWithText(alt))
+	}
+
+	var caption *RichBlockCaption
+	if alt != "" {
+		caption = &RichBlockCaption{
+			Text: p.inline(alt, line),
+		}
+	}
+	return NewInputRichBlockPhoto(InputMediaPhoto{
+		Type:  InputMediaTypePhoto,
+		Media: src,
+	}, caption)
+}
+
+// parse a list starting at given index, and return it with the index of the next line
+func (p *markdownParser) list(lines []string, numbers []int, i int) (InputRichBlock, int) {
+	first := mdListItem.FindStringSubmatch(lines[i])
+	ordered := first[3] != ""
+	marker := first[2]
+	markerChar := marker[len(marker)-1:]
+
+	items := []InputRichBlockListItem{}
+	for i < len(lines) {
+		m := mdListItem.FindStringSubmatch(lines[i])
+		if m == nil || (m[3] != "") != ordered || m[2][len(m[2])-1:] != markerChar || leadingSpaces(lines[i]) >= 4 {
+			break
+		}
+
+		// column where the content of item starts
+		spaces := len(m[4])
+		if spaces > 4 || m[5] == "" {
+			spaces = 1
+		}
+		column := len(m[1]) + len(m[2]) + spaces
+
+		content := m[5]
+		if len(m[4]) > 4 {
+			content = strings.Repeat(" ", len(m[4])-1) + content
+		}
+		itemLines, itemNumbers := []string{content}, []int{numbers[i]}
+
+		item := InputRichBlockListItem{}
+		if ordered {
+			value, _ := strconv.Atoi(m[3])
+			item.Value = &value
+			item.Type = new("1")
+		}
+		if c := mdTaskCheckbox.FindStringSubmatch(content); c != nil {
+			itemLines[0] = content[len(c[0]):]
+			item.HasCheckbox = new(true)
+			item.IsChecked = new(c[1] != " ")
+		}
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				itemLines = append(itemLines, "")
+				itemNumbers = append(itemNumbers, numbers[i])
+				continue
+			}
+			if leadingSpaces(line) >= column {
+				itemLines = append(itemLines, line[column:])
+				itemNumbers = append(itemNumbers, numbers[i])
+				continue
+			}
+			// lazy continuation of a paragraph
+			if last := itemLines[len(itemLines)-1]; strings.TrimSpace(last) != "" &&
+				!p.interruptsParagraph(line) && !mdListItem.MatchString(line) {
+				itemLines = append(itemLines, strings.TrimLeft(line, " "))
+				itemNumbers = append(itemNumbers, numbers[i])
+				continue
+			}
+			break
+		}
+
+		// blank lines at the end belong to the list, not to the item
+		for len(itemLines) > 1 && strings.TrimSpace(itemLines[len(itemLines)-1]) == "" {
+			itemLines = itemLines[:len(itemLines)-1]
+			itemNumbers = itemNumbers[:len(itemNumbers)-1]
+		}
+
+		item.Blocks = p.blocks(itemLines, itemNumbers)
+		if item.Blocks == nil {
+			item.Blocks = []InputRichBlock{NewInputRichBlockParagraph(NewRichTextWithText(""))}
+		}
+		items = append(items, item)
+	}
+
+	return NewInputRichBlockList(items), i
+}
+
+// parse a table starting at given index, and return it with the index of the next line
+func (p *markdownParser) table(lines []string, numbers []int, i int) (InputRichBlock, int) {
+	header := splitTableRow(lines[i])
+	delimiters := splitTableRow(lines[i+1])
+	columns := len(header)
+	if len(delimiters) != columns {
+		p.problem(numbers[i+1], "table delimiter row has %d columns (expected %d)", len(delimiters), columns)
+	}
+
+	aligns := make([]string, columns)
+	for c := range columns {
+		aligns[c] = "left"
+		if c < len(delimiters) {
+			d := strings.TrimSpace(delimiters[c])
+			switch {
+			case strings.HasPrefix(d, ":") && strings.HasSuffix(d, ":"):
+				aligns[c] = "center"
+			case strings.HasSuffix(d, ":"):
+				aligns[c] = "right"
+			}
+		}
+	}
+
+	row := func(cells []string, line int, isHeader bool) []RichBlockTableCell {
+		if len(cells) != columns {
+			p.problem(line, "table row has %d columns (expected %d)", len(cells), columns)
+		}
+		row := []RichBlockTableCell{}
+		for c := range columns {
+			cell := RichBlockTableCell{
+				Text:   NewRichTextWithText(""),
+				Align:  aligns[c],
+				Valign: "top",
+			}
+			if c < len(cells) {
+				cell.Text = p.inline(strings.TrimSpace(cells[c]), line)
+			}
+			if isHeader {
+				cell.IsHeader = new(true)
+			}
+			row = append(row, cell)
+		}
+		return row
+	}
+
+	cells := [][]RichBlockTableCell{row(header, numbers[i], true)}
+	for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		cells = append(cells, row(splitTableRow(lines[i]), numbers[i], false))
+	}
+
+	return NewInputRichBlockTable(cells, nil, nil, nil), i
+}
+
+// split a row of table into cells
+func splitTableRow(line string) (cells []string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cell strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			inCode = !inCode
+			cell.WriteByte(c)
+		case c == '|' && !inCode:
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	return append(cells, cell.String())
+}
+
+// parse a <details> block starting at given index, and return it with the index of the next line
+func (p *markdownParser) details(lines []string, numbers []int, i int) (InputRichBlock, int) {
+	m := mdDetailsOpen.FindStringSubmatch(lines[i])
+	start := numbers[i]
+	isOpen := m[1] != ""
+
+	content, contentNumbers := []string{}, []int{}
+	if rest := strings.TrimSpace(m[2]); rest != "" {
+		content = append(content, rest)
+		contentNumbers = append(contentNumbers, numbers[i])
+	}
+
+	depth := 1
+	for i++; i < len(lines); i++ {
+		if mdDetailsOpen.MatchString(lines[i]) {
+			depth++
+		} else if mdDetailsClose.MatchString(lines[i]) {
+			if depth--; depth == 0 {
+				i++
+				break
+			}
+		}
+		content = append(content, lines[i])
+		contentNumbers = append(contentNumbers, numbers[i])
+	}
+	if depth > 0 {
+		p.problem(start, "unclosed <details>")
+	}
+
+	// <summary>
+	summary := NewRichTextWithText("")
+	for j, line := range content {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if s := mdSummary.FindStringSubmatch(line); s != nil {
+			summary = p.inline(strings.TrimSpace(s[1]), contentNumbers[j])
+			content = content[j+1:]
+			contentNumbers = contentNumbers[j+1:]
+		} else {
+			p.problem(start, "<details> without <summary>")
+		}
+		break
+	}
+
+	var open *bool
+	if isOpen {
+		open = new(true)
+	}
+	return NewInputRichBlockDetails(summary, p.blocks(content, contentNumbers), open), i
+}
+
+////////////////////////////////
+// Inline parsing
+//
+
+// richTextParts is a builder of RichText parts
+type richTextParts []RichText
+
+// append a plain text (merged with the previous one if possible)
+func (parts *richTextParts) text(text string) {
+	if text == "" {
+		return
+	}
+	if n := len(*parts); n > 0 {
+		if prev, ok := (*parts)[n-1].Value.(string); ok {
+			(*parts)[n-1].Value = prev + text
+			return
+		}
+	}
+	*parts = append(*parts, NewRichTextWithText(text))
+}
+
+// append a typed RichText
+func (parts *richTextParts) typed(value any) {
+	*parts = append(*parts, RichText{Value: value})
+}
+
+// build a RichText from the parts
+func (parts richTextParts) build() RichText {
+	switch len(parts) {
+	case 0:
+		return NewRichTextWithText("")
+	case 1:
+		return parts[0]
+	}
+	return NewRichTextWithRichTexts(parts...)
+}
+
+// inline html tags which are supported
+var mdInlineTags = map[string]string{
+	"u":      "underline",
+	"ins":    "underline",
+	"s":      "strikethrough",
+	"del":    "strikethrough",
+	"sub":    "subscript",
+	"sup":    "superscript",
+	"mark":   "marked",
+	"b":      "bold",
+	"strong": "bold",
+	"i":      "italic",
+	"em":     "italic",
+}
+
+// emphasis delimiters and their types (longer ones first)
+var mdDelimiters = []struct {
+	delimiter string
+	typ       string
+}{
+	{"**", "bold"},
+	{"__", "bold"},
+	{"~~", "strikethrough"},
+	{"||", "spoiler"},
+	{"==", "marked"},
+	{"*", "italic"},
+	{"_", "italic"},
+}
+
+// parse inline elements of given text
+func (p *markdownParser) inline(text string, line int) RichText {
+	parts := richTextParts{}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		// backslash escape, or hard line break
+		case c == '\\' && i+1 < len(text):
+			if text[i+1] == '\n' {
+				parts.text("\n")
+			} else if isASCIIPunct(text[i+1]) {
+				parts.text(text[i+1 : i+2])
+			} else {
+				parts.text(`\` + text[i+1:i+2])
+			}
+			i += 2
+			continue
+
+		// line break
+		case c == '\n':
+			if strings.HasSuffix(text[:i], "  ") {
+				parts.trimTrailingSpaces()
+				parts.text("\n")
+			} else {
+				parts.trimTrailingSpaces()
+				parts.text(" ")
+			}
+			i++
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+			continue
+
+		// code span
+		case c == '`':
+			n := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := findBacktickRun(text, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(text[i+n:end], "\n", " ")
+				if len(code) > 1 && strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				parts.typed(&RichTextCode{Type: "code", Text: NewRichTextWithText(code)})
+				i = end + n
+			} else {
+				parts.text(text[i : i+n])
+				i += n
+			}
+			continue
+
+		// inline math
+		case c == '$' && i+1 < len(text) && text[i+1] != ' ' && text[i+1] != '$':
+			if end := findInlineMathEnd(text, i+1); end >= 0 {
+				parts.typed(&RichTextMathematicalExpression{Type: "mathematical_expression", Expression: text[i+1 : end]})
+				i = end + 1
+				continue
+			}
+
+		// inline image (not supported)
+		case strings.HasPrefix(rest, "!["):
+			if label, dest, n, ok := parseInlineLink(rest[1:]); ok {
+				p.problem(line, "inline images are not supported: %s (converted to a plain text)", dest)
+				parts.text(label)
+				i += 1 + n
+				continue
+			}
+
+		// footnote reference
+		case strings.HasPrefix(rest, "[^"):
+			if end := strings.IndexByte(rest, ']'); end > 2 && !strings.ContainsAny(rest[2:end], " \n") &&
+				!strings.HasPrefix(rest[end+1:], ":") {
+				name := rest[2:end]
+				parts.typed(&RichTextReferenceLink{Type: "reference_link", Text: NewRichTextWithText("[" + name + "]"), ReferenceName: name})
+				i += end + 1
+				continue
+			}
+
+		// link
+		case c == '[':
+			if label, dest, n, ok := parseInlineLink(rest); ok {
+				inner := p.inline(label, line)
+				if anchor, isAnchor := strings.CutPrefix(dest, "#"); isAnchor {
+					parts.typed(&RichTextAnchorLink{Type: "anchor_link", Text: inner, AnchorName: anchor})
+				} else if isAbsoluteURL(dest) {
+					parts.typed(&RichTextURL{Type: "url", Text: inner, URL: dest})
+				} else {
+					p.problem(line, "link must be an absolute url: %s (converted to a plain text)", dest)
+					parts = append(parts, inner)
+				}
+				i += n
+				continue
+			}
+
+		// autolink, inline html
+		case c == '<':
+			if end := strings.IndexByte(rest, '>'); end > 1 {
+				inside := rest[1:end]
+
+				// autolink
+				if !strings.ContainsAny(inside, " \n<") && isAbsoluteURL(inside) {
+					parts.typed(&RichTextURL{Type: "url", Text: NewRichTextWithText(inside), URL: inside})
+					i += end + 1
+					continue
+				}
+				if !strings.ContainsAny(inside, " \n<:/") && strings.Contains(inside, "@") {
+					parts.typed(&RichTextEmailAddress{Type: "email_address", Text: NewRichTextWithText(inside), EmailAddress: inside})
+					i += end + 1
+					continue
+				}
+
+				// <br>
+				if tag := strings.ToLower(strings.TrimSpace(strings.TrimSuffix(inside, "/"))); tag == "br" {
+					parts.text("\n")
+					i += end + 1
+					continue
+				}
+
+				// <a name="..."></a>
+				if m := mdAnchorTag.FindStringSubmatch(rest); m != nil {
+					parts.typed(&RichTextAnchor{Type: "anchor", Name: m[1]})
+					i += len(m[0])
+					continue
+				}
+
+				// <u>...</u>, ...
+				tag := strings.ToLower(inside)
+				if typ, exists := mdInlineTags[tag]; exists {
+					closing := "</" + tag + ">"
+					if closeAt := strings.Index(strings.ToLower(rest[end+1:]), closing); closeAt >= 0 {
+						inner := p.inline(rest[end+1:end+1+closeAt], line)
+						parts.typed(newStyledRichText(typ, inner))
+						i += end + 1 + closeAt + len(closing)
+						continue
+					}
+				}
+
+				if tag != "" && (isASCIILetter(tag[0]) || tag[0] == '/') {
+					p.problem(line, "inline HTML is not supported: <%s> (converted to a plain text)", inside)
+				}
+			}
+
+		// emphasis
+		default:
+			if typ, n, end, ok := findEmphasis(text, i); ok {
+				parts.typed(newStyledRichText(typ, p.inline(text[i+n:end], line)))
+				i = end + n
+				continue
+			}
+		}
+
+		parts.text(text[i : i+1])
+		i++
+	}
+
+	return parts.build()
+}
+
+// trim trailing spaces of the last plain text
+func (parts *richTextParts) trimTrailingSpaces() {
+	if n := len(*parts); n > 0 {
+		if prev, ok := (*parts)[n-1].Value.(string); ok {
+			(*parts)[n-1].Value = strings.TrimRight(prev, " ")
+		}
+	}
+}
+
+// create a styled RichText of given type
+func newStyledRichText(typ string, text RichText) any {
+	switch typ {
+	case "bold":
+		return &RichTextBold{Type: typ, Text: text}
+	case "italic":
+		return &RichTextItalic{Type: typ, Text: text}
+	case "underline":
+		return &RichTextUnderline{Type: typ, Text: text}
+	case "strikethrough":
+		return &RichTextStrikethrough{Type: typ, Text: text}
+	case "spoiler":
+		return &RichTextSpoiler{Type: typ, Text: text}
+	case "subscript":
+		return &RichTextSubscript{Type: typ, Text: text}
+	case "superscript":
+		return &RichTextSuperscript{Type: typ, Text: text}
+	case "marked":
+		return &RichTextMarked{Type: typ, Text: text}
+	}
+	return text.Value
+}
+
+// find an emphasis which starts at given index, and return its type, the length of delimiter, and the index of closing delimiter
+func findEmphasis(text string, i int) (typ string, n, end int, ok bool) {
+	for _, d := range mdDelimiters {
+		if !strings.HasPrefix(text[i:], d.delimiter) {
+			continue
+		}
+		n = len(d.delimiter)
+
+		// opening delimiter must be followed by a non-space
+		if i+n >= len(text) || text[i+n] == ' ' || text[i+n] == '\n' {
+			return "", 0, 0, false
+		}
+		// intraword underscores are not emphasis
+		if d.delimiter[0] == '_' && i > 0 && isASCIIAlnum(text[i-1]) {
+			return "", 0, 0, false
+		}
+
+		for j := i + n + 1; j+n <= len(text); j++ {
+			switch text[j] {
+			case '\\':
+				j++
+				continue
+			case '`':
+				// skip code spans
+				run := len(text[j:]) - len(strings.TrimLeft(text[j:], "`"))
+				if close := findBacktickRun(text, j+run, run); close >= 0 {
+					j = close + run - 1
+				}
+				continue
+			}
+			if !strings.HasPrefix(text[j:], d.delimiter) || text[j-1] == ' ' || text[j-1] == '\n' {
+				continue
+			}
+			// single delimiters should not match the doubled ones
+			if n == 1 && j+1 < len(text) && text[j+1] == d.delimiter[0] {
+				j++
+				continue
+			}
+			if d.delimiter[0] == '_' && j+n < len(text) && isASCIIAlnum(text[j+n]) {
+				continue
+			}
+			return d.typ, n, j, true
+		}
+		return "", 0, 0, false
+	}
+	return "", 0, 0, false
+}
+
+// find the index of a backtick run with exactly given length, from given index
+func findBacktickRun(text string, from, length int) int {
+	for j := from; j < len(text); {
+		if text[j] != '`' {
+			j++
+			continue
+		}
+		run := len(text[j:]) - len(strings.TrimLeft(text[j:], "`"))
+		if run == length {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+// find the index of closing '$' of an inline math
+func findInlineMathEnd(text string, from int) int {
+	for j := from; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '\n':
+			return -1
+		case '$':
+			if text[j-1] != ' ' && (j+1 >= len(text) || !isASCIIDigit(text[j+1])) {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// parse an inline link (`[label](destination "title")`), and return its label, destination, and length
+func parseInlineLink(text string) (label, dest string, n int, ok bool) {
+	depth := 0
+	closeAt := -1
+	for j := 0; j < len(text) && closeAt < 0; j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				closeAt = j
+			}
+		}
+	}
+	if closeAt < 0 || !strings.HasPrefix(text[closeAt+1:], "(") {
+		return "", "", 0, false
+	}
+
+	end := strings.IndexByte(text[closeAt+2:], ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+	inside := strings.TrimSpace(text[closeAt+2 : closeAt+2+end])
+	if strings.HasPrefix(inside, "<") {
+		if gt := strings.IndexByte(inside, '>'); gt > 0 {
+			inside = inside[1:gt]
+		}
+	} else if before, _, found := strings.Cut(inside, " "); found {
+		inside = before // strip title
+	}
+
+	return text[1:closeAt], inside, closeAt + 2 + end + 1, true
+}
+
+// check if given url is absolute (with a scheme supported by Telegram)
+func isAbsoluteURL(str string) bool {
+	u, err := url.Parse(str)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "tg", "mailto", "tel", "ton":
+		return true
+	}
+	return false
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIIAlnum(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c)
+}
@@ -0,0 +1,223 @@
+// markdown_rich_test.go
+//
+// offline tests of converting Markdown documents to InputRichBlocks
+
+package telegrambot
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMarkdown = "# Changelog v1.2\n" +
+	"\n" +
+	"Released with **bold**, *italic*, ~~old~~, `code`, $e=mc^2$ and [docs](https://example.com/docs \"title\").\n" +
+	"Soft break, see [above](#top) and a note[^n1].\n" +
+	"\n" +
+	"- [x] done\n" +
+	"- [ ] todo\n" +
+	"  - nested *item*\n" +
+	"\n" +
+	"3. third\n" +
+	"4. fourth\n" +
+	"\n" +
+	"| name | score |\n" +
+	"|:-----|------:|\n" +
+	"| a \\| b | `1` |\n" +
+	"\n" +
+	"```go\n" +
+	"fmt.Println(\"**not bold**\")\n" +
+	"```\n" +
+	"\n" +
+	"$$\n" +
+	"\\int_0^1 x\\,dx\n" +
+	"$$\n" +
+	"\n" +
+	"> quoted <u>text</u>\n" +
+	"continued\n" +
+	"\n" +
+	"<details open>\n" +
+	"<summary>More</summary>\n" +
+	"\n" +
+	"Hidden __content__.\n" +
+	"\n" +
+	"</details>\n" +
+	"\n" +
+	"---\n" +
+	"\n" +
+	"![a photo](https://example.com/photo.jpg)\n" +
+	"\n" +
+	"[^n1]: the note\n"
+
+// Markdown documents should be converted to InputRichBlocks.
+func TestMarkdownToInputRichBlocks(t *testing.T) {
+	slog.Info("testing conversion of Markdown to InputRichBlocks...")
+
+	blocks, problems := MarkdownToInputRichBlocks(testMarkdown)
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	types := []string{}
+	for _, block := range blocks {
+		types = append(types, block.Type)
+	}
+	if strings.Join(types, ",") != "heading,paragraph,list,list,table,pre,mathematical_expression,blockquote,details,divider,photo,footer" {
+		t.Fatalf("unexpected blocks: %v", types)
+	}
+
+	if *blocks[0].Size != 1 {
+		t.Errorf("unexpected heading size: %d", *blocks[0].Size)
+	}
+
+	// inline styles
+	paragraph, err := json.Marshal(blocks[1])
+	if err != nil {
+		t.Fatalf("failed to marshal paragraph: %s", err)
+	}
+	for _, expected := range []string{
+		`{"type":"bold","text":"bold"}`,
+		`{"type":"italic","text":"italic"}`,
+		`{"type":"strikethrough","text":"old"}`,
+		`{"type":"code","text":"code"}`,
+		`{"type":"mathematical_expression","expression":"e=mc^2"}`,
+		`{"type":"url","text":"docs","url":"https://example.com/docs"}`,
+		`". Soft break, see "`,
+		`{"type":"anchor_link","text":"above","anchor_name":"top"}`,
+		`{"type":"reference_link","text":"[n1]","reference_name":"n1"}`,
+	} {
+		if !strings.Contains(string(paragraph), expected) {
+			t.Errorf("expected %s in paragraph: %s", expected, paragraph)
+		}
+	}
+
+	// lists
+	tasks := blocks[2].Items
+	if len(tasks) != 2 || !*tasks[0].IsChecked || *tasks[1].IsChecked || tasks[1].Blocks[1].Type != "list" {
+		t.Errorf("unexpected task list: %+v", tasks)
+	}
+	if ordered := blocks[3].Items; len(ordered) != 2 || *ordered[0].Value != 3 || *ordered[1].Value != 4 {
+		t.Errorf("unexpected ordered list: %+v", ordered)
+	}
+
+	// table
+	cells := blocks[4].Cells
+	if len(cells) != 2 || !*cells[0][0].IsHeader || cells[0][1].Align != "right" || cells[1][0].Text.Value != "a | b" {
+		t.Errorf("unexpected table: %+v", cells)
+	}
+
+	// code & math
+	if *blocks[5].Language != "go" || blocks[5].Text.Value != `fmt.Println("**not bold**")` {
+		t.Errorf("unexpected code block: %+v", blocks[5])
+	}
+	if *blocks[6].Expression != `\int_0^1 x\,dx` {
+		t.Errorf("unexpected math block: %s", *blocks[6].Expression)
+	}
+
+	// quote & details
+	if quote := blocks[7].Blocks; len(quote) != 1 || quote[0].Type != "paragraph" {
+		t.Errorf("unexpected quote: %+v", quote)
+	}
+	if details := blocks[8]; details.Summary.Value != "More" || !*details.IsOpen || len(details.Blocks) != 1 {
+		t.Errorf("unexpected details: %+v", details)
+	}
+
+	// photo
+	if photo := blocks[10]; photo.Photo.Media != "https://example.com/photo.jpg" {
+		t.Errorf("unexpected photo: %+v", photo)
+	}
+
+	// round-trip: rendered as a received RichMessage (except the photo which differs in received ones)
+	bytes, _ := json.Marshal(InputRichMessage{Blocks: append(blocks[:10:10], blocks[11:]...)})
+	var received RichMessage
+	if err := json.Unmarshal(bytes, &received); err != nil {
+		t.Fatalf("failed to unmarshal converted blocks: %s", err)
+	}
+	rendered := received.PlainText(nil)
+	for _, expected := range []string{
+		"Changelog v1.2",
+		"• [x] done\n• [ ] todo\n      • nested item",
+		"3. third\n4. fourth",
+		"quoted text continued",
+		"Hidden content.",
+		"[n1] [1] the note",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("expected %q in rendered text:\n%s", expected, rendered)
+		}
+	}
+}
+
+// unsupported constructs should be reported as problems.
+func TestMarkdownProblems(t *testing.T) {
+	slog.Info("testing problems of Markdown conversion...")
+
+	_, problems := MarkdownToInputRichBlocks("see [relative](docs/readme.md)\n" +
+		"\n" +
+		"<div>raw html</div>\n" +
+		"\n" +
+		"| a | b |\n" +
+		"|---|---|\n" +
+		"| 1 |\n" +
+		"\n" +
+		"```\n" +
+		"unclosed\n")
+
+	expected := []int{1, 3, 7, 9}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got: %v", len(expected), problems)
+	}
+	for i, line := range expected {
+		if problems[i].Line != line {
+			t.Errorf("expected problem at line %d, got: %s", line, problems[i])
+		}
+	}
+
+	if _, err := NewInputRichMessageFromMarkdown("[x](ftp://example.com)"); err == nil {
+		t.Errorf("expected an error for an unsupported link")
+	}
+}
+
+// inputs which once hung the parser (non-space whitespace before `>`)
+var testMarkdownHangs = []string{
+	"\u00a0> quoted",
+	"\v>",
+	"text\n\u00a0> q",
+	"> a\n\u00a0> b",
+	"- item\n\v> q",
+}
+
+// parsing should always terminate, even with whitespace other than spaces before block markers.
+func TestMarkdownWhitespaceBeforeQuote(t *testing.T) {
+	slog.Info("testing Markdown with non-space whitespace before quotes...")
+
+	for _, markdown := range testMarkdownHangs {
+		done := make(chan []InputRichBlock, 1)
+		go func() {
+			blocks, _ := MarkdownToInputRichBlocks(markdown)
+			done <- blocks
+		}()
+
+		select {
+		case blocks := <-done:
+			if len(blocks) == 0 {
+				t.Errorf("expected blocks for %q", markdown)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("conversion of %q did not terminate", markdown)
+		}
+	}
+}
+
+// conversion should terminate for any input.
+func FuzzMarkdownToInputRichBlocks(f *testing.F) {
+	for _, seed := range append(testMarkdownHangs, testMarkdown, "> quote\n>\n> - item", "$$\nx\n", "| a |\n|---|\n") {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, markdown string) {
+		_, _ = MarkdownToInputRichBlocks(markdown)
+	})
+}
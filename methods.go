@@ -1559,7 +1559,7 @@ func (b *Bot) GetFile(
 
 // GetFileURL gets download link from a given File.
 func (b *Bot) GetFileURL(file File) string {
-	return fmt.Sprintf("%s%s/%s", fileBaseURL, b.token, *file.FilePath)
+	return fmt.Sprintf("%s%s/%s", b.fileBaseURL, b.token, *file.FilePath)
 }
 
 // BanChatMember bans a chat member.
@@ -2625,13 +2625,13 @@ func (b *Bot) DeleteAllMessageReactions(
 
 // AnswerInlineQuery sends answers to an inline query.
 //
-// results = array of InlineQueryResultArticle, InlineQueryResultPhoto, InlineQueryResultGif, InlineQueryResultMpeg4Gif, or InlineQueryResultVideo.
+// results = array of InlineQueryResultXXX types (eg. InlineQueryResultArticle, InlineQueryResultPhoto, ...).
 //
 // https://core.telegram.org/bots/api#answerinlinequery
 func (b *Bot) AnswerInlineQuery(
 	ctx context.Context,
 	inlineQueryID string,
-	results []any,
+	results []InlineQueryResultVariant,
 	options OptionsAnswerInlineQuery,
 ) (result APIResponse[bool], err error) {
 	if options == nil {
@@ -3301,7 +3301,7 @@ func (b *Bot) request(
 	method string,
 	params map[string]any,
 ) (resp []byte, err error) {
-	apiURL := fmt.Sprintf("%s%s/%s", apiBaseURL, b.token, method)
+	apiURL := fmt.Sprintf("%s%s/%s", b.apiBaseURL, b.token, method)
 
 	b.verbose("sending request to api url: %s, params: %#v", apiURL, params)
 
@@ -3587,6 +3587,11 @@ func (b *Bot) requestMessageOrBool(
 		var resMessage APIResponse[Message]
 		err = json.Unmarshal(bytes, &resMessage)
 		if err == nil {
+			if resMessage.OK {
+				b.reportUnknownFields(method, bytes, &resMessage)
+				b.keepRawJSONOf(bytes, &resMessage)
+			}
+
 			res := APIResponseMessageOrBool{
 				OK:            resMessage.OK,
 				Description:   resMessage.Description,
@@ -3638,6 +3643,11 @@ func requestGeneric[T any](
 		var res APIResponse[T]
 		err = json.Unmarshal(bytes, &res)
 		if err == nil {
+			if res.OK {
+				b.reportUnknownFields(method, bytes, &res)
+				b.keepRawJSONOf(bytes, &res)
+			}
+
 			if !res.OK && res.Description != nil {
 				err = strToErr(*res.Description)
 			}
@@ -3668,15 +3678,10 @@ func (b *Bot) handleWebhook(writer http.ResponseWriter, req *http.Request) {
 			b.error("error while parsing json (%s)", err)
 		} else {
 			b.verbose("received webhook body: %s", string(body))
+			b.reportUnknownFields("webhook", body, &webhook)
+			b.keepRawJSONOf(body, &webhook)
 
-			// if there is a matching command, handle it as a command,
-			if !handleUpdateAsCommand(b, webhook) {
-				// if there is a matching handler by type, handle with it
-				if !handleUpdateByType(b, webhook) {
-					// otherwise, handle it manually
-					b.runHandler(func() { b.updateHandler(b, webhook, nil) })
-				}
-			}
+			b.dispatchUpdate(webhook)
 		}
 	} else {
 		b.error("error while reading webhook request (%s)", err)
@@ -178,7 +178,7 @@ func (o OptionsSendMessage) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendMessage.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendMessage) SetReplyMarkup(replyMarkup any) OptionsSendMessage {
+func (o OptionsSendMessage) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendMessage {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -351,7 +351,7 @@ func (o OptionsCopyMessage) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the reply_markup value of OptionsCopyMessage.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsCopyMessage) SetReplyMarkup(replyMarkup any) OptionsCopyMessage {
+func (o OptionsCopyMessage) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsCopyMessage {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -503,7 +503,7 @@ func (o OptionsSendPhoto) SetReplyParameters(replyParameters ReplyParameters) Op
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendPhoto.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendPhoto) SetReplyMarkup(replyMarkup any) OptionsSendPhoto {
+func (o OptionsSendPhoto) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendPhoto {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -604,7 +604,7 @@ func (o OptionsSendLivePhoto) SetReplyParameters(replyParameters ReplyParameters
 }
 
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendLivePhoto.
-func (o OptionsSendLivePhoto) SetReplyMarkup(replyMarkup any) OptionsSendLivePhoto {
+func (o OptionsSendLivePhoto) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendLivePhoto {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -733,7 +733,7 @@ func (o OptionsSendAudio) SetReplyParameters(replyParameters ReplyParameters) Op
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendAudio.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendAudio) SetReplyMarkup(replyMarkup any) OptionsSendAudio {
+func (o OptionsSendAudio) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendAudio {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -850,7 +850,7 @@ func (o OptionsSendDocument) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the reply_markup value of OptionsSendDocument.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendDocument) SetReplyMarkup(replyMarkup any) OptionsSendDocument {
+func (o OptionsSendDocument) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendDocument {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -940,7 +940,7 @@ func (o OptionsSendSticker) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendSticker.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendSticker) SetReplyMarkup(replyMarkup any) OptionsSendSticker {
+func (o OptionsSendSticker) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendSticker {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1010,7 +1010,7 @@ func (o OptionsSendRichMessage) SetReplyParameters(replyParameters ReplyParamete
 }
 
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendRichMessage.
-func (o OptionsSendRichMessage) SetReplyMarkup(replyMarkup any) OptionsSendRichMessage {
+func (o OptionsSendRichMessage) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendRichMessage {
 	// `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
 	o["reply_markup"] = replyMarkup
 	return o
@@ -1328,7 +1328,7 @@ func (o OptionsSendVideo) SetReplyParameters(replyParameters ReplyParameters) Op
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendVideo.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendVideo) SetReplyMarkup(replyMarkup any) OptionsSendVideo {
+func (o OptionsSendVideo) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendVideo {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1469,7 +1469,7 @@ func (o OptionsSendAnimation) SetReplyParameters(replyParameters ReplyParameters
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendAnimation.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendAnimation) SetReplyMarkup(replyMarkup any) OptionsSendAnimation {
+func (o OptionsSendAnimation) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendAnimation {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1577,7 +1577,7 @@ func (o OptionsSendVoice) SetReplyParameters(replyParameters ReplyParameters) Op
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendVoice.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendVoice) SetReplyMarkup(replyMarkup any) OptionsSendVoice {
+func (o OptionsSendVoice) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendVoice {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1683,7 +1683,7 @@ func (o OptionsSendVideoNote) SetReplyParameters(replyParameters ReplyParameters
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendVideoNote.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendVideoNote) SetReplyMarkup(replyMarkup any) OptionsSendVideoNote {
+func (o OptionsSendVideoNote) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendVideoNote {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1774,7 +1774,7 @@ func (o OptionsSendPaidMedia) SetReplyParameters(replyParameters ReplyParameters
 }
 
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendPaidMedia.
-func (o OptionsSendPaidMedia) SetReplyMarkup(replyMarkup any) OptionsSendPaidMedia {
+func (o OptionsSendPaidMedia) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendPaidMedia {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -1938,7 +1938,7 @@ func (o OptionsSendLocation) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendLocation.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendLocation) SetReplyMarkup(replyMarkup any) OptionsSendLocation {
+func (o OptionsSendLocation) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendLocation {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -2047,7 +2047,7 @@ func (o OptionsSendVenue) SetReplyParameters(replyParameters ReplyParameters) Op
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendVenue.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendVenue) SetReplyMarkup(replyMarkup any) OptionsSendVenue {
+func (o OptionsSendVenue) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendVenue {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -2250,7 +2250,7 @@ func (o OptionsSendPoll) SetReplyParameters(replyParameters ReplyParameters) Opt
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendPoll.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendPoll) SetReplyMarkup(replyMarkup any) OptionsSendPoll {
+func (o OptionsSendPoll) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendPoll {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -2409,7 +2409,7 @@ func (o OptionsSendDice) SetReplyParameters(replyParameters ReplyParameters) Opt
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendDice.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendDice) SetReplyMarkup(replyMarkup any) OptionsSendDice {
+func (o OptionsSendDice) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendDice {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -2568,7 +2568,7 @@ func (o OptionsSendContact) SetReplyParameters(replyParameters ReplyParameters)
 // SetReplyMarkup sets the `reply_markup` value of OptionsSendContact.
 //
 // `replyMarkup` can be one of InlineKeyboardMarkup, ReplyKeyboardMarkup, ReplyKeyboardRemove, or ForceReply.
-func (o OptionsSendContact) SetReplyMarkup(replyMarkup any) OptionsSendContact {
+func (o OptionsSendContact) SetReplyMarkup(replyMarkup ReplyMarkup) OptionsSendContact {
 	o["reply_markup"] = replyMarkup
 	return o
 }
@@ -2949,7 +2949,7 @@ type OptionsGetMyCommands MethodOptions
 // SetScope sets the `scope` value of OptionsGetMyCommands.
 //
 // `scope` can be one of: BotCommandScopeDefault, BotCommandScopeAllPrivateChats, BotCommandScopeAllGroupChats, BotCommandScopeAllChatAdministrators, BotCommandScopeChat, BotCommandScopeChatAdministrators, or BotCommandScopeChatMember.
-func (o OptionsGetMyCommands) SetScope(scope any) OptionsGetMyCommands {
+func (o OptionsGetMyCommands) SetScope(scope BotCommandScope) OptionsGetMyCommands {
 	o["scope"] = scope
 	return o
 }
@@ -2985,7 +2985,7 @@ type OptionsSetMyCommands MethodOptions
 // SetScope sets the `scope` value of OptionsSetMyCommands.
 //
 // `scope` can be one of: BotCommandScopeDefault, BotCommandScopeAllPrivateChats, BotCommandScopeAllGroupChats, BotCommandScopeAllChatAdministrators, BotCommandScopeChat, BotCommandScopeChatAdministrators, or BotCommandScopeChatMember.
-func (o OptionsSetMyCommands) SetScope(scope any) OptionsSetMyCommands {
+func (o OptionsSetMyCommands) SetScope(scope BotCommandScope) OptionsSetMyCommands {
 	o["scope"] = scope
 	return o
 }
@@ -3008,7 +3008,7 @@ type OptionsDeleteMyCommands MethodOptions
 // SetScope sets the `scope` value of OptionsDeleteMyCommands.
 //
 // `scope` can be one of: BotCommandScopeDefault, BotCommandScopeAllPrivateChats, BotCommandScopeAllGroupChats, BotCommandScopeAllChatAdministrators, BotCommandScopeChat, BotCommandScopeChatAdministrators, or BotCommandScopeChatMember.
-func (o OptionsDeleteMyCommands) SetScope(scope any) OptionsDeleteMyCommands {
+func (o OptionsDeleteMyCommands) SetScope(scope BotCommandScope) OptionsDeleteMyCommands {
 	o["scope"] = scope
 	return o
 }
@@ -0,0 +1,823 @@
+package telegrambot
+
+// Rendering of received RichMessages to HTML, Markdown (CommonMark), and plain text
+//
+// https://core.telegram.org/bots/api#richmessage
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RichFormat is a format of rendered RichMessages
+type RichFormat string
+
+// RichFormat strings
+const (
+	RichFormatHTML      RichFormat = "html"
+	RichFormatMarkdown  RichFormat = "markdown" // CommonMark (with inline HTML for styles not supported by it)
+	RichFormatPlainText RichFormat = "text"
+)
+
+// RichRenderOptions is options for rendering RichMessages.
+type RichRenderOptions struct {
+	// Media renders media blocks (type == "animation", "audio", "photo", "video", "voice_note", or "map")
+	// in given format. Captions of the blocks are rendered separately.
+	//
+	// When nil, placeholders like "[photo]" will be rendered instead.
+	Media func(format RichFormat, block RichBlock) string
+
+	// Unknown renders (future) RichTexts of unknown types, which are decoded as map[string]any.
+	// The returned string must be already escaped for given format.
+	//
+	// When nil, `text` of the value will be rendered if it exists.
+	Unknown func(format RichFormat, value map[string]any) string
+}
+
+// HTML renders the RichMessage to a sanitized HTML.
+func (m RichMessage) HTML(options *RichRenderOptions) string {
+	return newRichRenderer(RichFormatHTML, options, m.Blocks).blocks(m.Blocks)
+}
+
+// Markdown renders the RichMessage to a CommonMark string.
+func (m RichMessage) Markdown(options *RichRenderOptions) string {
+	return newRichRenderer(RichFormatMarkdown, options, m.Blocks).blocks(m.Blocks)
+}
+
+// PlainText renders the RichMessage to a plain text.
+func (m RichMessage) PlainText(options *RichRenderOptions) string {
+	return newRichRenderer(RichFormatPlainText, options, m.Blocks).blocks(m.Blocks)
+}
+
+// PlainText renders the RichText to a plain text.
+func (t RichText) PlainText() string {
+	return newRichRenderer(RichFormatPlainText, nil, nil).text(t)
+}
+
+// richRenderer renders RichTexts and RichBlocks in a format
+type richRenderer struct {
+	format  RichFormat
+	options RichRenderOptions
+
+	anchors    map[string]bool // names of anchors
+	references map[string]int  // names of references => their numbers
+}
+
+// returns a new richRenderer, with anchors and references in given blocks resolved
+func newRichRenderer(format RichFormat, options *RichRenderOptions, blocks []RichBlock) *richRenderer {
+	r := &richRenderer{
+		format:     format,
+		anchors:    map[string]bool{},
+		references: map[string]int{},
+	}
+	if options != nil {
+		r.options = *options
+	}
+	r.collectBlocks(blocks)
+
+	return r
+}
+
+////////////////////////////////
+// Resolution of anchors and references
+//
+
+func (r *richRenderer) collectBlocks(blocks []RichBlock) {
+	for _, block := range blocks {
+		if block.Type == "anchor" && block.Name != nil {
+			r.anchors[*block.Name] = true
+		}
+		r.collectText(block.Text)
+		r.collectText(block.Credit)
+		r.collectText(block.Summary)
+		if block.Caption != nil {
+			r.collectText(block.Caption.Text)
+			r.collectText(block.Caption.Credit)
+		}
+		for _, row := range block.Cells {
+			for _, cell := range row {
+				r.collectText(cell.Text)
+			}
+		}
+		for _, item := range block.Items {
+			r.collectBlocks(item.Blocks)
+		}
+		r.collectBlocks(block.Blocks)
+	}
+}
+
+func (r *richRenderer) collectText(t RichText) {
+	switch v := normalizeRichTextValue(t.Value).(type) {
+	case []RichText:
+		for _, child := range v {
+			r.collectText(child)
+		}
+	case *RichTextAnchor:
+		r.anchors[v.Name] = true
+	case *RichTextReference:
+		if _, exists := r.references[v.Name]; !exists {
+			r.references[v.Name] = len(r.references) + 1
+		}
+		r.collectText(v.Text)
+	default:
+		if child, ok := richTextChild(v); ok {
+			r.collectText(child)
+		}
+	}
+}
+
+// normalize given value of RichText (eg. RichTextBold => *RichTextBold)
+func normalizeRichTextValue(value any) any {
+	switch value.(type) {
+	case nil, string, []RichText, map[string]any:
+		return value
+	}
+
+	// non-pointer structs: re-decode into pointers
+	if bytes, err := json.Marshal(value); err == nil {
+		var t RichText
+		if err := t.UnmarshalJSON(bytes); err == nil {
+			return t.Value
+		}
+	}
+	return value
+}
+
+// get the nested text of given (normalized) value of RichText
+func richTextChild(value any) (RichText, bool) {
+	switch v := value.(type) {
+	case *RichTextBold:
+		return v.Text, true
+	case *RichTextItalic:
+		return v.Text, true
+	case *RichTextUnderline:
+		return v.Text, true
+	case *RichTextStrikethrough:
+		return v.Text, true
+	case *RichTextSpoiler:
+		return v.Text, true
+	case *RichTextDateTime:
+		return v.Text, true
+	case *RichTextTextMention:
+		return v.Text, true
+	case *RichTextSubscript:
+		return v.Text, true
+	case *RichTextSuperscript:
+		return v.Text, true
+	case *RichTextMarked:
+		return v.Text, true
+	case *RichTextCode:
+		return v.Text, true
+	case *RichTextURL:
+		return v.Text, true
+	case *RichTextEmailAddress:
+		return v.Text, true
+	case *RichTextPhoneNumber:
+		return v.Text, true
+	case *RichTextBankCardNumber:
+		return v.Text, true
+	case *RichTextMention:
+		return v.Text, true
+	case *RichTextHashtag:
+		return v.Text, true
+	case *RichTextCashtag:
+		return v.Text, true
+	case *RichTextBotCommand:
+		return v.Text, true
+	case *RichTextAnchorLink:
+		return v.Text, true
+	case *RichTextReference:
+		return v.Text, true
+	case *RichTextReferenceLink:
+		return v.Text, true
+	}
+	return RichText{}, false
+}
+
+////////////////////////////////
+// Rendering of RichTexts
+//
+
+// render given RichText
+func (r *richRenderer) text(t RichText) string {
+	switch v := normalizeRichTextValue(t.Value).(type) {
+	case nil:
+		return ""
+	case string:
+		return r.escape(v)
+	case []RichText:
+		var sb strings.Builder
+		for _, child := range v {
+			sb.WriteString(r.text(child))
+		}
+		return sb.String()
+	case *RichTextBold:
+		return r.wrap(v.Text, "<b>", "</b>", "**", "**")
+	case *RichTextItalic:
+		return r.wrap(v.Text, "<i>", "</i>", "*", "*")
+	case *RichTextUnderline:
+		return r.wrap(v.Text, "<u>", "</u>", "<u>", "</u>")
+	case *RichTextStrikethrough:
+		return r.wrap(v.Text, "<s>", "</s>", "<s>", "</s>")
+	case *RichTextSpoiler:
+		return r.wrap(v.Text, `<span class="spoiler">`, "</span>", "", "")
+	case *RichTextSubscript:
+		return r.wrap(v.Text, "<sub>", "</sub>", "<sub>", "</sub>")
+	case *RichTextSuperscript:
+		return r.wrap(v.Text, "<sup>", "</sup>", "<sup>", "</sup>")
+	case *RichTextMarked:
+		return r.wrap(v.Text, "<mark>", "</mark>", "<mark>", "</mark>")
+	case *RichTextCode:
+		return r.code(v.Text.PlainText())
+	case *RichTextMathematicalExpression:
+		return r.code(v.Expression)
+	case *RichTextCustomEmoji:
+		return r.escape(v.AlternativeText)
+	case *RichTextDateTime:
+		if r.format == RichFormatHTML {
+			datetime := time.Unix(int64(v.UnixTime), 0).UTC().Format(time.RFC3339)
+			return `<time datetime="` + datetime + `">` + r.text(v.Text) + "</time>"
+		}
+		return r.text(v.Text)
+	case *RichTextTextMention:
+		return r.link(v.Text, "tg://user?id="+strconv.FormatInt(v.User.ID, 10))
+	case *RichTextURL:
+		return r.link(v.Text, v.URL)
+	case *RichTextEmailAddress:
+		return r.link(v.Text, "mailto:"+v.EmailAddress)
+	case *RichTextPhoneNumber:
+		return r.link(v.Text, "tel:"+v.PhoneNumber)
+	case *RichTextMention:
+		return r.link(v.Text, "https://t.me/"+url.PathEscape(v.Username))
+	case *RichTextBankCardNumber:
+		return r.text(v.Text)
+	case *RichTextHashtag:
+		return r.text(v.Text)
+	case *RichTextCashtag:
+		return r.text(v.Text)
+	case *RichTextBotCommand:
+		return r.text(v.Text)
+	case *RichTextAnchor:
+		if r.format == RichFormatPlainText {
+			return ""
+		}
+		return `<a id="` + richAnchorID(v.Name) + `"></a>`
+	case *RichTextAnchorLink:
+		if !r.anchors[v.AnchorName] {
+			return r.text(v.Text) // unresolved
+		}
+		return r.link(v.Text, "#"+richAnchorID(v.AnchorName))
+	case *RichTextReference:
+		number := r.references[v.Name]
+		switch r.format {
+		case RichFormatHTML:
+			return `<span id="` + richReferenceID(v.Name) + `">` + r.text(v.Text) + "</span>"
+		case RichFormatMarkdown:
+			return `<a id="` + richReferenceID(v.Name) + `"></a>` + r.text(v.Text)
+		}
+		return fmt.Sprintf("[%d] %s", number, r.text(v.Text))
+	case *RichTextReferenceLink:
+		number, exists := r.references[v.ReferenceName]
+		if !exists {
+			return r.text(v.Text) // unresolved
+		}
+		if r.format == RichFormatPlainText {
+			return fmt.Sprintf("%s[%d]", r.text(v.Text), number)
+		}
+		return r.link(v.Text, "#"+richReferenceID(v.ReferenceName))
+	case map[string]any:
+		return r.unknownText(v)
+	}
+
+	return ""
+}
+
+// render a (future) RichText of unknown type
+func (r *richRenderer) unknownText(value map[string]any) string {
+	if r.options.Unknown != nil {
+		return r.options.Unknown(r.format, value)
+	}
+
+	if text, exists := value["text"]; exists {
+		if bytes, err := json.Marshal(text); err == nil {
+			var t RichText
+			if err := t.UnmarshalJSON(bytes); err == nil {
+				return r.text(t)
+			}
+		}
+	}
+	return ""
+}
+
+// escape given text for the format
+func (r *richRenderer) escape(text string) string {
+	switch r.format {
+	case RichFormatHTML:
+		return html.EscapeString(text)
+	case RichFormatMarkdown:
+		return commonMarkEscaper.Replace(text)
+	}
+	return text
+}
+
+// wrap given RichText with tags (for HTML) or markers (for Markdown)
+func (r *richRenderer) wrap(t RichText, openHTML, closeHTML, openMarkdown, closeMarkdown string) string {
+	text := r.text(t)
+	if text == "" {
+		return ""
+	}
+
+	switch r.format {
+	case RichFormatHTML:
+		return openHTML + text + closeHTML
+	case RichFormatMarkdown:
+		return openMarkdown + text + closeMarkdown
+	}
+	return text
+}
+
+// render an inline code
+func (r *richRenderer) code(code string) string {
+	switch r.format {
+	case RichFormatHTML:
+		return "<code>" + html.EscapeString(code) + "</code>"
+	case RichFormatMarkdown:
+		fence := strings.Repeat("`", longestRun(code, '`')+1)
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			code = " " + code + " "
+		}
+		return fence + code + fence
+	}
+	return code
+}
+
+// render given RichText with a link
+//
+// Links with unsafe schemes (eg. "javascript:") are dropped.
+func (r *richRenderer) link(t RichText, href string) string {
+	text := r.text(t)
+	if !isSafeRichURL(href) {
+		return text
+	}
+
+	switch r.format {
+	case RichFormatHTML:
+		return `<a href="` + html.EscapeString(href) + `">` + text + "</a>"
+	case RichFormatMarkdown:
+		return "[" + text + "](<" + commonMarkURLEscaper.Replace(href) + ">)"
+	}
+	if plain := t.PlainText(); plain != href && !strings.HasPrefix(href, "#") &&
+		!strings.HasPrefix(href, "tg:") && !strings.HasPrefix(href, "https://t.me/") {
+		return text + " (" + href + ")"
+	}
+	return text
+}
+
+var (
+	commonMarkEscaper    = newBackslashEscaper("\\`*_[]<>#|~")
+	commonMarkURLEscaper = newBackslashEscaper("<>\\")
+)
+
+// check if given url is safe to be linked
+func isSafeRichURL(href string) bool {
+	if strings.HasPrefix(href, "#") {
+		return true
+	}
+	if u, err := url.Parse(href); err == nil {
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https", "mailto", "tel", "tg":
+			return true
+		}
+	}
+	return false
+}
+
+// generate an id for given anchor name
+func richAnchorID(name string) string {
+	return sanitizeRichID(name)
+}
+
+// generate an id for given reference name
+func richReferenceID(name string) string {
+	return "ref-" + sanitizeRichID(name)
+}
+
+// sanitize given name for being used as an id
+func sanitizeRichID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// get the length of the longest run of given byte in given string
+func longestRun(str string, c byte) (longest int) {
+	run := 0
+	for i := 0; i < len(str); i++ {
+		if str[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+////////////////////////////////
+// Rendering of RichBlocks
+//
+
+// render given blocks
+func (r *richRenderer) blocks(blocks []RichBlock) string {
+	rendered := []string{}
+	for _, block := range blocks {
+		if b := r.block(block); b != "" {
+			rendered = append(rendered, b)
+		}
+	}
+
+	if r.format == RichFormatHTML {
+		return strings.Join(rendered, "\n")
+	}
+	return strings.Join(rendered, "\n\n")
+}
+
+// render given block
+func (r *richRenderer) block(block RichBlock) string {
+	switch block.Type {
+	case "paragraph":
+		return r.element("p", r.text(block.Text))
+	case "heading":
+		size := 1
+		if block.Size != nil {
+			size = min(max(*block.Size, 1), 6)
+		}
+		switch r.format {
+		case RichFormatHTML:
+			return r.element("h"+strconv.Itoa(size), r.text(block.Text))
+		case RichFormatMarkdown:
+			return strings.Repeat("#", size) + " " + strings.ReplaceAll(r.text(block.Text), "\n", " ")
+		}
+		return r.text(block.Text)
+	case "pre":
+		return r.preformatted(block.Text.PlainText(), block.Language)
+	case "mathematical_expression":
+		if block.Expression == nil {
+			return ""
+		}
+		return r.preformatted(*block.Expression, new("math"))
+	case "footer":
+		return r.element("footer", r.text(block.Text))
+	case "thinking":
+		return r.element("aside", r.text(block.Text))
+	case "divider":
+		switch r.format {
+		case RichFormatHTML:
+			return "<hr>"
+		case RichFormatMarkdown:
+			return "---"
+		}
+		return "----"
+	case "anchor":
+		if block.Name == nil || r.format == RichFormatPlainText {
+			return ""
+		}
+		return `<a id="` + richAnchorID(*block.Name) + `"></a>`
+	case "list":
+		return r.list(block.Items)
+	case "blockquote":
+		return r.quote(r.blocks(block.Blocks), block.Credit, "")
+	case "pullquote":
+		return r.quote(r.element("p", r.text(block.Text)), block.Credit, "pullquote")
+	case "collage", "slideshow":
+		return r.figure(block.Type, r.blocks(block.Blocks), block.Caption)
+	case "table":
+		return r.table(block)
+	case "details":
+		return r.details(block)
+	case "map", "animation", "audio", "photo", "video", "voice_note":
+		return r.figure(block.Type, r.media(block), block.Caption)
+	}
+
+	// (future) blocks of unknown types
+	rendered := []string{}
+	if text := r.text(block.Text); text != "" {
+		rendered = append(rendered, r.element("p", text))
+	}
+	if blocks := r.blocks(block.Blocks); blocks != "" {
+		rendered = append(rendered, blocks)
+	}
+	return strings.Join(rendered, r.blockSeparator())
+}
+
+// get the separator of blocks for the format
+func (r *richRenderer) blockSeparator() string {
+	if r.format == RichFormatHTML {
+		return "\n"
+	}
+	return "\n\n"
+}
+
+// render an HTML element with given (rendered) content, or the content itself for other formats
+func (r *richRenderer) element(tag, content string) string {
+	if content == "" {
+		return ""
+	}
+	if r.format == RichFormatHTML {
+		return "<" + tag + ">" + content + "</" + tag + ">"
+	}
+	return content
+}
+
+// render a preformatted block
+func (r *richRenderer) preformatted(code string, language *string) string {
+	switch r.format {
+	case RichFormatHTML:
+		if language != nil && *language != "" {
+			return `<pre><code class="language-` + html.EscapeString(*language) + `">` + html.EscapeString(code) + "</code></pre>"
+		}
+		return "<pre>" + html.EscapeString(code) + "</pre>"
+	case RichFormatMarkdown:
+		fence := strings.Repeat("`", max(longestRun(code, '`')+1, 3))
+		lang := ""
+		if language != nil {
+			lang = strings.ReplaceAll(*language, "`", "")
+		}
+		return fence + lang + "\n" + code + "\n" + fence
+	}
+	return code
+}
+
+// render a list
+func (r *richRenderer) list(items []RichBlockListItem) string {
+	ordered := false
+	for _, item := range items {
+		if item.Value != nil || item.Type != nil {
+			ordered = true
+		}
+	}
+
+	switch r.format {
+	case RichFormatHTML:
+		var sb strings.Builder
+		if ordered {
+			sb.WriteString("<ol>")
+		} else {
+			sb.WriteString("<ul>")
+		}
+		for _, item := range items {
+			sb.WriteString("<li")
+			if item.Value != nil {
+				sb.WriteString(` value="` + strconv.Itoa(*item.Value) + `"`)
+			}
+			sb.WriteString(">")
+			if item.HasCheckbox != nil && *item.HasCheckbox {
+				if item.IsChecked != nil && *item.IsChecked {
+					sb.WriteString(`<input type="checkbox" disabled checked> `)
+				} else {
+					sb.WriteString(`<input type="checkbox" disabled> `)
+				}
+			}
+			sb.WriteString(r.blocks(item.Blocks))
+			sb.WriteString("</li>")
+		}
+		if ordered {
+			sb.WriteString("</ol>")
+		} else {
+			sb.WriteString("</ul>")
+		}
+		return sb.String()
+	}
+
+	rendered := []string{}
+	for i, item := range items {
+		var marker string
+		if ordered {
+			number := i + 1
+			if item.Value != nil {
+				number = *item.Value
+			}
+			marker = strconv.Itoa(number) + ". "
+		} else if r.format == RichFormatMarkdown {
+			marker = "- "
+		} else if item.Label != "" {
+			marker = item.Label + " "
+		} else {
+			marker = "• "
+		}
+		if item.HasCheckbox != nil && *item.HasCheckbox {
+			if item.IsChecked != nil && *item.IsChecked {
+				marker += "[x] "
+			} else {
+				marker += "[ ] "
+			}
+		}
+
+		// NOTE: item blocks are separated with single newlines for compactness
+		blocks := []string{}
+		for _, block := range item.Blocks {
+			if b := r.block(block); b != "" {
+				blocks = append(blocks, b)
+			}
+		}
+		content := strings.Join(blocks, "\n")
+		rendered = append(rendered, marker+indentLines(content, strings.Repeat(" ", len([]rune(marker)))))
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// render a quote with given (rendered) content and credit
+func (r *richRenderer) quote(content string, credit RichText, class string) string {
+	creditText := r.text(credit)
+
+	switch r.format {
+	case RichFormatHTML:
+		attrs := ""
+		if class != "" {
+			attrs = ` class="` + class + `"`
+		}
+		if creditText != "" {
+			content += "<cite>" + creditText + "</cite>"
+		}
+		return "<blockquote" + attrs + ">" + content + "</blockquote>"
+	case RichFormatMarkdown:
+		if creditText != "" {
+			content += "\n\n— " + creditText
+		}
+		return prefixLines(content, "> ", ">")
+	}
+
+	if creditText != "" {
+		content += "\n— " + creditText
+	}
+	return content
+}
+
+// render a figure with given (rendered) content and caption
+func (r *richRenderer) figure(class, content string, caption *RichBlockCaption) string {
+	captionText := ""
+	if caption != nil {
+		captionText = r.text(caption.Text)
+		if credit := r.text(caption.Credit); credit != "" {
+			if captionText != "" {
+				captionText += " "
+			}
+			captionText += "— " + credit
+		}
+	}
+
+	if r.format == RichFormatHTML {
+		figure := `<figure class="` + class + `">` + content
+		if captionText != "" {
+			figure += "<figcaption>" + captionText + "</figcaption>"
+		}
+		return figure + "</figure>"
+	}
+
+	if captionText != "" {
+		if content != "" {
+			content += r.blockSeparator()
+		}
+		content += captionText
+	}
+	return content
+}
+
+// render a media block
+func (r *richRenderer) media(block RichBlock) string {
+	if r.options.Media != nil {
+		return r.options.Media(r.format, block)
+	}
+
+	placeholder := "[" + strings.ReplaceAll(block.Type, "_", " ") + "]"
+	if block.Type == "map" && block.Location != nil {
+		placeholder = fmt.Sprintf("[map: %f, %f]", block.Location.Latitude, block.Location.Longitude)
+	}
+	return r.escape(placeholder)
+}
+
+// render a table
+func (r *richRenderer) table(block RichBlock) string {
+	caption := ""
+	if block.Caption != nil {
+		caption = r.text(block.Caption.Text)
+	}
+
+	switch r.format {
+	case RichFormatHTML:
+		var sb strings.Builder
+		sb.WriteString("<table>")
+		if caption != "" {
+			sb.WriteString("<caption>" + caption + "</caption>")
+		}
+		for _, row := range block.Cells {
+			sb.WriteString("<tr>")
+			for _, cell := range row {
+				tag := "td"
+				if cell.IsHeader != nil && *cell.IsHeader {
+					tag = "th"
+				}
+				sb.WriteString("<" + tag)
+				if cell.Colspan != nil && *cell.Colspan > 1 {
+					sb.WriteString(` colspan="` + strconv.Itoa(*cell.Colspan) + `"`)
+				}
+				if cell.Rowspan != nil && *cell.Rowspan > 1 {
+					sb.WriteString(` rowspan="` + strconv.Itoa(*cell.Rowspan) + `"`)
+				}
+				switch cell.Align {
+				case "left", "center", "right":
+					sb.WriteString(` align="` + cell.Align + `"`)
+				}
+				sb.WriteString(">" + r.text(cell.Text) + "</" + tag + ">")
+			}
+			sb.WriteString("</tr>")
+		}
+		sb.WriteString("</table>")
+		return sb.String()
+	case RichFormatMarkdown:
+		if len(block.Cells) == 0 {
+			return caption
+		}
+		columns := 0
+		for _, row := range block.Cells {
+			columns = max(columns, len(row))
+		}
+		lines := []string{}
+		for i, row := range block.Cells {
+			cells := make([]string, columns)
+			for j, cell := range row {
+				cells[j] = strings.ReplaceAll(r.text(cell.Text), "\n", " ")
+			}
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+			if i == 0 {
+				lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+			}
+		}
+		table := strings.Join(lines, "\n")
+		if caption != "" {
+			table = caption + "\n\n" + table
+		}
+		return table
+	}
+
+	lines := []string{}
+	if caption != "" {
+		lines = append(lines, caption)
+	}
+	for _, row := range block.Cells {
+		cells := []string{}
+		for _, cell := range row {
+			cells = append(cells, strings.ReplaceAll(r.text(cell.Text), "\n", " "))
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// render a details block
+func (r *richRenderer) details(block RichBlock) string {
+	summary := r.text(block.Summary)
+	content := r.blocks(block.Blocks)
+
+	switch r.format {
+	case RichFormatHTML:
+		open := ""
+		if block.IsOpen != nil && *block.IsOpen {
+			open = " open"
+		}
+		return "<details" + open + "><summary>" + summary + "</summary>" + content + "</details>"
+	case RichFormatMarkdown:
+		return "<details>\n<summary>" + summary + "</summary>\n\n" + content + "\n\n</details>"
+	}
+
+	if content == "" {
+		return summary
+	}
+	return summary + "\n" + content
+}
+
+// indent all lines of given text except the first one
+func indentLines(text, indent string) string {
+	return strings.ReplaceAll(text, "\n", "\n"+indent)
+}
+
+// prefix all lines of given text (with a different prefix for empty lines)
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
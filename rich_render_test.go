@@ -0,0 +1,123 @@
+// rich_render_test.go
+//
+// offline tests of rendering received RichMessages
+
+package telegrambot
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+const testRichMessageJSON = `{"blocks":[
+{"type":"heading","size":2,"text":["Hello ",{"type":"bold","text":"<world>"}]},
+{"type":"anchor","name":"intro"},
+{"type":"paragraph","text":[
+	"See ",{"type":"url","text":"docs","url":"https://example.com/?a=1&b=2"},
+	", ",{"type":"url","text":"evil","url":"javascript:alert(1)"},
+	", ",{"type":"anchor_link","text":"intro","anchor_name":"intro"},
+	", ",{"type":"anchor_link","text":"missing","anchor_name":"nowhere"},
+	" and ",{"type":"reference_link","text":"note","reference_name":"n1"},
+	" ",{"type":"sparkles","text":"future"}
+]},
+{"type":"list","items":[
+	{"label":"•","blocks":[{"type":"paragraph","text":"done"}],"has_checkbox":true,"is_checked":true},
+	{"label":"•","blocks":[{"type":"paragraph","text":"todo"}],"has_checkbox":true}
+]},
+{"type":"pre","language":"go","text":"fmt.Println(1 < 2)"},
+{"type":"table","caption":"scores","cells":[
+	[{"text":"name","is_header":true,"align":"left","valign":"top"},{"text":"score","is_header":true,"align":"right","valign":"top"}],
+	[{"text":"a|b","align":"left","valign":"top"},{"text":"1","align":"right","valign":"top"}]
+]},
+{"type":"blockquote","blocks":[{"type":"paragraph","text":"quoted"}],"credit":"someone"},
+{"type":"details","summary":"more","blocks":[{"type":"paragraph","text":"hidden"}]},
+{"type":"photo","photo":[{"file_id":"f","file_unique_id":"u","width":1,"height":1}],"caption":{"text":"a photo","credit":"me"}},
+{"type":"footer","text":[{"type":"reference","text":"the note","name":"n1"}]}
+]}`
+
+// received RichMessages should be rendered to HTML, Markdown, and plain text.
+func TestRenderRichMessage(t *testing.T) {
+	slog.Info("testing rendering of RichMessage...")
+
+	var message RichMessage
+	if err := json.Unmarshal([]byte(testRichMessageJSON), &message); err != nil {
+		t.Fatalf("failed to unmarshal RichMessage: %s", err)
+	}
+
+	// HTML
+	rendered := message.HTML(nil)
+	for _, expected := range []string{
+		"<h2>Hello <b>&lt;world&gt;</b></h2>",
+		`<a id="intro"></a>`,
+		`<a href="https://example.com/?a=1&amp;b=2">docs</a>`,
+		", evil, ",
+		`<a href="#intro">intro</a>`,
+		", missing and ",
+		`<a href="#ref-n1">note</a> future`,
+		`<li><input type="checkbox" disabled checked> <p>done</p></li>`,
+		`<pre><code class="language-go">fmt.Println(1 &lt; 2)</code></pre>`,
+		`<table><caption>scores</caption><tr><th align="left">name</th>`,
+		"<blockquote><p>quoted</p><cite>someone</cite></blockquote>",
+		"<details><summary>more</summary><p>hidden</p></details>",
+		`<figure class="photo">[photo]<figcaption>a photo — me</figcaption></figure>`,
+		`<footer><span id="ref-n1">the note</span></footer>`,
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("expected %q in rendered html:\n%s", expected, rendered)
+		}
+	}
+	if strings.Contains(rendered, "javascript:") {
+		t.Errorf("expected unsafe links to be dropped:\n%s", rendered)
+	}
+
+	// Markdown
+	rendered = message.Markdown(&RichRenderOptions{
+		Media: func(format RichFormat, block RichBlock) string {
+			return "![" + block.Type + "](" + block.Photo[0].FileID + ")"
+		},
+	})
+	for _, expected := range []string{
+		"## Hello **\\<world\\>**",
+		"[docs](<https://example.com/?a=1&b=2>)",
+		"[intro](<#intro>)",
+		"- [x] done\n- [ ] todo",
+		"```go\nfmt.Println(1 < 2)\n```",
+		"| name | score |\n| --- | --- |\n| a\\|b | 1 |",
+		"> quoted\n>\n> — someone",
+		"![photo](f)\n\na photo — me",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("expected %q in rendered markdown:\n%s", expected, rendered)
+		}
+	}
+
+	// plain text
+	rendered = message.PlainText(nil)
+	for _, expected := range []string{
+		"Hello <world>",
+		"See docs (https://example.com/?a=1&b=2), evil, intro, missing and note[1] future",
+		"• [x] done\n• [ ] todo",
+		"scores\nname\tscore\na|b\t1",
+		"quoted\n— someone",
+		"[1] the note",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("expected %q in rendered plain text:\n%s", expected, rendered)
+		}
+	}
+}
+
+// RichTexts built with non-pointer values should be rendered too.
+func TestRenderRichTextValues(t *testing.T) {
+	slog.Info("testing rendering of RichText values...")
+
+	text := NewRichTextWithRichTexts(
+		NewRichTextWithText("a "),
+		RichText{Value: RichTextBold{Type: "bold", Text: NewRichTextWithText("b")}},
+	)
+	if plain := text.PlainText(); plain != "a b" {
+		t.Errorf("expected %q, got %q", "a b", plain)
+	}
+}
@@ -114,7 +114,7 @@ func updateHandler(b *bot.Bot, update bot.Update, err error) {
 				"Han Solo",
 			)
 
-			results := []any{
+			results := []bot.InlineQueryResultVariant{
 				article1,
 				article2,
 			}
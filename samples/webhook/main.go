@@ -112,7 +112,7 @@ func webhookHandler(b *bot.Bot, webhook bot.Update, err error) {
 				"I know.",
 				"Han Solo")
 
-			results := []any{
+			results := []bot.InlineQueryResultVariant{
 				article1,
 				article2,
 			}
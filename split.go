@@ -0,0 +1,322 @@
+package telegrambot
+
+// Splitting of long texts (with entities) into chunks, in UTF-16 code units
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxMessageTextLength  = 4096 // max length of a message text in UTF-16 code units
+	maxCaptionLength      = 1024 // max length of a media caption in UTF-16 code units
+	maxEntitiesPerMessage = 100  // max number of entities in a message
+)
+
+// textChunk is a chunk of a split text, with its entities
+type textChunk struct {
+	Text     string
+	Entities []MessageEntity
+}
+
+// utf16Index is an index of a string's runes, in bytes and UTF-16 code units
+type utf16Index struct {
+	text  string
+	bytes []int // byte offsets of rune boundaries (len == number of runes + 1)
+	units []int // UTF-16 offsets of rune boundaries (len == number of runes + 1)
+}
+
+// build an index of given text
+func newUTF16Index(text string) utf16Index {
+	idx := utf16Index{
+		text:  text,
+		bytes: []int{},
+		units: []int{},
+	}
+	unit := 0
+	for i, r := range text {
+		idx.bytes = append(idx.bytes, i)
+		idx.units = append(idx.units, unit)
+		if r >= 0x10000 {
+			unit += 2
+		} else {
+			unit++
+		}
+	}
+	idx.bytes = append(idx.bytes, len(text))
+	idx.units = append(idx.units, unit)
+
+	return idx
+}
+
+// number of runes
+func (idx utf16Index) runes() int {
+	return len(idx.bytes) - 1
+}
+
+// substring between given rune indices
+func (idx utf16Index) slice(from, to int) string {
+	return idx.text[idx.bytes[from]:idx.bytes[to]]
+}
+
+// split given text with entities into chunks of at most `limit` UTF-16 code units.
+//
+// Texts are split at paragraph, line, or word boundaries if possible,
+// and entities which straddle a boundary are cut and reopened in the next chunk.
+//
+// `pre` and `code` entities are kept intact unless they are longer than the limit.
+func splitText(text string, entities []MessageEntity, limit int) (chunks []textChunk) {
+	idx := newUTF16Index(text)
+	total := idx.runes()
+
+	for start := 0; start < total; {
+		// skip separators at the start of a chunk
+		for start < total && isChunkSeparator(idx.slice(start, start+1)) {
+			start++
+		}
+		if start >= total {
+			break
+		}
+
+		// find the farthest end within the limit
+		end := start
+		for end < total && idx.units[end+1]-idx.units[start] <= limit {
+			end++
+		}
+		if end == start { // (limit is smaller than a rune)
+			end = start + 1
+		}
+
+		next := end
+		if end < total {
+			end, next = findChunkBoundary(idx, start, end)
+			end, next = keepCodeIntact(idx, entities, start, end, next, limit)
+		}
+
+		// trim trailing separators
+		for end > start && isChunkSeparator(idx.slice(end-1, end)) {
+			end--
+		}
+
+		chunks = append(chunks, textChunk{
+			Text:     idx.slice(start, end),
+			Entities: clipEntities(entities, idx.units[start], idx.units[end]),
+		})
+		start = next
+	}
+
+	return chunks
+}
+
+// find the best boundary for a chunk from `start` to (at most) `end`,
+// and return the end of current chunk and the start of next one (in rune indices)
+func findChunkBoundary(idx utf16Index, start, end int) (chunkEnd, nextStart int) {
+	candidate := idx.slice(start, end)
+	minimum := idx.bytes[start] + len(candidate)/4 // do not make too short chunks
+
+	for _, separator := range []string{"\n\n", "\n", " "} {
+		if at := strings.LastIndex(candidate, separator); at >= 0 && idx.bytes[start]+at > minimum {
+			boundary := byteToRuneIndex(idx, idx.bytes[start]+at)
+			return boundary, boundary + len([]rune(separator))
+		}
+	}
+
+	return end, end
+}
+
+// move the boundary of a chunk out of a `pre` or `code` entity which would be cut by it,
+// to the end of the entity if it fits in the chunk, or to the start of the entity otherwise
+func keepCodeIntact(idx utf16Index, entities []MessageEntity, start, end, next, limit int) (chunkEnd, nextStart int) {
+	for _, entity := range entities {
+		if entity.Type != MessageEntityTypePre && entity.Type != MessageEntityTypeCode {
+			continue
+		}
+
+		from, to := entity.Offset, entity.Offset+entity.Length
+		if from >= idx.units[end] || to <= idx.units[end] || entity.Length > limit {
+			continue // not cut, or cannot be kept intact anyway
+		}
+
+		if to-idx.units[start] <= limit {
+			boundary := unitToRuneIndex(idx, to)
+			return boundary, boundary
+		} else if from > idx.units[start] {
+			boundary := unitToRuneIndex(idx, from)
+			return boundary, boundary
+		}
+	}
+
+	return end, next
+}
+
+// convert an offset in UTF-16 code units to a rune index
+func unitToRuneIndex(idx utf16Index, offset int) int {
+	lo, hi := 0, len(idx.units)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if idx.units[mid] < offset {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// convert a byte offset to a rune index
+func byteToRuneIndex(idx utf16Index, offset int) int {
+	lo, hi := 0, len(idx.bytes)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if idx.bytes[mid] < offset {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// check if given string is a separator between chunks
+func isChunkSeparator(s string) bool {
+	return s == "\n" || s == " "
+}
+
+// clip given entities to the range [from, to) (in UTF-16 code units), with offsets relative to `from`
+func clipEntities(entities []MessageEntity, from, to int) (clipped []MessageEntity) {
+	for _, entity := range entities {
+		start := max(entity.Offset, from)
+		end := min(entity.Offset+entity.Length, to)
+		if end <= start {
+			continue
+		}
+
+		entity.Offset = start - from
+		entity.Length = end - start
+		clipped = append(clipped, entity)
+	}
+	return clipped
+}
+
+////////////////////////////////
+// Sending long texts
+//
+
+// SendLongMessage sends a text which may be longer than the limit of a message (4096 characters),
+// split into multiple messages at paragraph, line, or word boundaries.
+//
+// Entities in `options` are cut and reopened across the split messages,
+// and each following message is sent as a reply to the previous one.
+// Reply markup is attached to the last message only.
+//
+// A text with a parse mode cannot be split; build it with entities (eg. with TextBuilder) instead.
+//
+// All sent messages are returned, even when it fails in the middle.
+//
+// https://core.telegram.org/bots/api#sendmessage
+func (b *Bot) SendLongMessage(
+	ctx context.Context,
+	chatID ChatID,
+	text string,
+	options OptionsSendMessage,
+) (messages []Message, err error) {
+	if utf16Length(text) <= maxMessageTextLength {
+		res, err := b.SendMessage(ctx, chatID, text, options)
+		if err != nil {
+			return nil, err
+		}
+		return []Message{*res.Result}, nil
+	}
+
+	if parseMode, exists := options["parse_mode"]; exists {
+		return nil, fmt.Errorf("cannot split a text with parse mode: %v", parseMode)
+	}
+
+	entities, _ := options["entities"].([]MessageEntity)
+	chunks := splitText(text, entities, maxMessageTextLength)
+	for i, chunk := range chunks {
+		chunkOptions := OptionsSendMessage(cloneOptions(options))
+		delete(chunkOptions, "entities")
+		if len(chunk.Entities) > 0 {
+			chunkOptions = chunkOptions.SetEntities(chunk.Entities)
+		}
+		if i > 0 {
+			delete(chunkOptions, "message_effect_id")
+			chunkOptions = chunkOptions.SetReplyParameters(NewReplyParameters(messages[i-1].MessageID))
+		}
+		if i < len(chunks)-1 {
+			delete(chunkOptions, "reply_markup")
+		}
+
+		res, err := b.SendMessage(ctx, chatID, chunk.Text, chunkOptions)
+		if err != nil {
+			return messages, fmt.Errorf("failed to send message (chunk #%d of %d): %w", i+1, len(chunks), err)
+		}
+		messages = append(messages, *res.Result)
+	}
+
+	return messages, nil
+}
+
+// SendWithLongCaption sends a media message with `send`, moving its caption
+// into follow-up message(s) when it is longer than the limit of a caption (1024 characters).
+//
+// `options` is one of the options of media messages (eg. OptionsSendPhoto),
+// and `send` is a function which sends the media message with given options.
+// Follow-up messages are sent with SendLongMessage as replies to the media message.
+//
+//	messages, err := SendWithLongCaption(ctx, bot, chatID, OptionsSendPhoto{}.SetCaption(caption),
+//		func(options OptionsSendPhoto) (APIResponse[Message], error) {
+//			return bot.SendPhoto(ctx, chatID, photo, options)
+//		})
+func SendWithLongCaption[O ~map[string]any](
+	ctx context.Context,
+	b *Bot,
+	chatID ChatID,
+	options O,
+	send func(options O) (APIResponse[Message], error),
+) (messages []Message, err error) {
+	caption, _ := options["caption"].(string)
+	if utf16Length(caption) <= maxCaptionLength {
+		res, err := send(options)
+		if err != nil {
+			return nil, err
+		}
+		return []Message{*res.Result}, nil
+	}
+
+	// send the media without its caption
+	mediaOptions := O(cloneOptions(options))
+	for _, key := range []string{"caption", "parse_mode", "caption_entities", "show_caption_above_media"} {
+		delete(mediaOptions, key)
+	}
+	res, err := send(mediaOptions)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, *res.Result)
+
+	// and the caption as follow-up message(s)
+	textOptions := OptionsSendMessage{}
+	for _, key := range []string{"business_connection_id", "message_thread_id", "direct_messages_topic_id", "disable_notification", "protect_content", "allow_paid_broadcast"} {
+		if value, exists := options[key]; exists {
+			textOptions[key] = value
+		}
+	}
+	if parseMode, exists := options["parse_mode"]; exists {
+		textOptions["parse_mode"] = parseMode
+	}
+	if entities, exists := options["caption_entities"]; exists {
+		textOptions["entities"] = entities
+	}
+	textOptions = textOptions.SetReplyParameters(NewReplyParameters(res.Result.MessageID))
+
+	followUps, err := b.SendLongMessage(ctx, chatID, caption, textOptions)
+	messages = append(messages, followUps...)
+	if err != nil {
+		return messages, fmt.Errorf("failed to send the caption as a follow-up message: %w", err)
+	}
+
+	return messages, nil
+}
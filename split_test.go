@@ -0,0 +1,129 @@
+// split_test.go
+//
+// offline tests of splitting long texts
+
+package telegrambot
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// texts should be split at boundaries in UTF-16 code units, with entities cut and reopened.
+func TestSplitText(t *testing.T) {
+	slog.Info("testing splitting of texts...")
+
+	// short text
+	if chunks := splitText("short", nil, 10); len(chunks) != 1 || chunks[0].Text != "short" {
+		t.Errorf("unexpected chunks: %+v", chunks)
+	}
+
+	// split at paragraph boundaries first
+	text := "first paragraph\n\nsecond line\nthird"
+	chunks := splitText(text, nil, 30)
+	if len(chunks) != 2 || chunks[0].Text != "first paragraph" || chunks[1].Text != "second line\nthird" {
+		t.Errorf("unexpected chunks: %+v", chunks)
+	}
+
+	// emojis are 2 UTF-16 code units long
+	text = strings.Repeat("😀", 6)
+	chunks = splitText(text, []MessageEntity{NewMessageEntity(MessageEntityTypeBold, 2, 8)}, 5)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got: %+v", chunks)
+	}
+	for i, chunk := range chunks {
+		if chunk.Text != "😀😀" {
+			t.Errorf("unexpected chunk #%d: %q", i, chunk.Text)
+		}
+	}
+	if e := chunks[0].Entities; len(e) != 1 || e[0].Offset != 2 || e[0].Length != 2 {
+		t.Errorf("unexpected entities in chunk #0: %+v", e)
+	}
+	if e := chunks[1].Entities; len(e) != 1 || e[0].Offset != 0 || e[0].Length != 4 {
+		t.Errorf("unexpected entities in chunk #1: %+v", e)
+	}
+	if e := chunks[2].Entities; len(e) != 1 || e[0].Offset != 0 || e[0].Length != 2 {
+		t.Errorf("unexpected entities in chunk #2: %+v", e)
+	}
+}
+
+// code blocks should not be cut unless they are longer than the limit.
+func TestSplitTextKeepingCode(t *testing.T) {
+	slog.Info("testing splitting of texts with code blocks...")
+
+	text := "intro text\n" + "fmt.Println(1)\nfmt.Println(2)"
+	code := NewMessageEntity(MessageEntityTypePre, 11, 29)
+
+	// cut before the code block
+	chunks := splitText(text, []MessageEntity{code}, 35)
+	if len(chunks) != 2 || chunks[0].Text != "intro text" || chunks[1].Text != "fmt.Println(1)\nfmt.Println(2)" {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+	if e := chunks[1].Entities; len(e) != 1 || e[0].Offset != 0 || e[0].Length != 29 {
+		t.Errorf("expected an intact code block, got: %+v", e)
+	}
+
+	// code blocks longer than the limit are cut
+	chunks = splitText(text, []MessageEntity{code}, 20)
+	if len(chunks) != 3 || chunks[1].Text != "fmt.Println(1)" || chunks[1].Entities[0].Type != MessageEntityTypePre {
+		t.Errorf("unexpected chunks: %+v", chunks)
+	}
+}
+
+// long messages should be sent as chained replies, and long captions as follow-up messages.
+func TestSendLongMessage(t *testing.T) {
+	slog.Info("testing sending of long messages...")
+
+	server := newTestRecordingServer()
+	defer server.Close()
+
+	client := NewClient(testToken)
+	client.SetBaseURL(server.URL)
+
+	text := strings.Repeat("paragraph\n\n", 700)
+	messages, err := client.SendLongMessage(context.TODO(), 12345, text, OptionsSendMessage{}.
+		SetEntities([]MessageEntity{NewMessageEntity(MessageEntityTypeBold, 4000, 1000)}).
+		SetReplyMarkup(NewInlineKeyboardMarkup([][]InlineKeyboardButton{{NewInlineKeyboardButton("ok").SetCallbackData("ok")}})))
+	if err != nil {
+		t.Fatalf("failed to send long message: %s", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	calls := server.callsTo("sendMessage")
+	if calls[0]["reply_markup"] != "" || calls[1]["reply_markup"] == "" {
+		t.Errorf("expected reply markup on the last message only: %+v", calls)
+	}
+	if !strings.Contains(calls[1]["reply_parameters"], `"message_id":1`) {
+		t.Errorf("expected the second message to reply to the first one: %+v", calls[1])
+	}
+	if !strings.Contains(calls[0]["entities"], `"type":"bold"`) || !strings.Contains(calls[1]["entities"], `"offset":0`) {
+		t.Errorf("expected entities to be reopened: %+v", calls)
+	}
+
+	// text with parse mode cannot be split
+	if _, err := client.SendLongMessage(context.TODO(), 12345, text, OptionsSendMessage{}.SetParseMode(ParseModeHTML)); err == nil {
+		t.Errorf("expected an error for a long text with parse mode")
+	}
+
+	// long caption
+	caption := strings.Repeat("caption ", 200)
+	messages, err = SendWithLongCaption(context.TODO(), client, 12345, OptionsSendPhoto{}.SetCaption(caption).SetParseMode(ParseModeHTML),
+		func(options OptionsSendPhoto) (APIResponse[Message], error) {
+			return client.SendPhoto(context.TODO(), 12345, NewInputFileFromURL("https://example.com/photo.jpg"), options)
+		})
+	if err != nil {
+		t.Fatalf("failed to send photo with long caption: %s", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	if photo := server.callsTo("sendPhoto")[0]; photo["caption"] != "" || photo["parse_mode"] != "" {
+		t.Errorf("expected the caption to be moved: %+v", photo)
+	}
+	if followUp := server.callsTo("sendMessage")[2]; followUp["text"] != caption || followUp["parse_mode"] != "HTML" {
+		t.Errorf("unexpected follow-up message: %+v", followUp)
+	}
+}
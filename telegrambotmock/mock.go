@@ -0,0 +1,72 @@
+// Package telegrambotmock provides a mock of the Bot API methods for unit tests,
+// which records calls and returns programmable results without any HTTP server.
+//
+//	mock := &telegrambotmock.Mock{}
+//	mock.SendMessageFunc = func(ctx context.Context, chatID telegrambot.ChatID, text string, options telegrambot.OptionsSendMessage) (telegrambot.APIResponse[telegrambot.Message], error) {
+//		return telegrambot.APIResponse[telegrambot.Message]{OK: true, Result: &telegrambot.Message{MessageID: 1}}, nil
+//	}
+//
+//	notifier := NewNotifier(mock) // (depends on telegrambot.MessagingAPI, satisfied by both *telegrambot.Bot and *Mock)
+//	notifier.Notify(ctx, "hello")
+//
+//	calls := mock.CallsOf("SendMessage")
+//
+// Methods of Mock are generated from methods.go with `go generate`, so they are kept in sync with *telegrambot.Bot.
+package telegrambotmock
+
+import (
+	"slices"
+	"sync"
+)
+
+// Call is a recorded call of a method.
+type Call struct {
+	Method string
+	Args   []any // arguments of the call, except `ctx`
+}
+
+// recorder records calls of methods.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record a call
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+}
+
+// Calls returns all recorded calls in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.calls)
+}
+
+// CallsOf returns recorded calls of given method (eg. "SendMessage") in order.
+func (r *recorder) CallsOf(method string) (calls []Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all recorded calls.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
@@ -0,0 +1,2263 @@
+// Code generated by mockgen from methods.go; DO NOT EDIT.
+
+package telegrambotmock
+
+import (
+	"context"
+
+	telegrambot "github.com/meinside/telegram-bot-go"
+)
+
+// Mock is a mock of telegrambot.API which records calls, and returns results of programmable functions.
+//
+// Methods whose functions are not set return zero results (with `OK` set to true for API responses).
+type Mock struct {
+	recorder
+
+	// UpdatesAPI
+	GetUpdatesFunc     func(ctx context.Context, options telegrambot.OptionsGetUpdates) (result telegrambot.APIResponse[[]telegrambot.Update], err error)
+	SetWebhookFunc     func(ctx context.Context, host string, port int, options telegrambot.OptionsSetWebhook) (result telegrambot.APIResponse[bool], err error)
+	DeleteWebhookFunc  func(ctx context.Context, dropPendingUpdates bool) (result telegrambot.APIResponse[bool], err error)
+	GetWebhookInfoFunc func(ctx context.Context) (result telegrambot.APIResponse[telegrambot.WebhookInfo], err error)
+
+	// BusinessAPI
+	ReadBusinessMessageFunc               func(ctx context.Context, businessConnectionID string, chatID int64, messageID int64) (result telegrambot.APIResponse[bool], err error)
+	DeleteBusinessMessagesFunc            func(ctx context.Context, businessConnectionID string, messageIDs []int64) (result telegrambot.APIResponse[bool], err error)
+	SetBusinessAccountNameFunc            func(ctx context.Context, businessConnectionID string, firstName string, options telegrambot.OptionsSetBusinessAccountName) (result telegrambot.APIResponse[bool], err error)
+	SetBusinessAccountUsernameFunc        func(ctx context.Context, businessConnectionID string, options telegrambot.OptionsSetBusinessAccountUsername) (result telegrambot.APIResponse[bool], err error)
+	SetBusinessAccountBioFunc             func(ctx context.Context, businessConnectionID string, options telegrambot.OptionsSetBusinessAccountBio) (result telegrambot.APIResponse[bool], err error)
+	SetBusinessAccountProfilePhotoFunc    func(ctx context.Context, businessConnectionID string, photo telegrambot.InputProfilePhoto, options telegrambot.OptionsSetBusinessAccountProfilePhoto) (result telegrambot.APIResponse[bool], err error)
+	RemoveBusinessAccountProfilePhotoFunc func(ctx context.Context, businessConnectionID string, options telegrambot.OptionsRemoveBusinessAccountProfilePhoto) (result telegrambot.APIResponse[bool], err error)
+	SetBusinessAccountGiftSettingsFunc    func(ctx context.Context, businessConnectionID string, showGiftButton bool, acceptedGiftTypes telegrambot.AcceptedGiftTypes) (result telegrambot.APIResponse[bool], err error)
+	GetBusinessAccountStarBalanceFunc     func(ctx context.Context, businessConnectionID string) (result telegrambot.APIResponse[telegrambot.StarAmount], err error)
+	TransferBusinessAccountStarsFunc      func(ctx context.Context, businessConnectionID string, starCount int) (result telegrambot.APIResponse[bool], err error)
+	GetBusinessAccountGiftsFunc           func(ctx context.Context, businessConnectionID string, options telegrambot.OptionsGetBusinessAccountGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error)
+	GetBusinessConnectionFunc             func(ctx context.Context, businessConnectionID string) (result telegrambot.APIResponse[telegrambot.BusinessConnection], err error)
+
+	// ForumTopicsAPI
+	CreateForumTopicFunc                  func(ctx context.Context, chatID telegrambot.ChatID, name string, options telegrambot.OptionsCreateForumTopic) (result telegrambot.APIResponse[telegrambot.ForumTopic], err error)
+	EditForumTopicFunc                    func(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64, options telegrambot.OptionsEditForumTopic) (result telegrambot.APIResponse[bool], err error)
+	CloseForumTopicFunc                   func(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error)
+	ReopenForumTopicFunc                  func(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error)
+	DeleteForumTopicFunc                  func(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error)
+	UnpinAllForumTopicMessagesFunc        func(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error)
+	EditGeneralForumTopicFunc             func(ctx context.Context, chatID telegrambot.ChatID, name string) (result telegrambot.APIResponse[bool], err error)
+	CloseGeneralForumTopicFunc            func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	ReopenGeneralForumTopicFunc           func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	HideGeneralForumTopicFunc             func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	UnhideGeneralForumTopicFunc           func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	UnpinAllGeneralForumTopicMessagesFunc func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	GetForumTopicIconStickersFunc         func(ctx context.Context) (result telegrambot.APIResponse[[]telegrambot.Sticker], err error)
+
+	// StoriesAPI
+	PostStoryFunc   func(ctx context.Context, businessConnectionID string, content telegrambot.InputStoryContent, activePeriod int, options telegrambot.OptionsPostStory) (result telegrambot.APIResponse[telegrambot.Story], err error)
+	RepostStoryFunc func(ctx context.Context, businessConnectionID string, fromChatID int64, fromStoryID int64, activePeriod int, options telegrambot.OptionsRepostStory) (result telegrambot.APIResponse[telegrambot.Story], err error)
+	EditStoryFunc   func(ctx context.Context, businessConnectionID string, storyID int64, content telegrambot.InputStoryContent, options telegrambot.OptionsEditStory) (result telegrambot.APIResponse[telegrambot.Story], err error)
+	DeleteStoryFunc func(ctx context.Context, businessConnectionID string, storyID int64) (result telegrambot.APIResponse[bool], err error)
+
+	// GiftsAPI
+	GetAvailableGiftsFunc       func(ctx context.Context) (result telegrambot.APIResponse[telegrambot.Gifts], err error)
+	SendGiftFunc                func(ctx context.Context, giftID string, options telegrambot.OptionsSendGift) (result telegrambot.APIResponse[bool], err error)
+	GiftPremiumSubscriptionFunc func(ctx context.Context, userID int64, monthCount int, starCount int, options telegrambot.OptionsGiftPremiumSubscription) (result telegrambot.APIResponse[bool], err error)
+	GetUserGiftsFunc            func(ctx context.Context, userID int64, options telegrambot.OptionsGetUserGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error)
+	GetChatGiftsFunc            func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsGetChatGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error)
+	ConvertGiftToStarsFunc      func(ctx context.Context, businessConnectionID string, ownedGiftID string) (result telegrambot.APIResponse[bool], err error)
+	UpgradeGiftFunc             func(ctx context.Context, businessConnectionID string, ownedGiftID string, options telegrambot.OptionsUpgradeGift) (result telegrambot.APIResponse[bool], err error)
+	TransferGiftFunc            func(ctx context.Context, businessConnectionID string, ownedGiftID string, newOwnerChatID int64, options telegrambot.OptionsTransferGift) (result telegrambot.APIResponse[bool], err error)
+
+	// PaymentsAPI
+	SendPaidMediaFunc            func(ctx context.Context, chatID telegrambot.ChatID, starCount int, media []telegrambot.InputPaidMedia, options telegrambot.OptionsSendPaidMedia) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendInvoiceFunc              func(ctx context.Context, chatID int64, title string, description string, payload string, providerToken string, currency string, prices []telegrambot.LabeledPrice, options telegrambot.OptionsSendInvoice) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	CreateInvoiceLinkFunc        func(ctx context.Context, title string, description string, payload string, currency string, prices []telegrambot.LabeledPrice, options telegrambot.OptionsCreateInvoiceLink) (result telegrambot.APIResponse[string], err error)
+	AnswerShippingQueryFunc      func(ctx context.Context, shippingQueryID string, ok bool, shippingOptions []telegrambot.ShippingOption, errorMessage *string) (result telegrambot.APIResponse[bool], err error)
+	AnswerPreCheckoutQueryFunc   func(ctx context.Context, preCheckoutQueryID string, ok bool, errorMessage *string) (result telegrambot.APIResponse[bool], err error)
+	GetMyStarBalanceFunc         func(ctx context.Context) (result telegrambot.APIResponse[telegrambot.StarAmount], err error)
+	GetStarTransactionsFunc      func(ctx context.Context, options telegrambot.OptionsGetStarTransactions) (result telegrambot.APIResponse[telegrambot.StarTransactions], err error)
+	RefundStarPaymentFunc        func(ctx context.Context, userID int64, telegramPaymentChargeID string) (result telegrambot.APIResponse[bool], err error)
+	EditUserStarSubscriptionFunc func(ctx context.Context, userID int64, telegramPaymentChargeID string, isCanceled bool) (result telegrambot.APIResponse[bool], err error)
+
+	// GamesAPI
+	SendGameFunc          func(ctx context.Context, chatID telegrambot.ChatID, gameShortName string, options telegrambot.OptionsSendGame) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SetGameScoreFunc      func(ctx context.Context, userID int64, score int, options telegrambot.OptionsSetGameScore) (result telegrambot.APIResponseMessageOrBool, err error)
+	GetGameHighScoresFunc func(ctx context.Context, userID int64, options telegrambot.OptionsGetGameHighScores) (result telegrambot.APIResponse[[]telegrambot.GameHighScore], err error)
+
+	// BotSettingsAPI
+	GetMeFunc                           func(ctx context.Context) (result telegrambot.APIResponse[telegrambot.User], err error)
+	LogOutFunc                          func(ctx context.Context) (result telegrambot.APIResponse[bool], err error)
+	CloseFunc                           func(ctx context.Context) (result telegrambot.APIResponse[bool], err error)
+	GetMyCommandsFunc                   func(ctx context.Context, options telegrambot.OptionsGetMyCommands) (result telegrambot.APIResponse[[]telegrambot.BotCommand], err error)
+	SetMyNameFunc                       func(ctx context.Context, name string, options telegrambot.OptionsSetMyName) (result telegrambot.APIResponse[bool], err error)
+	GetMyNameFunc                       func(ctx context.Context, options telegrambot.OptionsGetMyName) (result telegrambot.APIResponse[telegrambot.BotName], err error)
+	SetMyDescriptionFunc                func(ctx context.Context, options telegrambot.OptionsSetMyDescription) (result telegrambot.APIResponse[bool], err error)
+	GetMyDescriptionFunc                func(ctx context.Context, options telegrambot.OptionsGetMyDescription) (result telegrambot.APIResponse[telegrambot.BotDescription], err error)
+	SetMyShortDescriptionFunc           func(ctx context.Context, options telegrambot.OptionsSetMyShortDescription) (result telegrambot.APIResponse[bool], err error)
+	GetMyShortDescriptionFunc           func(ctx context.Context, options telegrambot.OptionsGetMyShortDescription) (result telegrambot.APIResponse[telegrambot.BotShortDescription], err error)
+	GetManagedBotTokenFunc              func(ctx context.Context, userID int64) (result telegrambot.APIResponse[string], err error)
+	ReplaceManagedBotTokenFunc          func(ctx context.Context, userID int64) (result telegrambot.APIResponse[string], err error)
+	GetManagedBotAccessSettingsFunc     func(ctx context.Context, userID int64) (result telegrambot.APIResponse[telegrambot.BotAccessSettings], err error)
+	SetManagedBotAccessSettingsFunc     func(ctx context.Context, userID int64, isAccessRestricted bool, options telegrambot.OptionsSetManagedBotAccessSettings) (result telegrambot.APIResponse[bool], err error)
+	SetMyCommandsFunc                   func(ctx context.Context, commands []telegrambot.BotCommand, options telegrambot.OptionsSetMyCommands) (result telegrambot.APIResponse[bool], err error)
+	DeleteMyCommandsFunc                func(ctx context.Context, options telegrambot.OptionsDeleteMyCommands) (result telegrambot.APIResponse[bool], err error)
+	SetMyProfilePhotoFunc               func(ctx context.Context, photo telegrambot.InputProfilePhoto) (result telegrambot.APIResponse[bool], err error)
+	RemoveMyProfilePhotoFunc            func(ctx context.Context) (result telegrambot.APIResponse[bool], err error)
+	SetChatMenuButtonFunc               func(ctx context.Context, options telegrambot.OptionsSetChatMenuButton) (result telegrambot.APIResponse[bool], err error)
+	GetChatMenuButtonFunc               func(ctx context.Context, options telegrambot.OptionsGetChatMenuButton) (result telegrambot.APIResponse[telegrambot.MenuButton], err error)
+	SetMyDefaultAdministratorRightsFunc func(ctx context.Context, options telegrambot.OptionsSetMyDefaultAdministratorRights) (result telegrambot.APIResponse[bool], err error)
+	GetMyDefaultAdministratorRightsFunc func(ctx context.Context, options telegrambot.OptionsGetMyDefaultAdministratorRights) (result telegrambot.APIResponse[bool], err error)
+
+	// ChatAdminAPI
+	BanChatMemberFunc                    func(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsBanChatMember) (result telegrambot.APIResponse[bool], err error)
+	LeaveChatFunc                        func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	UnbanChatMemberFunc                  func(ctx context.Context, chatID telegrambot.ChatID, userID int64, onlyIfBanned bool) (result telegrambot.APIResponse[bool], err error)
+	RestrictChatMemberFunc               func(ctx context.Context, chatID telegrambot.ChatID, userID int64, permissions telegrambot.ChatPermissions, options telegrambot.OptionsRestrictChatMember) (result telegrambot.APIResponse[bool], err error)
+	PromoteChatMemberFunc                func(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsPromoteChatMember) (result telegrambot.APIResponse[bool], err error)
+	SetChatAdministratorCustomTitleFunc  func(ctx context.Context, chatID telegrambot.ChatID, userID int64, customTitle string) (result telegrambot.APIResponse[bool], err error)
+	SetChatMemberTagFunc                 func(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsSetChatMemberTag) (result telegrambot.APIResponse[bool], err error)
+	BanChatSenderChatFunc                func(ctx context.Context, chatID telegrambot.ChatID, senderChatID int64) (result telegrambot.APIResponse[bool], err error)
+	UnbanChatSenderChatFunc              func(ctx context.Context, chatID telegrambot.ChatID, senderChatID int64) (result telegrambot.APIResponse[bool], err error)
+	SetChatPermissionsFunc               func(ctx context.Context, chatID telegrambot.ChatID, permissions telegrambot.ChatPermissions, options telegrambot.OptionsSetChatPermissions) (result telegrambot.APIResponse[bool], err error)
+	ExportChatInviteLinkFunc             func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[string], err error)
+	CreateChatInviteLinkFunc             func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsCreateChatInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error)
+	EditChatInviteLinkFunc               func(ctx context.Context, chatID telegrambot.ChatID, inviteLink string, options telegrambot.OptionsCreateChatInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error)
+	CreateChatSubscriptionInviteLinkFunc func(ctx context.Context, chatID telegrambot.ChatID, subscriptionPeriod int, subscriptionPrice int, options telegrambot.OptionsCreateChatSubscriptionInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error)
+	EditChatSubscriptionInviteLinkFunc   func(ctx context.Context, chatID telegrambot.ChatID, inviteLink string, options telegrambot.OptionsEditChatSubscriptionInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error)
+	RevokeChatInviteLinkFunc             func(ctx context.Context, chatID telegrambot.ChatID, inviteLink string) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error)
+	ApproveChatJoinRequestFunc           func(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[bool], err error)
+	DeclineChatJoinRequestFunc           func(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[bool], err error)
+	AnswerChatJoinRequestQueryFunc       func(ctx context.Context, chatJoinRequestQueryID string, res string) (result telegrambot.APIResponse[bool], err error)
+	SendChatJoinRequestWebAppFunc        func(ctx context.Context, chatJoinRequestQueryID string, webAppURL string) (result telegrambot.APIResponse[bool], err error)
+	SetChatPhotoFunc                     func(ctx context.Context, chatID telegrambot.ChatID, photo telegrambot.InputFile) (result telegrambot.APIResponse[bool], err error)
+	DeleteChatPhotoFunc                  func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	SetChatTitleFunc                     func(ctx context.Context, chatID telegrambot.ChatID, title string) (result telegrambot.APIResponse[bool], err error)
+	SetChatDescriptionFunc               func(ctx context.Context, chatID telegrambot.ChatID, description string) (result telegrambot.APIResponse[bool], err error)
+	PinChatMessageFunc                   func(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsPinChatMessage) (result telegrambot.APIResponse[bool], err error)
+	UnpinChatMessageFunc                 func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsUnpinChatMessage) (result telegrambot.APIResponse[bool], err error)
+	UnpinAllChatMessagesFunc             func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	GetChatFunc                          func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[telegrambot.ChatFullInfo], err error)
+	GetChatAdministratorsFunc            func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsGetChatAdministrators) (result telegrambot.APIResponse[[]telegrambot.ChatMember], err error)
+	GetChatMemberCountFunc               func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[int], err error)
+	GetChatMemberFunc                    func(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[telegrambot.ChatMember], err error)
+	SetChatStickerSetFunc                func(ctx context.Context, chatID telegrambot.ChatID, stickerSetName string) (result telegrambot.APIResponse[bool], err error)
+	DeleteChatStickerSetFunc             func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+
+	// StickersAPI
+	SendStickerFunc                       func(ctx context.Context, chatID telegrambot.ChatID, sticker telegrambot.InputFile, options telegrambot.OptionsSendSticker) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	GetStickerSetFunc                     func(ctx context.Context, name string) (result telegrambot.APIResponse[telegrambot.StickerSet], err error)
+	GetCustomEmojiStickersFunc            func(ctx context.Context, customEmojiIDs []string) (result telegrambot.APIResponse[[]telegrambot.Sticker], err error)
+	UploadStickerFileFunc                 func(ctx context.Context, userID int64, sticker telegrambot.InputFile, stickerFormat telegrambot.StickerFormat) (result telegrambot.APIResponse[telegrambot.File], err error)
+	CreateNewStickerSetFunc               func(ctx context.Context, userID int64, name string, title string, stickers []telegrambot.InputSticker, options telegrambot.OptionsCreateNewStickerSet) (result telegrambot.APIResponse[bool], err error)
+	AddStickerToSetFunc                   func(ctx context.Context, userID int64, name string, sticker telegrambot.InputSticker, options telegrambot.OptionsAddStickerToSet) (result telegrambot.APIResponse[bool], err error)
+	SetStickerPositionInSetFunc           func(ctx context.Context, sticker string, position int) (result telegrambot.APIResponse[bool], err error)
+	DeleteStickerFromSetFunc              func(ctx context.Context, sticker string) (result telegrambot.APIResponse[bool], err error)
+	SetStickerSetThumbnailFunc            func(ctx context.Context, name string, userID int64, format telegrambot.StickerFormat, options telegrambot.OptionsSetStickerSetThumbnail) (result telegrambot.APIResponse[bool], err error)
+	SetCustomEmojiStickerSetThumbnailFunc func(ctx context.Context, name string, options telegrambot.OptionsSetCustomEmojiStickerSetThumbnail) (result telegrambot.APIResponse[bool], err error)
+	SetStickerSetTitleFunc                func(ctx context.Context, name string, title string) (result telegrambot.APIResponse[bool], err error)
+	DeleteStickerSetFunc                  func(ctx context.Context, name string) (result telegrambot.APIResponse[bool], err error)
+	ReplaceStickerInSetFunc               func(ctx context.Context, userID string, name string, oldSticker string, sticker telegrambot.InputSticker) (result telegrambot.APIResponse[bool], err error)
+	SetStickerEmojiListFunc               func(ctx context.Context, sticker string, emojiList []string) (result telegrambot.APIResponse[bool], err error)
+	SetStickerKeywordsFunc                func(ctx context.Context, sticker string, keywords []string) (result telegrambot.APIResponse[bool], err error)
+	SetStickerMaskPositionFunc            func(ctx context.Context, sticker string, options telegrambot.OptionsSetStickerMaskPosition) (result telegrambot.APIResponse[bool], err error)
+
+	// UsersAPI
+	VerifyUserFunc                  func(ctx context.Context, userID int64, options telegrambot.OptionsVerifyUser) (result telegrambot.APIResponse[bool], err error)
+	VerifyChatFunc                  func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsVerifyChat) (result telegrambot.APIResponse[bool], err error)
+	RemoveUserVerificationFunc      func(ctx context.Context, userID int64) (result telegrambot.APIResponse[bool], err error)
+	RemoveChatVerificationFunc      func(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error)
+	GetUserProfilePhotosFunc        func(ctx context.Context, userID int64, options telegrambot.OptionsGetUserProfilePhotos) (result telegrambot.APIResponse[telegrambot.UserProfilePhotos], err error)
+	GetUserProfileAudiosFunc        func(ctx context.Context, userID int64, options telegrambot.OptionsGetUserProfileAudios) (result telegrambot.APIResponse[telegrambot.UserProfileAudios], err error)
+	SetUserEmojiStatusFunc          func(ctx context.Context, userID int64, options telegrambot.OptionsSetUserEmojiStatus) (result telegrambot.APIResponse[bool], err error)
+	GetUserPersonalChatMessagesFunc func(ctx context.Context, userID int64, limit int) (result telegrambot.APIResponse[[]telegrambot.Message], err error)
+	GetUserChatBoostsFunc           func(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[telegrambot.UserChatBoosts], err error)
+
+	// InlineAPI
+	AnswerGuestQueryFunc           func(ctx context.Context, guestQueryID string, queryResult telegrambot.InlineQueryResult) (result telegrambot.APIResponse[telegrambot.SentGuestMessage], err error)
+	AnswerInlineQueryFunc          func(ctx context.Context, inlineQueryID string, results []telegrambot.InlineQueryResultVariant, options telegrambot.OptionsAnswerInlineQuery) (result telegrambot.APIResponse[bool], err error)
+	AnswerWebAppQueryFunc          func(ctx context.Context, webAppQueryID string, res telegrambot.InlineQueryResult) (result telegrambot.APIResponse[telegrambot.SentWebAppMessage], err error)
+	SavePreparedInlineMessageFunc  func(ctx context.Context, userID int64, result telegrambot.InlineQueryResult, options telegrambot.OptionsSavePreparedInlineMessage) (res telegrambot.APIResponse[telegrambot.PreparedInlineMessage], err error)
+	SavePreparedKeyboardButtonFunc func(ctx context.Context, userID int64, button telegrambot.KeyboardButton) (res telegrambot.APIResponse[telegrambot.PreparedKeyboardButton], err error)
+
+	// MessagingAPI
+	SendMessageFunc                     func(ctx context.Context, chatID telegrambot.ChatID, text string, options telegrambot.OptionsSendMessage) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	ForwardMessageFunc                  func(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsForwardMessage) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	ForwardMessagesFunc                 func(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageIDs []int64, options telegrambot.OptionsForwardMessages) (result telegrambot.APIResponse[[]telegrambot.MessageID], err error)
+	CopyMessageFunc                     func(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsCopyMessage) (result telegrambot.APIResponse[telegrambot.MessageID], err error)
+	CopyMessagesFunc                    func(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageIDs []int64, options telegrambot.OptionsCopyMessages) (result telegrambot.APIResponse[[]telegrambot.MessageID], err error)
+	SendPhotoFunc                       func(ctx context.Context, chatID telegrambot.ChatID, photo telegrambot.InputFile, options telegrambot.OptionsSendPhoto) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendLivePhotoFunc                   func(ctx context.Context, chatID telegrambot.ChatID, livePhoto telegrambot.InputFile, photo telegrambot.InputFile, options telegrambot.OptionsSendLivePhoto) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendAudioFunc                       func(ctx context.Context, chatID telegrambot.ChatID, audio telegrambot.InputFile, options telegrambot.OptionsSendAudio) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendDocumentFunc                    func(ctx context.Context, chatID telegrambot.ChatID, document telegrambot.InputFile, options telegrambot.OptionsSendDocument) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendRichMessageFunc                 func(ctx context.Context, chatID telegrambot.ChatID, richMessage telegrambot.InputRichMessage, options telegrambot.OptionsSendRichMessage) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendRichMessageDraftFunc            func(ctx context.Context, chatID telegrambot.ChatID, draftID int64, richMessage telegrambot.InputRichMessage, options telegrambot.OptionsSendRichMessageDraft) (result telegrambot.APIResponse[bool], err error)
+	SendVideoFunc                       func(ctx context.Context, chatID telegrambot.ChatID, video telegrambot.InputFile, options telegrambot.OptionsSendVideo) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendAnimationFunc                   func(ctx context.Context, chatID telegrambot.ChatID, animation telegrambot.InputFile, options telegrambot.OptionsSendAnimation) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendVoiceFunc                       func(ctx context.Context, chatID telegrambot.ChatID, voice telegrambot.InputFile, options telegrambot.OptionsSendVoice) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendVideoNoteFunc                   func(ctx context.Context, chatID telegrambot.ChatID, videoNote telegrambot.InputFile, options telegrambot.OptionsSendVideoNote) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendMediaGroupFunc                  func(ctx context.Context, chatID telegrambot.ChatID, media []telegrambot.InputMedia, options telegrambot.OptionsSendMediaGroup) (result telegrambot.APIResponse[[]telegrambot.Message], err error)
+	SendLocationFunc                    func(ctx context.Context, chatID telegrambot.ChatID, latitude float32, longitude float32, options telegrambot.OptionsSendLocation) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendVenueFunc                       func(ctx context.Context, chatID telegrambot.ChatID, latitude float32, longitude float32, title string, address string, options telegrambot.OptionsSendVenue) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendContactFunc                     func(ctx context.Context, chatID telegrambot.ChatID, phoneNumber string, firstName string, options telegrambot.OptionsSendContact) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendPollFunc                        func(ctx context.Context, chatID telegrambot.ChatID, question string, pollOptions []telegrambot.InputPollOption, options telegrambot.OptionsSendPoll) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	StopPollFunc                        func(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsStopPoll) (result telegrambot.APIResponse[telegrambot.Poll], err error)
+	ApproveSuggestedPostFunc            func(ctx context.Context, chatID int64, messageID int64, options telegrambot.OptionsApproveSuggestedPost) (result telegrambot.APIResponse[bool], err error)
+	DeclineSuggestedPostFunc            func(ctx context.Context, chatID int64, messageID int64, options telegrambot.OptionsDeclineSuggestedPost) (result telegrambot.APIResponse[bool], err error)
+	SendChecklistFunc                   func(ctx context.Context, businessConnectionID string, chatID telegrambot.ChatID, checklist telegrambot.InputChecklist, options telegrambot.OptionsSendChecklist) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendDiceFunc                        func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsSendDice) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	SendMessageDraftFunc                func(ctx context.Context, chatID telegrambot.ChatID, draftID int64, text string, options telegrambot.OptionsSendMessageDraft) (result telegrambot.APIResponse[bool], err error)
+	SendChatActionFunc                  func(ctx context.Context, chatID telegrambot.ChatID, action telegrambot.ChatAction, options telegrambot.OptionsSendChatAction) (result telegrambot.APIResponse[bool], err error)
+	SetMessageReactionFunc              func(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsSetMessageReaction) (result telegrambot.APIResponse[bool], err error)
+	GetFileFunc                         func(ctx context.Context, fileID string) (result telegrambot.APIResponse[telegrambot.File], err error)
+	GetFileURLFunc                      func(file telegrambot.File) (r0 string)
+	AnswerCallbackQueryFunc             func(ctx context.Context, callbackQueryID string, options telegrambot.OptionsAnswerCallbackQuery) (result telegrambot.APIResponse[bool], err error)
+	EditMessageTextFunc                 func(ctx context.Context, text string, options telegrambot.OptionsEditMessageText) (result telegrambot.APIResponseMessageOrBool, err error)
+	EditMessageCaptionFunc              func(ctx context.Context, options telegrambot.OptionsEditMessageCaption) (result telegrambot.APIResponseMessageOrBool, err error)
+	EditMessageMediaFunc                func(ctx context.Context, media telegrambot.InputMedia, options telegrambot.OptionsEditMessageMedia) (result telegrambot.APIResponseMessageOrBool, err error)
+	EditMessageLiveLocationFunc         func(ctx context.Context, latitude float32, longitude float32, options telegrambot.OptionsEditMessageLiveLocation) (result telegrambot.APIResponseMessageOrBool, err error)
+	StopMessageLiveLocationFunc         func(ctx context.Context, options telegrambot.OptionsStopMessageLiveLocation) (result telegrambot.APIResponseMessageOrBool, err error)
+	EditMessageChecklistFunc            func(ctx context.Context, businessConnectionID string, chatID int64, messageID int64, checklist telegrambot.InputChecklist, options telegrambot.OptionsEditMessageChecklist) (result telegrambot.APIResponse[telegrambot.Message], err error)
+	EditMessageReplyMarkupFunc          func(ctx context.Context, options telegrambot.OptionsEditMessageReplyMarkup) (result telegrambot.APIResponseMessageOrBool, err error)
+	DeleteMessageFunc                   func(ctx context.Context, chatID telegrambot.ChatID, messageID int64) (result telegrambot.APIResponse[bool], err error)
+	DeleteMessagesFunc                  func(ctx context.Context, chatID telegrambot.ChatID, messageIDs []int64) (result telegrambot.APIResponse[bool], err error)
+	DeleteMessageReactionFunc           func(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsDeleteMessageReaction) (result telegrambot.APIResponse[bool], err error)
+	DeleteAllMessageReactionsFunc       func(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsDeleteAllMessageReactions) (result telegrambot.APIResponse[bool], err error)
+	EditEphemeralMessageTextFunc        func(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, text string, options telegrambot.OptionsEditEphemeralMessageText) (result telegrambot.APIResponse[bool], err error)
+	EditEphemeralMessageMediaFunc       func(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageMedia, media telegrambot.InputMedia) (result telegrambot.APIResponse[bool], err error)
+	EditEphemeralMessageCaptionFunc     func(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageCaption) (result telegrambot.APIResponse[bool], err error)
+	EditEphemeralMessageReplyMarkupFunc func(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageReplyMarkup) (result telegrambot.APIResponse[bool], err error)
+	DeleteEphemeralMessageFunc          func(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64) (result telegrambot.APIResponse[bool], err error)
+}
+
+var _ telegrambot.API = (*Mock)(nil)
+
+// GetUpdates records the call, and returns the result of GetUpdatesFunc.
+func (m *Mock) GetUpdates(ctx context.Context, options telegrambot.OptionsGetUpdates) (result telegrambot.APIResponse[[]telegrambot.Update], err error) {
+	m.record("GetUpdates", options)
+
+	if m.GetUpdatesFunc != nil {
+		return m.GetUpdatesFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetWebhook records the call, and returns the result of SetWebhookFunc.
+func (m *Mock) SetWebhook(ctx context.Context, host string, port int, options telegrambot.OptionsSetWebhook) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetWebhook", host, port, options)
+
+	if m.SetWebhookFunc != nil {
+		return m.SetWebhookFunc(ctx, host, port, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteWebhook records the call, and returns the result of DeleteWebhookFunc.
+func (m *Mock) DeleteWebhook(ctx context.Context, dropPendingUpdates bool) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteWebhook", dropPendingUpdates)
+
+	if m.DeleteWebhookFunc != nil {
+		return m.DeleteWebhookFunc(ctx, dropPendingUpdates)
+	}
+	result.OK = true
+	return
+}
+
+// GetWebhookInfo records the call, and returns the result of GetWebhookInfoFunc.
+func (m *Mock) GetWebhookInfo(ctx context.Context) (result telegrambot.APIResponse[telegrambot.WebhookInfo], err error) {
+	m.record("GetWebhookInfo")
+
+	if m.GetWebhookInfoFunc != nil {
+		return m.GetWebhookInfoFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// ReadBusinessMessage records the call, and returns the result of ReadBusinessMessageFunc.
+func (m *Mock) ReadBusinessMessage(ctx context.Context, businessConnectionID string, chatID int64, messageID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ReadBusinessMessage", businessConnectionID, chatID, messageID)
+
+	if m.ReadBusinessMessageFunc != nil {
+		return m.ReadBusinessMessageFunc(ctx, businessConnectionID, chatID, messageID)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteBusinessMessages records the call, and returns the result of DeleteBusinessMessagesFunc.
+func (m *Mock) DeleteBusinessMessages(ctx context.Context, businessConnectionID string, messageIDs []int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteBusinessMessages", businessConnectionID, messageIDs)
+
+	if m.DeleteBusinessMessagesFunc != nil {
+		return m.DeleteBusinessMessagesFunc(ctx, businessConnectionID, messageIDs)
+	}
+	result.OK = true
+	return
+}
+
+// SetBusinessAccountName records the call, and returns the result of SetBusinessAccountNameFunc.
+func (m *Mock) SetBusinessAccountName(ctx context.Context, businessConnectionID string, firstName string, options telegrambot.OptionsSetBusinessAccountName) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetBusinessAccountName", businessConnectionID, firstName, options)
+
+	if m.SetBusinessAccountNameFunc != nil {
+		return m.SetBusinessAccountNameFunc(ctx, businessConnectionID, firstName, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetBusinessAccountUsername records the call, and returns the result of SetBusinessAccountUsernameFunc.
+func (m *Mock) SetBusinessAccountUsername(ctx context.Context, businessConnectionID string, options telegrambot.OptionsSetBusinessAccountUsername) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetBusinessAccountUsername", businessConnectionID, options)
+
+	if m.SetBusinessAccountUsernameFunc != nil {
+		return m.SetBusinessAccountUsernameFunc(ctx, businessConnectionID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetBusinessAccountBio records the call, and returns the result of SetBusinessAccountBioFunc.
+func (m *Mock) SetBusinessAccountBio(ctx context.Context, businessConnectionID string, options telegrambot.OptionsSetBusinessAccountBio) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetBusinessAccountBio", businessConnectionID, options)
+
+	if m.SetBusinessAccountBioFunc != nil {
+		return m.SetBusinessAccountBioFunc(ctx, businessConnectionID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetBusinessAccountProfilePhoto records the call, and returns the result of SetBusinessAccountProfilePhotoFunc.
+func (m *Mock) SetBusinessAccountProfilePhoto(ctx context.Context, businessConnectionID string, photo telegrambot.InputProfilePhoto, options telegrambot.OptionsSetBusinessAccountProfilePhoto) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetBusinessAccountProfilePhoto", businessConnectionID, photo, options)
+
+	if m.SetBusinessAccountProfilePhotoFunc != nil {
+		return m.SetBusinessAccountProfilePhotoFunc(ctx, businessConnectionID, photo, options)
+	}
+	result.OK = true
+	return
+}
+
+// RemoveBusinessAccountProfilePhoto records the call, and returns the result of RemoveBusinessAccountProfilePhotoFunc.
+func (m *Mock) RemoveBusinessAccountProfilePhoto(ctx context.Context, businessConnectionID string, options telegrambot.OptionsRemoveBusinessAccountProfilePhoto) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RemoveBusinessAccountProfilePhoto", businessConnectionID, options)
+
+	if m.RemoveBusinessAccountProfilePhotoFunc != nil {
+		return m.RemoveBusinessAccountProfilePhotoFunc(ctx, businessConnectionID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetBusinessAccountGiftSettings records the call, and returns the result of SetBusinessAccountGiftSettingsFunc.
+func (m *Mock) SetBusinessAccountGiftSettings(ctx context.Context, businessConnectionID string, showGiftButton bool, acceptedGiftTypes telegrambot.AcceptedGiftTypes) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetBusinessAccountGiftSettings", businessConnectionID, showGiftButton, acceptedGiftTypes)
+
+	if m.SetBusinessAccountGiftSettingsFunc != nil {
+		return m.SetBusinessAccountGiftSettingsFunc(ctx, businessConnectionID, showGiftButton, acceptedGiftTypes)
+	}
+	result.OK = true
+	return
+}
+
+// GetBusinessAccountStarBalance records the call, and returns the result of GetBusinessAccountStarBalanceFunc.
+func (m *Mock) GetBusinessAccountStarBalance(ctx context.Context, businessConnectionID string) (result telegrambot.APIResponse[telegrambot.StarAmount], err error) {
+	m.record("GetBusinessAccountStarBalance", businessConnectionID)
+
+	if m.GetBusinessAccountStarBalanceFunc != nil {
+		return m.GetBusinessAccountStarBalanceFunc(ctx, businessConnectionID)
+	}
+	result.OK = true
+	return
+}
+
+// TransferBusinessAccountStars records the call, and returns the result of TransferBusinessAccountStarsFunc.
+func (m *Mock) TransferBusinessAccountStars(ctx context.Context, businessConnectionID string, starCount int) (result telegrambot.APIResponse[bool], err error) {
+	m.record("TransferBusinessAccountStars", businessConnectionID, starCount)
+
+	if m.TransferBusinessAccountStarsFunc != nil {
+		return m.TransferBusinessAccountStarsFunc(ctx, businessConnectionID, starCount)
+	}
+	result.OK = true
+	return
+}
+
+// GetBusinessAccountGifts records the call, and returns the result of GetBusinessAccountGiftsFunc.
+func (m *Mock) GetBusinessAccountGifts(ctx context.Context, businessConnectionID string, options telegrambot.OptionsGetBusinessAccountGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error) {
+	m.record("GetBusinessAccountGifts", businessConnectionID, options)
+
+	if m.GetBusinessAccountGiftsFunc != nil {
+		return m.GetBusinessAccountGiftsFunc(ctx, businessConnectionID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetBusinessConnection records the call, and returns the result of GetBusinessConnectionFunc.
+func (m *Mock) GetBusinessConnection(ctx context.Context, businessConnectionID string) (result telegrambot.APIResponse[telegrambot.BusinessConnection], err error) {
+	m.record("GetBusinessConnection", businessConnectionID)
+
+	if m.GetBusinessConnectionFunc != nil {
+		return m.GetBusinessConnectionFunc(ctx, businessConnectionID)
+	}
+	result.OK = true
+	return
+}
+
+// CreateForumTopic records the call, and returns the result of CreateForumTopicFunc.
+func (m *Mock) CreateForumTopic(ctx context.Context, chatID telegrambot.ChatID, name string, options telegrambot.OptionsCreateForumTopic) (result telegrambot.APIResponse[telegrambot.ForumTopic], err error) {
+	m.record("CreateForumTopic", chatID, name, options)
+
+	if m.CreateForumTopicFunc != nil {
+		return m.CreateForumTopicFunc(ctx, chatID, name, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditForumTopic records the call, and returns the result of EditForumTopicFunc.
+func (m *Mock) EditForumTopic(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64, options telegrambot.OptionsEditForumTopic) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditForumTopic", chatID, messageThreadID, options)
+
+	if m.EditForumTopicFunc != nil {
+		return m.EditForumTopicFunc(ctx, chatID, messageThreadID, options)
+	}
+	result.OK = true
+	return
+}
+
+// CloseForumTopic records the call, and returns the result of CloseForumTopicFunc.
+func (m *Mock) CloseForumTopic(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("CloseForumTopic", chatID, messageThreadID)
+
+	if m.CloseForumTopicFunc != nil {
+		return m.CloseForumTopicFunc(ctx, chatID, messageThreadID)
+	}
+	result.OK = true
+	return
+}
+
+// ReopenForumTopic records the call, and returns the result of ReopenForumTopicFunc.
+func (m *Mock) ReopenForumTopic(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ReopenForumTopic", chatID, messageThreadID)
+
+	if m.ReopenForumTopicFunc != nil {
+		return m.ReopenForumTopicFunc(ctx, chatID, messageThreadID)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteForumTopic records the call, and returns the result of DeleteForumTopicFunc.
+func (m *Mock) DeleteForumTopic(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteForumTopic", chatID, messageThreadID)
+
+	if m.DeleteForumTopicFunc != nil {
+		return m.DeleteForumTopicFunc(ctx, chatID, messageThreadID)
+	}
+	result.OK = true
+	return
+}
+
+// UnpinAllForumTopicMessages records the call, and returns the result of UnpinAllForumTopicMessagesFunc.
+func (m *Mock) UnpinAllForumTopicMessages(ctx context.Context, chatID telegrambot.ChatID, messageThreadID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnpinAllForumTopicMessages", chatID, messageThreadID)
+
+	if m.UnpinAllForumTopicMessagesFunc != nil {
+		return m.UnpinAllForumTopicMessagesFunc(ctx, chatID, messageThreadID)
+	}
+	result.OK = true
+	return
+}
+
+// EditGeneralForumTopic records the call, and returns the result of EditGeneralForumTopicFunc.
+func (m *Mock) EditGeneralForumTopic(ctx context.Context, chatID telegrambot.ChatID, name string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditGeneralForumTopic", chatID, name)
+
+	if m.EditGeneralForumTopicFunc != nil {
+		return m.EditGeneralForumTopicFunc(ctx, chatID, name)
+	}
+	result.OK = true
+	return
+}
+
+// CloseGeneralForumTopic records the call, and returns the result of CloseGeneralForumTopicFunc.
+func (m *Mock) CloseGeneralForumTopic(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("CloseGeneralForumTopic", chatID)
+
+	if m.CloseGeneralForumTopicFunc != nil {
+		return m.CloseGeneralForumTopicFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// ReopenGeneralForumTopic records the call, and returns the result of ReopenGeneralForumTopicFunc.
+func (m *Mock) ReopenGeneralForumTopic(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ReopenGeneralForumTopic", chatID)
+
+	if m.ReopenGeneralForumTopicFunc != nil {
+		return m.ReopenGeneralForumTopicFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// HideGeneralForumTopic records the call, and returns the result of HideGeneralForumTopicFunc.
+func (m *Mock) HideGeneralForumTopic(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("HideGeneralForumTopic", chatID)
+
+	if m.HideGeneralForumTopicFunc != nil {
+		return m.HideGeneralForumTopicFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// UnhideGeneralForumTopic records the call, and returns the result of UnhideGeneralForumTopicFunc.
+func (m *Mock) UnhideGeneralForumTopic(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnhideGeneralForumTopic", chatID)
+
+	if m.UnhideGeneralForumTopicFunc != nil {
+		return m.UnhideGeneralForumTopicFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// UnpinAllGeneralForumTopicMessages records the call, and returns the result of UnpinAllGeneralForumTopicMessagesFunc.
+func (m *Mock) UnpinAllGeneralForumTopicMessages(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnpinAllGeneralForumTopicMessages", chatID)
+
+	if m.UnpinAllGeneralForumTopicMessagesFunc != nil {
+		return m.UnpinAllGeneralForumTopicMessagesFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// GetForumTopicIconStickers records the call, and returns the result of GetForumTopicIconStickersFunc.
+func (m *Mock) GetForumTopicIconStickers(ctx context.Context) (result telegrambot.APIResponse[[]telegrambot.Sticker], err error) {
+	m.record("GetForumTopicIconStickers")
+
+	if m.GetForumTopicIconStickersFunc != nil {
+		return m.GetForumTopicIconStickersFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// PostStory records the call, and returns the result of PostStoryFunc.
+func (m *Mock) PostStory(ctx context.Context, businessConnectionID string, content telegrambot.InputStoryContent, activePeriod int, options telegrambot.OptionsPostStory) (result telegrambot.APIResponse[telegrambot.Story], err error) {
+	m.record("PostStory", businessConnectionID, content, activePeriod, options)
+
+	if m.PostStoryFunc != nil {
+		return m.PostStoryFunc(ctx, businessConnectionID, content, activePeriod, options)
+	}
+	result.OK = true
+	return
+}
+
+// RepostStory records the call, and returns the result of RepostStoryFunc.
+func (m *Mock) RepostStory(ctx context.Context, businessConnectionID string, fromChatID int64, fromStoryID int64, activePeriod int, options telegrambot.OptionsRepostStory) (result telegrambot.APIResponse[telegrambot.Story], err error) {
+	m.record("RepostStory", businessConnectionID, fromChatID, fromStoryID, activePeriod, options)
+
+	if m.RepostStoryFunc != nil {
+		return m.RepostStoryFunc(ctx, businessConnectionID, fromChatID, fromStoryID, activePeriod, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditStory records the call, and returns the result of EditStoryFunc.
+func (m *Mock) EditStory(ctx context.Context, businessConnectionID string, storyID int64, content telegrambot.InputStoryContent, options telegrambot.OptionsEditStory) (result telegrambot.APIResponse[telegrambot.Story], err error) {
+	m.record("EditStory", businessConnectionID, storyID, content, options)
+
+	if m.EditStoryFunc != nil {
+		return m.EditStoryFunc(ctx, businessConnectionID, storyID, content, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteStory records the call, and returns the result of DeleteStoryFunc.
+func (m *Mock) DeleteStory(ctx context.Context, businessConnectionID string, storyID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteStory", businessConnectionID, storyID)
+
+	if m.DeleteStoryFunc != nil {
+		return m.DeleteStoryFunc(ctx, businessConnectionID, storyID)
+	}
+	result.OK = true
+	return
+}
+
+// GetAvailableGifts records the call, and returns the result of GetAvailableGiftsFunc.
+func (m *Mock) GetAvailableGifts(ctx context.Context) (result telegrambot.APIResponse[telegrambot.Gifts], err error) {
+	m.record("GetAvailableGifts")
+
+	if m.GetAvailableGiftsFunc != nil {
+		return m.GetAvailableGiftsFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// SendGift records the call, and returns the result of SendGiftFunc.
+func (m *Mock) SendGift(ctx context.Context, giftID string, options telegrambot.OptionsSendGift) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SendGift", giftID, options)
+
+	if m.SendGiftFunc != nil {
+		return m.SendGiftFunc(ctx, giftID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GiftPremiumSubscription records the call, and returns the result of GiftPremiumSubscriptionFunc.
+func (m *Mock) GiftPremiumSubscription(ctx context.Context, userID int64, monthCount int, starCount int, options telegrambot.OptionsGiftPremiumSubscription) (result telegrambot.APIResponse[bool], err error) {
+	m.record("GiftPremiumSubscription", userID, monthCount, starCount, options)
+
+	if m.GiftPremiumSubscriptionFunc != nil {
+		return m.GiftPremiumSubscriptionFunc(ctx, userID, monthCount, starCount, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetUserGifts records the call, and returns the result of GetUserGiftsFunc.
+func (m *Mock) GetUserGifts(ctx context.Context, userID int64, options telegrambot.OptionsGetUserGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error) {
+	m.record("GetUserGifts", userID, options)
+
+	if m.GetUserGiftsFunc != nil {
+		return m.GetUserGiftsFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetChatGifts records the call, and returns the result of GetChatGiftsFunc.
+func (m *Mock) GetChatGifts(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsGetChatGifts) (result telegrambot.APIResponse[telegrambot.OwnedGifts], err error) {
+	m.record("GetChatGifts", chatID, options)
+
+	if m.GetChatGiftsFunc != nil {
+		return m.GetChatGiftsFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// ConvertGiftToStars records the call, and returns the result of ConvertGiftToStarsFunc.
+func (m *Mock) ConvertGiftToStars(ctx context.Context, businessConnectionID string, ownedGiftID string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ConvertGiftToStars", businessConnectionID, ownedGiftID)
+
+	if m.ConvertGiftToStarsFunc != nil {
+		return m.ConvertGiftToStarsFunc(ctx, businessConnectionID, ownedGiftID)
+	}
+	result.OK = true
+	return
+}
+
+// UpgradeGift records the call, and returns the result of UpgradeGiftFunc.
+func (m *Mock) UpgradeGift(ctx context.Context, businessConnectionID string, ownedGiftID string, options telegrambot.OptionsUpgradeGift) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UpgradeGift", businessConnectionID, ownedGiftID, options)
+
+	if m.UpgradeGiftFunc != nil {
+		return m.UpgradeGiftFunc(ctx, businessConnectionID, ownedGiftID, options)
+	}
+	result.OK = true
+	return
+}
+
+// TransferGift records the call, and returns the result of TransferGiftFunc.
+func (m *Mock) TransferGift(ctx context.Context, businessConnectionID string, ownedGiftID string, newOwnerChatID int64, options telegrambot.OptionsTransferGift) (result telegrambot.APIResponse[bool], err error) {
+	m.record("TransferGift", businessConnectionID, ownedGiftID, newOwnerChatID, options)
+
+	if m.TransferGiftFunc != nil {
+		return m.TransferGiftFunc(ctx, businessConnectionID, ownedGiftID, newOwnerChatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendPaidMedia records the call, and returns the result of SendPaidMediaFunc.
+func (m *Mock) SendPaidMedia(ctx context.Context, chatID telegrambot.ChatID, starCount int, media []telegrambot.InputPaidMedia, options telegrambot.OptionsSendPaidMedia) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendPaidMedia", chatID, starCount, media, options)
+
+	if m.SendPaidMediaFunc != nil {
+		return m.SendPaidMediaFunc(ctx, chatID, starCount, media, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendInvoice records the call, and returns the result of SendInvoiceFunc.
+func (m *Mock) SendInvoice(ctx context.Context, chatID int64, title string, description string, payload string, providerToken string, currency string, prices []telegrambot.LabeledPrice, options telegrambot.OptionsSendInvoice) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendInvoice", chatID, title, description, payload, providerToken, currency, prices, options)
+
+	if m.SendInvoiceFunc != nil {
+		return m.SendInvoiceFunc(ctx, chatID, title, description, payload, providerToken, currency, prices, options)
+	}
+	result.OK = true
+	return
+}
+
+// CreateInvoiceLink records the call, and returns the result of CreateInvoiceLinkFunc.
+func (m *Mock) CreateInvoiceLink(ctx context.Context, title string, description string, payload string, currency string, prices []telegrambot.LabeledPrice, options telegrambot.OptionsCreateInvoiceLink) (result telegrambot.APIResponse[string], err error) {
+	m.record("CreateInvoiceLink", title, description, payload, currency, prices, options)
+
+	if m.CreateInvoiceLinkFunc != nil {
+		return m.CreateInvoiceLinkFunc(ctx, title, description, payload, currency, prices, options)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerShippingQuery records the call, and returns the result of AnswerShippingQueryFunc.
+func (m *Mock) AnswerShippingQuery(ctx context.Context, shippingQueryID string, ok bool, shippingOptions []telegrambot.ShippingOption, errorMessage *string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AnswerShippingQuery", shippingQueryID, ok, shippingOptions, errorMessage)
+
+	if m.AnswerShippingQueryFunc != nil {
+		return m.AnswerShippingQueryFunc(ctx, shippingQueryID, ok, shippingOptions, errorMessage)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerPreCheckoutQuery records the call, and returns the result of AnswerPreCheckoutQueryFunc.
+func (m *Mock) AnswerPreCheckoutQuery(ctx context.Context, preCheckoutQueryID string, ok bool, errorMessage *string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AnswerPreCheckoutQuery", preCheckoutQueryID, ok, errorMessage)
+
+	if m.AnswerPreCheckoutQueryFunc != nil {
+		return m.AnswerPreCheckoutQueryFunc(ctx, preCheckoutQueryID, ok, errorMessage)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyStarBalance records the call, and returns the result of GetMyStarBalanceFunc.
+func (m *Mock) GetMyStarBalance(ctx context.Context) (result telegrambot.APIResponse[telegrambot.StarAmount], err error) {
+	m.record("GetMyStarBalance")
+
+	if m.GetMyStarBalanceFunc != nil {
+		return m.GetMyStarBalanceFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// GetStarTransactions records the call, and returns the result of GetStarTransactionsFunc.
+func (m *Mock) GetStarTransactions(ctx context.Context, options telegrambot.OptionsGetStarTransactions) (result telegrambot.APIResponse[telegrambot.StarTransactions], err error) {
+	m.record("GetStarTransactions", options)
+
+	if m.GetStarTransactionsFunc != nil {
+		return m.GetStarTransactionsFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// RefundStarPayment records the call, and returns the result of RefundStarPaymentFunc.
+func (m *Mock) RefundStarPayment(ctx context.Context, userID int64, telegramPaymentChargeID string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RefundStarPayment", userID, telegramPaymentChargeID)
+
+	if m.RefundStarPaymentFunc != nil {
+		return m.RefundStarPaymentFunc(ctx, userID, telegramPaymentChargeID)
+	}
+	result.OK = true
+	return
+}
+
+// EditUserStarSubscription records the call, and returns the result of EditUserStarSubscriptionFunc.
+func (m *Mock) EditUserStarSubscription(ctx context.Context, userID int64, telegramPaymentChargeID string, isCanceled bool) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditUserStarSubscription", userID, telegramPaymentChargeID, isCanceled)
+
+	if m.EditUserStarSubscriptionFunc != nil {
+		return m.EditUserStarSubscriptionFunc(ctx, userID, telegramPaymentChargeID, isCanceled)
+	}
+	result.OK = true
+	return
+}
+
+// SendGame records the call, and returns the result of SendGameFunc.
+func (m *Mock) SendGame(ctx context.Context, chatID telegrambot.ChatID, gameShortName string, options telegrambot.OptionsSendGame) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendGame", chatID, gameShortName, options)
+
+	if m.SendGameFunc != nil {
+		return m.SendGameFunc(ctx, chatID, gameShortName, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetGameScore records the call, and returns the result of SetGameScoreFunc.
+func (m *Mock) SetGameScore(ctx context.Context, userID int64, score int, options telegrambot.OptionsSetGameScore) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("SetGameScore", userID, score, options)
+
+	if m.SetGameScoreFunc != nil {
+		return m.SetGameScoreFunc(ctx, userID, score, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetGameHighScores records the call, and returns the result of GetGameHighScoresFunc.
+func (m *Mock) GetGameHighScores(ctx context.Context, userID int64, options telegrambot.OptionsGetGameHighScores) (result telegrambot.APIResponse[[]telegrambot.GameHighScore], err error) {
+	m.record("GetGameHighScores", userID, options)
+
+	if m.GetGameHighScoresFunc != nil {
+		return m.GetGameHighScoresFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetMe records the call, and returns the result of GetMeFunc.
+func (m *Mock) GetMe(ctx context.Context) (result telegrambot.APIResponse[telegrambot.User], err error) {
+	m.record("GetMe")
+
+	if m.GetMeFunc != nil {
+		return m.GetMeFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// LogOut records the call, and returns the result of LogOutFunc.
+func (m *Mock) LogOut(ctx context.Context) (result telegrambot.APIResponse[bool], err error) {
+	m.record("LogOut")
+
+	if m.LogOutFunc != nil {
+		return m.LogOutFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// Close records the call, and returns the result of CloseFunc.
+func (m *Mock) Close(ctx context.Context) (result telegrambot.APIResponse[bool], err error) {
+	m.record("Close")
+
+	if m.CloseFunc != nil {
+		return m.CloseFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyCommands records the call, and returns the result of GetMyCommandsFunc.
+func (m *Mock) GetMyCommands(ctx context.Context, options telegrambot.OptionsGetMyCommands) (result telegrambot.APIResponse[[]telegrambot.BotCommand], err error) {
+	m.record("GetMyCommands", options)
+
+	if m.GetMyCommandsFunc != nil {
+		return m.GetMyCommandsFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyName records the call, and returns the result of SetMyNameFunc.
+func (m *Mock) SetMyName(ctx context.Context, name string, options telegrambot.OptionsSetMyName) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyName", name, options)
+
+	if m.SetMyNameFunc != nil {
+		return m.SetMyNameFunc(ctx, name, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyName records the call, and returns the result of GetMyNameFunc.
+func (m *Mock) GetMyName(ctx context.Context, options telegrambot.OptionsGetMyName) (result telegrambot.APIResponse[telegrambot.BotName], err error) {
+	m.record("GetMyName", options)
+
+	if m.GetMyNameFunc != nil {
+		return m.GetMyNameFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyDescription records the call, and returns the result of SetMyDescriptionFunc.
+func (m *Mock) SetMyDescription(ctx context.Context, options telegrambot.OptionsSetMyDescription) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyDescription", options)
+
+	if m.SetMyDescriptionFunc != nil {
+		return m.SetMyDescriptionFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyDescription records the call, and returns the result of GetMyDescriptionFunc.
+func (m *Mock) GetMyDescription(ctx context.Context, options telegrambot.OptionsGetMyDescription) (result telegrambot.APIResponse[telegrambot.BotDescription], err error) {
+	m.record("GetMyDescription", options)
+
+	if m.GetMyDescriptionFunc != nil {
+		return m.GetMyDescriptionFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyShortDescription records the call, and returns the result of SetMyShortDescriptionFunc.
+func (m *Mock) SetMyShortDescription(ctx context.Context, options telegrambot.OptionsSetMyShortDescription) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyShortDescription", options)
+
+	if m.SetMyShortDescriptionFunc != nil {
+		return m.SetMyShortDescriptionFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyShortDescription records the call, and returns the result of GetMyShortDescriptionFunc.
+func (m *Mock) GetMyShortDescription(ctx context.Context, options telegrambot.OptionsGetMyShortDescription) (result telegrambot.APIResponse[telegrambot.BotShortDescription], err error) {
+	m.record("GetMyShortDescription", options)
+
+	if m.GetMyShortDescriptionFunc != nil {
+		return m.GetMyShortDescriptionFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetManagedBotToken records the call, and returns the result of GetManagedBotTokenFunc.
+func (m *Mock) GetManagedBotToken(ctx context.Context, userID int64) (result telegrambot.APIResponse[string], err error) {
+	m.record("GetManagedBotToken", userID)
+
+	if m.GetManagedBotTokenFunc != nil {
+		return m.GetManagedBotTokenFunc(ctx, userID)
+	}
+	result.OK = true
+	return
+}
+
+// ReplaceManagedBotToken records the call, and returns the result of ReplaceManagedBotTokenFunc.
+func (m *Mock) ReplaceManagedBotToken(ctx context.Context, userID int64) (result telegrambot.APIResponse[string], err error) {
+	m.record("ReplaceManagedBotToken", userID)
+
+	if m.ReplaceManagedBotTokenFunc != nil {
+		return m.ReplaceManagedBotTokenFunc(ctx, userID)
+	}
+	result.OK = true
+	return
+}
+
+// GetManagedBotAccessSettings records the call, and returns the result of GetManagedBotAccessSettingsFunc.
+func (m *Mock) GetManagedBotAccessSettings(ctx context.Context, userID int64) (result telegrambot.APIResponse[telegrambot.BotAccessSettings], err error) {
+	m.record("GetManagedBotAccessSettings", userID)
+
+	if m.GetManagedBotAccessSettingsFunc != nil {
+		return m.GetManagedBotAccessSettingsFunc(ctx, userID)
+	}
+	result.OK = true
+	return
+}
+
+// SetManagedBotAccessSettings records the call, and returns the result of SetManagedBotAccessSettingsFunc.
+func (m *Mock) SetManagedBotAccessSettings(ctx context.Context, userID int64, isAccessRestricted bool, options telegrambot.OptionsSetManagedBotAccessSettings) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetManagedBotAccessSettings", userID, isAccessRestricted, options)
+
+	if m.SetManagedBotAccessSettingsFunc != nil {
+		return m.SetManagedBotAccessSettingsFunc(ctx, userID, isAccessRestricted, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyCommands records the call, and returns the result of SetMyCommandsFunc.
+func (m *Mock) SetMyCommands(ctx context.Context, commands []telegrambot.BotCommand, options telegrambot.OptionsSetMyCommands) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyCommands", commands, options)
+
+	if m.SetMyCommandsFunc != nil {
+		return m.SetMyCommandsFunc(ctx, commands, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteMyCommands records the call, and returns the result of DeleteMyCommandsFunc.
+func (m *Mock) DeleteMyCommands(ctx context.Context, options telegrambot.OptionsDeleteMyCommands) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteMyCommands", options)
+
+	if m.DeleteMyCommandsFunc != nil {
+		return m.DeleteMyCommandsFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyProfilePhoto records the call, and returns the result of SetMyProfilePhotoFunc.
+func (m *Mock) SetMyProfilePhoto(ctx context.Context, photo telegrambot.InputProfilePhoto) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyProfilePhoto", photo)
+
+	if m.SetMyProfilePhotoFunc != nil {
+		return m.SetMyProfilePhotoFunc(ctx, photo)
+	}
+	result.OK = true
+	return
+}
+
+// RemoveMyProfilePhoto records the call, and returns the result of RemoveMyProfilePhotoFunc.
+func (m *Mock) RemoveMyProfilePhoto(ctx context.Context) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RemoveMyProfilePhoto")
+
+	if m.RemoveMyProfilePhotoFunc != nil {
+		return m.RemoveMyProfilePhotoFunc(ctx)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatMenuButton records the call, and returns the result of SetChatMenuButtonFunc.
+func (m *Mock) SetChatMenuButton(ctx context.Context, options telegrambot.OptionsSetChatMenuButton) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatMenuButton", options)
+
+	if m.SetChatMenuButtonFunc != nil {
+		return m.SetChatMenuButtonFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetChatMenuButton records the call, and returns the result of GetChatMenuButtonFunc.
+func (m *Mock) GetChatMenuButton(ctx context.Context, options telegrambot.OptionsGetChatMenuButton) (result telegrambot.APIResponse[telegrambot.MenuButton], err error) {
+	m.record("GetChatMenuButton", options)
+
+	if m.GetChatMenuButtonFunc != nil {
+		return m.GetChatMenuButtonFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMyDefaultAdministratorRights records the call, and returns the result of SetMyDefaultAdministratorRightsFunc.
+func (m *Mock) SetMyDefaultAdministratorRights(ctx context.Context, options telegrambot.OptionsSetMyDefaultAdministratorRights) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMyDefaultAdministratorRights", options)
+
+	if m.SetMyDefaultAdministratorRightsFunc != nil {
+		return m.SetMyDefaultAdministratorRightsFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetMyDefaultAdministratorRights records the call, and returns the result of GetMyDefaultAdministratorRightsFunc.
+func (m *Mock) GetMyDefaultAdministratorRights(ctx context.Context, options telegrambot.OptionsGetMyDefaultAdministratorRights) (result telegrambot.APIResponse[bool], err error) {
+	m.record("GetMyDefaultAdministratorRights", options)
+
+	if m.GetMyDefaultAdministratorRightsFunc != nil {
+		return m.GetMyDefaultAdministratorRightsFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// BanChatMember records the call, and returns the result of BanChatMemberFunc.
+func (m *Mock) BanChatMember(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsBanChatMember) (result telegrambot.APIResponse[bool], err error) {
+	m.record("BanChatMember", chatID, userID, options)
+
+	if m.BanChatMemberFunc != nil {
+		return m.BanChatMemberFunc(ctx, chatID, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// LeaveChat records the call, and returns the result of LeaveChatFunc.
+func (m *Mock) LeaveChat(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("LeaveChat", chatID)
+
+	if m.LeaveChatFunc != nil {
+		return m.LeaveChatFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// UnbanChatMember records the call, and returns the result of UnbanChatMemberFunc.
+func (m *Mock) UnbanChatMember(ctx context.Context, chatID telegrambot.ChatID, userID int64, onlyIfBanned bool) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnbanChatMember", chatID, userID, onlyIfBanned)
+
+	if m.UnbanChatMemberFunc != nil {
+		return m.UnbanChatMemberFunc(ctx, chatID, userID, onlyIfBanned)
+	}
+	result.OK = true
+	return
+}
+
+// RestrictChatMember records the call, and returns the result of RestrictChatMemberFunc.
+func (m *Mock) RestrictChatMember(ctx context.Context, chatID telegrambot.ChatID, userID int64, permissions telegrambot.ChatPermissions, options telegrambot.OptionsRestrictChatMember) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RestrictChatMember", chatID, userID, permissions, options)
+
+	if m.RestrictChatMemberFunc != nil {
+		return m.RestrictChatMemberFunc(ctx, chatID, userID, permissions, options)
+	}
+	result.OK = true
+	return
+}
+
+// PromoteChatMember records the call, and returns the result of PromoteChatMemberFunc.
+func (m *Mock) PromoteChatMember(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsPromoteChatMember) (result telegrambot.APIResponse[bool], err error) {
+	m.record("PromoteChatMember", chatID, userID, options)
+
+	if m.PromoteChatMemberFunc != nil {
+		return m.PromoteChatMemberFunc(ctx, chatID, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatAdministratorCustomTitle records the call, and returns the result of SetChatAdministratorCustomTitleFunc.
+func (m *Mock) SetChatAdministratorCustomTitle(ctx context.Context, chatID telegrambot.ChatID, userID int64, customTitle string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatAdministratorCustomTitle", chatID, userID, customTitle)
+
+	if m.SetChatAdministratorCustomTitleFunc != nil {
+		return m.SetChatAdministratorCustomTitleFunc(ctx, chatID, userID, customTitle)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatMemberTag records the call, and returns the result of SetChatMemberTagFunc.
+func (m *Mock) SetChatMemberTag(ctx context.Context, chatID telegrambot.ChatID, userID int64, options telegrambot.OptionsSetChatMemberTag) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatMemberTag", chatID, userID, options)
+
+	if m.SetChatMemberTagFunc != nil {
+		return m.SetChatMemberTagFunc(ctx, chatID, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// BanChatSenderChat records the call, and returns the result of BanChatSenderChatFunc.
+func (m *Mock) BanChatSenderChat(ctx context.Context, chatID telegrambot.ChatID, senderChatID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("BanChatSenderChat", chatID, senderChatID)
+
+	if m.BanChatSenderChatFunc != nil {
+		return m.BanChatSenderChatFunc(ctx, chatID, senderChatID)
+	}
+	result.OK = true
+	return
+}
+
+// UnbanChatSenderChat records the call, and returns the result of UnbanChatSenderChatFunc.
+func (m *Mock) UnbanChatSenderChat(ctx context.Context, chatID telegrambot.ChatID, senderChatID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnbanChatSenderChat", chatID, senderChatID)
+
+	if m.UnbanChatSenderChatFunc != nil {
+		return m.UnbanChatSenderChatFunc(ctx, chatID, senderChatID)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatPermissions records the call, and returns the result of SetChatPermissionsFunc.
+func (m *Mock) SetChatPermissions(ctx context.Context, chatID telegrambot.ChatID, permissions telegrambot.ChatPermissions, options telegrambot.OptionsSetChatPermissions) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatPermissions", chatID, permissions, options)
+
+	if m.SetChatPermissionsFunc != nil {
+		return m.SetChatPermissionsFunc(ctx, chatID, permissions, options)
+	}
+	result.OK = true
+	return
+}
+
+// ExportChatInviteLink records the call, and returns the result of ExportChatInviteLinkFunc.
+func (m *Mock) ExportChatInviteLink(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[string], err error) {
+	m.record("ExportChatInviteLink", chatID)
+
+	if m.ExportChatInviteLinkFunc != nil {
+		return m.ExportChatInviteLinkFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// CreateChatInviteLink records the call, and returns the result of CreateChatInviteLinkFunc.
+func (m *Mock) CreateChatInviteLink(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsCreateChatInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error) {
+	m.record("CreateChatInviteLink", chatID, options)
+
+	if m.CreateChatInviteLinkFunc != nil {
+		return m.CreateChatInviteLinkFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditChatInviteLink records the call, and returns the result of EditChatInviteLinkFunc.
+func (m *Mock) EditChatInviteLink(ctx context.Context, chatID telegrambot.ChatID, inviteLink string, options telegrambot.OptionsCreateChatInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error) {
+	m.record("EditChatInviteLink", chatID, inviteLink, options)
+
+	if m.EditChatInviteLinkFunc != nil {
+		return m.EditChatInviteLinkFunc(ctx, chatID, inviteLink, options)
+	}
+	result.OK = true
+	return
+}
+
+// CreateChatSubscriptionInviteLink records the call, and returns the result of CreateChatSubscriptionInviteLinkFunc.
+func (m *Mock) CreateChatSubscriptionInviteLink(ctx context.Context, chatID telegrambot.ChatID, subscriptionPeriod int, subscriptionPrice int, options telegrambot.OptionsCreateChatSubscriptionInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error) {
+	m.record("CreateChatSubscriptionInviteLink", chatID, subscriptionPeriod, subscriptionPrice, options)
+
+	if m.CreateChatSubscriptionInviteLinkFunc != nil {
+		return m.CreateChatSubscriptionInviteLinkFunc(ctx, chatID, subscriptionPeriod, subscriptionPrice, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditChatSubscriptionInviteLink records the call, and returns the result of EditChatSubscriptionInviteLinkFunc.
+func (m *Mock) EditChatSubscriptionInviteLink(ctx context.Context, chatID telegrambot.ChatID, inviteLink string, options telegrambot.OptionsEditChatSubscriptionInviteLink) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error) {
+	m.record("EditChatSubscriptionInviteLink", chatID, inviteLink, options)
+
+	if m.EditChatSubscriptionInviteLinkFunc != nil {
+		return m.EditChatSubscriptionInviteLinkFunc(ctx, chatID, inviteLink, options)
+	}
+	result.OK = true
+	return
+}
+
+// RevokeChatInviteLink records the call, and returns the result of RevokeChatInviteLinkFunc.
+func (m *Mock) RevokeChatInviteLink(ctx context.Context, chatID telegrambot.ChatID, inviteLink string) (result telegrambot.APIResponse[telegrambot.ChatInviteLink], err error) {
+	m.record("RevokeChatInviteLink", chatID, inviteLink)
+
+	if m.RevokeChatInviteLinkFunc != nil {
+		return m.RevokeChatInviteLinkFunc(ctx, chatID, inviteLink)
+	}
+	result.OK = true
+	return
+}
+
+// ApproveChatJoinRequest records the call, and returns the result of ApproveChatJoinRequestFunc.
+func (m *Mock) ApproveChatJoinRequest(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ApproveChatJoinRequest", chatID, userID)
+
+	if m.ApproveChatJoinRequestFunc != nil {
+		return m.ApproveChatJoinRequestFunc(ctx, chatID, userID)
+	}
+	result.OK = true
+	return
+}
+
+// DeclineChatJoinRequest records the call, and returns the result of DeclineChatJoinRequestFunc.
+func (m *Mock) DeclineChatJoinRequest(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeclineChatJoinRequest", chatID, userID)
+
+	if m.DeclineChatJoinRequestFunc != nil {
+		return m.DeclineChatJoinRequestFunc(ctx, chatID, userID)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerChatJoinRequestQuery records the call, and returns the result of AnswerChatJoinRequestQueryFunc.
+func (m *Mock) AnswerChatJoinRequestQuery(ctx context.Context, chatJoinRequestQueryID string, res string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AnswerChatJoinRequestQuery", chatJoinRequestQueryID, res)
+
+	if m.AnswerChatJoinRequestQueryFunc != nil {
+		return m.AnswerChatJoinRequestQueryFunc(ctx, chatJoinRequestQueryID, res)
+	}
+	result.OK = true
+	return
+}
+
+// SendChatJoinRequestWebApp records the call, and returns the result of SendChatJoinRequestWebAppFunc.
+func (m *Mock) SendChatJoinRequestWebApp(ctx context.Context, chatJoinRequestQueryID string, webAppURL string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SendChatJoinRequestWebApp", chatJoinRequestQueryID, webAppURL)
+
+	if m.SendChatJoinRequestWebAppFunc != nil {
+		return m.SendChatJoinRequestWebAppFunc(ctx, chatJoinRequestQueryID, webAppURL)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatPhoto records the call, and returns the result of SetChatPhotoFunc.
+func (m *Mock) SetChatPhoto(ctx context.Context, chatID telegrambot.ChatID, photo telegrambot.InputFile) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatPhoto", chatID, photo)
+
+	if m.SetChatPhotoFunc != nil {
+		return m.SetChatPhotoFunc(ctx, chatID, photo)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteChatPhoto records the call, and returns the result of DeleteChatPhotoFunc.
+func (m *Mock) DeleteChatPhoto(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteChatPhoto", chatID)
+
+	if m.DeleteChatPhotoFunc != nil {
+		return m.DeleteChatPhotoFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatTitle records the call, and returns the result of SetChatTitleFunc.
+func (m *Mock) SetChatTitle(ctx context.Context, chatID telegrambot.ChatID, title string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatTitle", chatID, title)
+
+	if m.SetChatTitleFunc != nil {
+		return m.SetChatTitleFunc(ctx, chatID, title)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatDescription records the call, and returns the result of SetChatDescriptionFunc.
+func (m *Mock) SetChatDescription(ctx context.Context, chatID telegrambot.ChatID, description string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatDescription", chatID, description)
+
+	if m.SetChatDescriptionFunc != nil {
+		return m.SetChatDescriptionFunc(ctx, chatID, description)
+	}
+	result.OK = true
+	return
+}
+
+// PinChatMessage records the call, and returns the result of PinChatMessageFunc.
+func (m *Mock) PinChatMessage(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsPinChatMessage) (result telegrambot.APIResponse[bool], err error) {
+	m.record("PinChatMessage", chatID, messageID, options)
+
+	if m.PinChatMessageFunc != nil {
+		return m.PinChatMessageFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// UnpinChatMessage records the call, and returns the result of UnpinChatMessageFunc.
+func (m *Mock) UnpinChatMessage(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsUnpinChatMessage) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnpinChatMessage", chatID, options)
+
+	if m.UnpinChatMessageFunc != nil {
+		return m.UnpinChatMessageFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// UnpinAllChatMessages records the call, and returns the result of UnpinAllChatMessagesFunc.
+func (m *Mock) UnpinAllChatMessages(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("UnpinAllChatMessages", chatID)
+
+	if m.UnpinAllChatMessagesFunc != nil {
+		return m.UnpinAllChatMessagesFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// GetChat records the call, and returns the result of GetChatFunc.
+func (m *Mock) GetChat(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[telegrambot.ChatFullInfo], err error) {
+	m.record("GetChat", chatID)
+
+	if m.GetChatFunc != nil {
+		return m.GetChatFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// GetChatAdministrators records the call, and returns the result of GetChatAdministratorsFunc.
+func (m *Mock) GetChatAdministrators(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsGetChatAdministrators) (result telegrambot.APIResponse[[]telegrambot.ChatMember], err error) {
+	m.record("GetChatAdministrators", chatID, options)
+
+	if m.GetChatAdministratorsFunc != nil {
+		return m.GetChatAdministratorsFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetChatMemberCount records the call, and returns the result of GetChatMemberCountFunc.
+func (m *Mock) GetChatMemberCount(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[int], err error) {
+	m.record("GetChatMemberCount", chatID)
+
+	if m.GetChatMemberCountFunc != nil {
+		return m.GetChatMemberCountFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// GetChatMember records the call, and returns the result of GetChatMemberFunc.
+func (m *Mock) GetChatMember(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[telegrambot.ChatMember], err error) {
+	m.record("GetChatMember", chatID, userID)
+
+	if m.GetChatMemberFunc != nil {
+		return m.GetChatMemberFunc(ctx, chatID, userID)
+	}
+	result.OK = true
+	return
+}
+
+// SetChatStickerSet records the call, and returns the result of SetChatStickerSetFunc.
+func (m *Mock) SetChatStickerSet(ctx context.Context, chatID telegrambot.ChatID, stickerSetName string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetChatStickerSet", chatID, stickerSetName)
+
+	if m.SetChatStickerSetFunc != nil {
+		return m.SetChatStickerSetFunc(ctx, chatID, stickerSetName)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteChatStickerSet records the call, and returns the result of DeleteChatStickerSetFunc.
+func (m *Mock) DeleteChatStickerSet(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteChatStickerSet", chatID)
+
+	if m.DeleteChatStickerSetFunc != nil {
+		return m.DeleteChatStickerSetFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// SendSticker records the call, and returns the result of SendStickerFunc.
+func (m *Mock) SendSticker(ctx context.Context, chatID telegrambot.ChatID, sticker telegrambot.InputFile, options telegrambot.OptionsSendSticker) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendSticker", chatID, sticker, options)
+
+	if m.SendStickerFunc != nil {
+		return m.SendStickerFunc(ctx, chatID, sticker, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetStickerSet records the call, and returns the result of GetStickerSetFunc.
+func (m *Mock) GetStickerSet(ctx context.Context, name string) (result telegrambot.APIResponse[telegrambot.StickerSet], err error) {
+	m.record("GetStickerSet", name)
+
+	if m.GetStickerSetFunc != nil {
+		return m.GetStickerSetFunc(ctx, name)
+	}
+	result.OK = true
+	return
+}
+
+// GetCustomEmojiStickers records the call, and returns the result of GetCustomEmojiStickersFunc.
+func (m *Mock) GetCustomEmojiStickers(ctx context.Context, customEmojiIDs []string) (result telegrambot.APIResponse[[]telegrambot.Sticker], err error) {
+	m.record("GetCustomEmojiStickers", customEmojiIDs)
+
+	if m.GetCustomEmojiStickersFunc != nil {
+		return m.GetCustomEmojiStickersFunc(ctx, customEmojiIDs)
+	}
+	result.OK = true
+	return
+}
+
+// UploadStickerFile records the call, and returns the result of UploadStickerFileFunc.
+func (m *Mock) UploadStickerFile(ctx context.Context, userID int64, sticker telegrambot.InputFile, stickerFormat telegrambot.StickerFormat) (result telegrambot.APIResponse[telegrambot.File], err error) {
+	m.record("UploadStickerFile", userID, sticker, stickerFormat)
+
+	if m.UploadStickerFileFunc != nil {
+		return m.UploadStickerFileFunc(ctx, userID, sticker, stickerFormat)
+	}
+	result.OK = true
+	return
+}
+
+// CreateNewStickerSet records the call, and returns the result of CreateNewStickerSetFunc.
+func (m *Mock) CreateNewStickerSet(ctx context.Context, userID int64, name string, title string, stickers []telegrambot.InputSticker, options telegrambot.OptionsCreateNewStickerSet) (result telegrambot.APIResponse[bool], err error) {
+	m.record("CreateNewStickerSet", userID, name, title, stickers, options)
+
+	if m.CreateNewStickerSetFunc != nil {
+		return m.CreateNewStickerSetFunc(ctx, userID, name, title, stickers, options)
+	}
+	result.OK = true
+	return
+}
+
+// AddStickerToSet records the call, and returns the result of AddStickerToSetFunc.
+func (m *Mock) AddStickerToSet(ctx context.Context, userID int64, name string, sticker telegrambot.InputSticker, options telegrambot.OptionsAddStickerToSet) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AddStickerToSet", userID, name, sticker, options)
+
+	if m.AddStickerToSetFunc != nil {
+		return m.AddStickerToSetFunc(ctx, userID, name, sticker, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerPositionInSet records the call, and returns the result of SetStickerPositionInSetFunc.
+func (m *Mock) SetStickerPositionInSet(ctx context.Context, sticker string, position int) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerPositionInSet", sticker, position)
+
+	if m.SetStickerPositionInSetFunc != nil {
+		return m.SetStickerPositionInSetFunc(ctx, sticker, position)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteStickerFromSet records the call, and returns the result of DeleteStickerFromSetFunc.
+func (m *Mock) DeleteStickerFromSet(ctx context.Context, sticker string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteStickerFromSet", sticker)
+
+	if m.DeleteStickerFromSetFunc != nil {
+		return m.DeleteStickerFromSetFunc(ctx, sticker)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerSetThumbnail records the call, and returns the result of SetStickerSetThumbnailFunc.
+func (m *Mock) SetStickerSetThumbnail(ctx context.Context, name string, userID int64, format telegrambot.StickerFormat, options telegrambot.OptionsSetStickerSetThumbnail) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerSetThumbnail", name, userID, format, options)
+
+	if m.SetStickerSetThumbnailFunc != nil {
+		return m.SetStickerSetThumbnailFunc(ctx, name, userID, format, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetCustomEmojiStickerSetThumbnail records the call, and returns the result of SetCustomEmojiStickerSetThumbnailFunc.
+func (m *Mock) SetCustomEmojiStickerSetThumbnail(ctx context.Context, name string, options telegrambot.OptionsSetCustomEmojiStickerSetThumbnail) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetCustomEmojiStickerSetThumbnail", name, options)
+
+	if m.SetCustomEmojiStickerSetThumbnailFunc != nil {
+		return m.SetCustomEmojiStickerSetThumbnailFunc(ctx, name, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerSetTitle records the call, and returns the result of SetStickerSetTitleFunc.
+func (m *Mock) SetStickerSetTitle(ctx context.Context, name string, title string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerSetTitle", name, title)
+
+	if m.SetStickerSetTitleFunc != nil {
+		return m.SetStickerSetTitleFunc(ctx, name, title)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteStickerSet records the call, and returns the result of DeleteStickerSetFunc.
+func (m *Mock) DeleteStickerSet(ctx context.Context, name string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteStickerSet", name)
+
+	if m.DeleteStickerSetFunc != nil {
+		return m.DeleteStickerSetFunc(ctx, name)
+	}
+	result.OK = true
+	return
+}
+
+// ReplaceStickerInSet records the call, and returns the result of ReplaceStickerInSetFunc.
+func (m *Mock) ReplaceStickerInSet(ctx context.Context, userID string, name string, oldSticker string, sticker telegrambot.InputSticker) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ReplaceStickerInSet", userID, name, oldSticker, sticker)
+
+	if m.ReplaceStickerInSetFunc != nil {
+		return m.ReplaceStickerInSetFunc(ctx, userID, name, oldSticker, sticker)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerEmojiList records the call, and returns the result of SetStickerEmojiListFunc.
+func (m *Mock) SetStickerEmojiList(ctx context.Context, sticker string, emojiList []string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerEmojiList", sticker, emojiList)
+
+	if m.SetStickerEmojiListFunc != nil {
+		return m.SetStickerEmojiListFunc(ctx, sticker, emojiList)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerKeywords records the call, and returns the result of SetStickerKeywordsFunc.
+func (m *Mock) SetStickerKeywords(ctx context.Context, sticker string, keywords []string) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerKeywords", sticker, keywords)
+
+	if m.SetStickerKeywordsFunc != nil {
+		return m.SetStickerKeywordsFunc(ctx, sticker, keywords)
+	}
+	result.OK = true
+	return
+}
+
+// SetStickerMaskPosition records the call, and returns the result of SetStickerMaskPositionFunc.
+func (m *Mock) SetStickerMaskPosition(ctx context.Context, sticker string, options telegrambot.OptionsSetStickerMaskPosition) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetStickerMaskPosition", sticker, options)
+
+	if m.SetStickerMaskPositionFunc != nil {
+		return m.SetStickerMaskPositionFunc(ctx, sticker, options)
+	}
+	result.OK = true
+	return
+}
+
+// VerifyUser records the call, and returns the result of VerifyUserFunc.
+func (m *Mock) VerifyUser(ctx context.Context, userID int64, options telegrambot.OptionsVerifyUser) (result telegrambot.APIResponse[bool], err error) {
+	m.record("VerifyUser", userID, options)
+
+	if m.VerifyUserFunc != nil {
+		return m.VerifyUserFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// VerifyChat records the call, and returns the result of VerifyChatFunc.
+func (m *Mock) VerifyChat(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsVerifyChat) (result telegrambot.APIResponse[bool], err error) {
+	m.record("VerifyChat", chatID, options)
+
+	if m.VerifyChatFunc != nil {
+		return m.VerifyChatFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// RemoveUserVerification records the call, and returns the result of RemoveUserVerificationFunc.
+func (m *Mock) RemoveUserVerification(ctx context.Context, userID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RemoveUserVerification", userID)
+
+	if m.RemoveUserVerificationFunc != nil {
+		return m.RemoveUserVerificationFunc(ctx, userID)
+	}
+	result.OK = true
+	return
+}
+
+// RemoveChatVerification records the call, and returns the result of RemoveChatVerificationFunc.
+func (m *Mock) RemoveChatVerification(ctx context.Context, chatID telegrambot.ChatID) (result telegrambot.APIResponse[bool], err error) {
+	m.record("RemoveChatVerification", chatID)
+
+	if m.RemoveChatVerificationFunc != nil {
+		return m.RemoveChatVerificationFunc(ctx, chatID)
+	}
+	result.OK = true
+	return
+}
+
+// GetUserProfilePhotos records the call, and returns the result of GetUserProfilePhotosFunc.
+func (m *Mock) GetUserProfilePhotos(ctx context.Context, userID int64, options telegrambot.OptionsGetUserProfilePhotos) (result telegrambot.APIResponse[telegrambot.UserProfilePhotos], err error) {
+	m.record("GetUserProfilePhotos", userID, options)
+
+	if m.GetUserProfilePhotosFunc != nil {
+		return m.GetUserProfilePhotosFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetUserProfileAudios records the call, and returns the result of GetUserProfileAudiosFunc.
+func (m *Mock) GetUserProfileAudios(ctx context.Context, userID int64, options telegrambot.OptionsGetUserProfileAudios) (result telegrambot.APIResponse[telegrambot.UserProfileAudios], err error) {
+	m.record("GetUserProfileAudios", userID, options)
+
+	if m.GetUserProfileAudiosFunc != nil {
+		return m.GetUserProfileAudiosFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetUserEmojiStatus records the call, and returns the result of SetUserEmojiStatusFunc.
+func (m *Mock) SetUserEmojiStatus(ctx context.Context, userID int64, options telegrambot.OptionsSetUserEmojiStatus) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetUserEmojiStatus", userID, options)
+
+	if m.SetUserEmojiStatusFunc != nil {
+		return m.SetUserEmojiStatusFunc(ctx, userID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetUserPersonalChatMessages records the call, and returns the result of GetUserPersonalChatMessagesFunc.
+func (m *Mock) GetUserPersonalChatMessages(ctx context.Context, userID int64, limit int) (result telegrambot.APIResponse[[]telegrambot.Message], err error) {
+	m.record("GetUserPersonalChatMessages", userID, limit)
+
+	if m.GetUserPersonalChatMessagesFunc != nil {
+		return m.GetUserPersonalChatMessagesFunc(ctx, userID, limit)
+	}
+	result.OK = true
+	return
+}
+
+// GetUserChatBoosts records the call, and returns the result of GetUserChatBoostsFunc.
+func (m *Mock) GetUserChatBoosts(ctx context.Context, chatID telegrambot.ChatID, userID int64) (result telegrambot.APIResponse[telegrambot.UserChatBoosts], err error) {
+	m.record("GetUserChatBoosts", chatID, userID)
+
+	if m.GetUserChatBoostsFunc != nil {
+		return m.GetUserChatBoostsFunc(ctx, chatID, userID)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerGuestQuery records the call, and returns the result of AnswerGuestQueryFunc.
+func (m *Mock) AnswerGuestQuery(ctx context.Context, guestQueryID string, queryResult telegrambot.InlineQueryResult) (result telegrambot.APIResponse[telegrambot.SentGuestMessage], err error) {
+	m.record("AnswerGuestQuery", guestQueryID, queryResult)
+
+	if m.AnswerGuestQueryFunc != nil {
+		return m.AnswerGuestQueryFunc(ctx, guestQueryID, queryResult)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerInlineQuery records the call, and returns the result of AnswerInlineQueryFunc.
+func (m *Mock) AnswerInlineQuery(ctx context.Context, inlineQueryID string, results []telegrambot.InlineQueryResultVariant, options telegrambot.OptionsAnswerInlineQuery) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AnswerInlineQuery", inlineQueryID, results, options)
+
+	if m.AnswerInlineQueryFunc != nil {
+		return m.AnswerInlineQueryFunc(ctx, inlineQueryID, results, options)
+	}
+	result.OK = true
+	return
+}
+
+// AnswerWebAppQuery records the call, and returns the result of AnswerWebAppQueryFunc.
+func (m *Mock) AnswerWebAppQuery(ctx context.Context, webAppQueryID string, res telegrambot.InlineQueryResult) (result telegrambot.APIResponse[telegrambot.SentWebAppMessage], err error) {
+	m.record("AnswerWebAppQuery", webAppQueryID, res)
+
+	if m.AnswerWebAppQueryFunc != nil {
+		return m.AnswerWebAppQueryFunc(ctx, webAppQueryID, res)
+	}
+	result.OK = true
+	return
+}
+
+// SavePreparedInlineMessage records the call, and returns the result of SavePreparedInlineMessageFunc.
+func (m *Mock) SavePreparedInlineMessage(ctx context.Context, userID int64, result telegrambot.InlineQueryResult, options telegrambot.OptionsSavePreparedInlineMessage) (res telegrambot.APIResponse[telegrambot.PreparedInlineMessage], err error) {
+	m.record("SavePreparedInlineMessage", userID, result, options)
+
+	if m.SavePreparedInlineMessageFunc != nil {
+		return m.SavePreparedInlineMessageFunc(ctx, userID, result, options)
+	}
+	res.OK = true
+	return
+}
+
+// SavePreparedKeyboardButton records the call, and returns the result of SavePreparedKeyboardButtonFunc.
+func (m *Mock) SavePreparedKeyboardButton(ctx context.Context, userID int64, button telegrambot.KeyboardButton) (res telegrambot.APIResponse[telegrambot.PreparedKeyboardButton], err error) {
+	m.record("SavePreparedKeyboardButton", userID, button)
+
+	if m.SavePreparedKeyboardButtonFunc != nil {
+		return m.SavePreparedKeyboardButtonFunc(ctx, userID, button)
+	}
+	res.OK = true
+	return
+}
+
+// SendMessage records the call, and returns the result of SendMessageFunc.
+func (m *Mock) SendMessage(ctx context.Context, chatID telegrambot.ChatID, text string, options telegrambot.OptionsSendMessage) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendMessage", chatID, text, options)
+
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, chatID, text, options)
+	}
+	result.OK = true
+	return
+}
+
+// ForwardMessage records the call, and returns the result of ForwardMessageFunc.
+func (m *Mock) ForwardMessage(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsForwardMessage) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("ForwardMessage", chatID, fromChatID, messageID, options)
+
+	if m.ForwardMessageFunc != nil {
+		return m.ForwardMessageFunc(ctx, chatID, fromChatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// ForwardMessages records the call, and returns the result of ForwardMessagesFunc.
+func (m *Mock) ForwardMessages(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageIDs []int64, options telegrambot.OptionsForwardMessages) (result telegrambot.APIResponse[[]telegrambot.MessageID], err error) {
+	m.record("ForwardMessages", chatID, fromChatID, messageIDs, options)
+
+	if m.ForwardMessagesFunc != nil {
+		return m.ForwardMessagesFunc(ctx, chatID, fromChatID, messageIDs, options)
+	}
+	result.OK = true
+	return
+}
+
+// CopyMessage records the call, and returns the result of CopyMessageFunc.
+func (m *Mock) CopyMessage(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsCopyMessage) (result telegrambot.APIResponse[telegrambot.MessageID], err error) {
+	m.record("CopyMessage", chatID, fromChatID, messageID, options)
+
+	if m.CopyMessageFunc != nil {
+		return m.CopyMessageFunc(ctx, chatID, fromChatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// CopyMessages records the call, and returns the result of CopyMessagesFunc.
+func (m *Mock) CopyMessages(ctx context.Context, chatID telegrambot.ChatID, fromChatID telegrambot.ChatID, messageIDs []int64, options telegrambot.OptionsCopyMessages) (result telegrambot.APIResponse[[]telegrambot.MessageID], err error) {
+	m.record("CopyMessages", chatID, fromChatID, messageIDs, options)
+
+	if m.CopyMessagesFunc != nil {
+		return m.CopyMessagesFunc(ctx, chatID, fromChatID, messageIDs, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendPhoto records the call, and returns the result of SendPhotoFunc.
+func (m *Mock) SendPhoto(ctx context.Context, chatID telegrambot.ChatID, photo telegrambot.InputFile, options telegrambot.OptionsSendPhoto) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendPhoto", chatID, photo, options)
+
+	if m.SendPhotoFunc != nil {
+		return m.SendPhotoFunc(ctx, chatID, photo, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendLivePhoto records the call, and returns the result of SendLivePhotoFunc.
+func (m *Mock) SendLivePhoto(ctx context.Context, chatID telegrambot.ChatID, livePhoto telegrambot.InputFile, photo telegrambot.InputFile, options telegrambot.OptionsSendLivePhoto) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendLivePhoto", chatID, livePhoto, photo, options)
+
+	if m.SendLivePhotoFunc != nil {
+		return m.SendLivePhotoFunc(ctx, chatID, livePhoto, photo, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendAudio records the call, and returns the result of SendAudioFunc.
+func (m *Mock) SendAudio(ctx context.Context, chatID telegrambot.ChatID, audio telegrambot.InputFile, options telegrambot.OptionsSendAudio) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendAudio", chatID, audio, options)
+
+	if m.SendAudioFunc != nil {
+		return m.SendAudioFunc(ctx, chatID, audio, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendDocument records the call, and returns the result of SendDocumentFunc.
+func (m *Mock) SendDocument(ctx context.Context, chatID telegrambot.ChatID, document telegrambot.InputFile, options telegrambot.OptionsSendDocument) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendDocument", chatID, document, options)
+
+	if m.SendDocumentFunc != nil {
+		return m.SendDocumentFunc(ctx, chatID, document, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendRichMessage records the call, and returns the result of SendRichMessageFunc.
+func (m *Mock) SendRichMessage(ctx context.Context, chatID telegrambot.ChatID, richMessage telegrambot.InputRichMessage, options telegrambot.OptionsSendRichMessage) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendRichMessage", chatID, richMessage, options)
+
+	if m.SendRichMessageFunc != nil {
+		return m.SendRichMessageFunc(ctx, chatID, richMessage, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendRichMessageDraft records the call, and returns the result of SendRichMessageDraftFunc.
+func (m *Mock) SendRichMessageDraft(ctx context.Context, chatID telegrambot.ChatID, draftID int64, richMessage telegrambot.InputRichMessage, options telegrambot.OptionsSendRichMessageDraft) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SendRichMessageDraft", chatID, draftID, richMessage, options)
+
+	if m.SendRichMessageDraftFunc != nil {
+		return m.SendRichMessageDraftFunc(ctx, chatID, draftID, richMessage, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendVideo records the call, and returns the result of SendVideoFunc.
+func (m *Mock) SendVideo(ctx context.Context, chatID telegrambot.ChatID, video telegrambot.InputFile, options telegrambot.OptionsSendVideo) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendVideo", chatID, video, options)
+
+	if m.SendVideoFunc != nil {
+		return m.SendVideoFunc(ctx, chatID, video, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendAnimation records the call, and returns the result of SendAnimationFunc.
+func (m *Mock) SendAnimation(ctx context.Context, chatID telegrambot.ChatID, animation telegrambot.InputFile, options telegrambot.OptionsSendAnimation) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendAnimation", chatID, animation, options)
+
+	if m.SendAnimationFunc != nil {
+		return m.SendAnimationFunc(ctx, chatID, animation, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendVoice records the call, and returns the result of SendVoiceFunc.
+func (m *Mock) SendVoice(ctx context.Context, chatID telegrambot.ChatID, voice telegrambot.InputFile, options telegrambot.OptionsSendVoice) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendVoice", chatID, voice, options)
+
+	if m.SendVoiceFunc != nil {
+		return m.SendVoiceFunc(ctx, chatID, voice, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendVideoNote records the call, and returns the result of SendVideoNoteFunc.
+func (m *Mock) SendVideoNote(ctx context.Context, chatID telegrambot.ChatID, videoNote telegrambot.InputFile, options telegrambot.OptionsSendVideoNote) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendVideoNote", chatID, videoNote, options)
+
+	if m.SendVideoNoteFunc != nil {
+		return m.SendVideoNoteFunc(ctx, chatID, videoNote, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendMediaGroup records the call, and returns the result of SendMediaGroupFunc.
+func (m *Mock) SendMediaGroup(ctx context.Context, chatID telegrambot.ChatID, media []telegrambot.InputMedia, options telegrambot.OptionsSendMediaGroup) (result telegrambot.APIResponse[[]telegrambot.Message], err error) {
+	m.record("SendMediaGroup", chatID, media, options)
+
+	if m.SendMediaGroupFunc != nil {
+		return m.SendMediaGroupFunc(ctx, chatID, media, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendLocation records the call, and returns the result of SendLocationFunc.
+func (m *Mock) SendLocation(ctx context.Context, chatID telegrambot.ChatID, latitude float32, longitude float32, options telegrambot.OptionsSendLocation) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendLocation", chatID, latitude, longitude, options)
+
+	if m.SendLocationFunc != nil {
+		return m.SendLocationFunc(ctx, chatID, latitude, longitude, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendVenue records the call, and returns the result of SendVenueFunc.
+func (m *Mock) SendVenue(ctx context.Context, chatID telegrambot.ChatID, latitude float32, longitude float32, title string, address string, options telegrambot.OptionsSendVenue) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendVenue", chatID, latitude, longitude, title, address, options)
+
+	if m.SendVenueFunc != nil {
+		return m.SendVenueFunc(ctx, chatID, latitude, longitude, title, address, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendContact records the call, and returns the result of SendContactFunc.
+func (m *Mock) SendContact(ctx context.Context, chatID telegrambot.ChatID, phoneNumber string, firstName string, options telegrambot.OptionsSendContact) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendContact", chatID, phoneNumber, firstName, options)
+
+	if m.SendContactFunc != nil {
+		return m.SendContactFunc(ctx, chatID, phoneNumber, firstName, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendPoll records the call, and returns the result of SendPollFunc.
+func (m *Mock) SendPoll(ctx context.Context, chatID telegrambot.ChatID, question string, pollOptions []telegrambot.InputPollOption, options telegrambot.OptionsSendPoll) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendPoll", chatID, question, pollOptions, options)
+
+	if m.SendPollFunc != nil {
+		return m.SendPollFunc(ctx, chatID, question, pollOptions, options)
+	}
+	result.OK = true
+	return
+}
+
+// StopPoll records the call, and returns the result of StopPollFunc.
+func (m *Mock) StopPoll(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsStopPoll) (result telegrambot.APIResponse[telegrambot.Poll], err error) {
+	m.record("StopPoll", chatID, messageID, options)
+
+	if m.StopPollFunc != nil {
+		return m.StopPollFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// ApproveSuggestedPost records the call, and returns the result of ApproveSuggestedPostFunc.
+func (m *Mock) ApproveSuggestedPost(ctx context.Context, chatID int64, messageID int64, options telegrambot.OptionsApproveSuggestedPost) (result telegrambot.APIResponse[bool], err error) {
+	m.record("ApproveSuggestedPost", chatID, messageID, options)
+
+	if m.ApproveSuggestedPostFunc != nil {
+		return m.ApproveSuggestedPostFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeclineSuggestedPost records the call, and returns the result of DeclineSuggestedPostFunc.
+func (m *Mock) DeclineSuggestedPost(ctx context.Context, chatID int64, messageID int64, options telegrambot.OptionsDeclineSuggestedPost) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeclineSuggestedPost", chatID, messageID, options)
+
+	if m.DeclineSuggestedPostFunc != nil {
+		return m.DeclineSuggestedPostFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendChecklist records the call, and returns the result of SendChecklistFunc.
+func (m *Mock) SendChecklist(ctx context.Context, businessConnectionID string, chatID telegrambot.ChatID, checklist telegrambot.InputChecklist, options telegrambot.OptionsSendChecklist) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendChecklist", businessConnectionID, chatID, checklist, options)
+
+	if m.SendChecklistFunc != nil {
+		return m.SendChecklistFunc(ctx, businessConnectionID, chatID, checklist, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendDice records the call, and returns the result of SendDiceFunc.
+func (m *Mock) SendDice(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsSendDice) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("SendDice", chatID, options)
+
+	if m.SendDiceFunc != nil {
+		return m.SendDiceFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendMessageDraft records the call, and returns the result of SendMessageDraftFunc.
+func (m *Mock) SendMessageDraft(ctx context.Context, chatID telegrambot.ChatID, draftID int64, text string, options telegrambot.OptionsSendMessageDraft) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SendMessageDraft", chatID, draftID, text, options)
+
+	if m.SendMessageDraftFunc != nil {
+		return m.SendMessageDraftFunc(ctx, chatID, draftID, text, options)
+	}
+	result.OK = true
+	return
+}
+
+// SendChatAction records the call, and returns the result of SendChatActionFunc.
+func (m *Mock) SendChatAction(ctx context.Context, chatID telegrambot.ChatID, action telegrambot.ChatAction, options telegrambot.OptionsSendChatAction) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SendChatAction", chatID, action, options)
+
+	if m.SendChatActionFunc != nil {
+		return m.SendChatActionFunc(ctx, chatID, action, options)
+	}
+	result.OK = true
+	return
+}
+
+// SetMessageReaction records the call, and returns the result of SetMessageReactionFunc.
+func (m *Mock) SetMessageReaction(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsSetMessageReaction) (result telegrambot.APIResponse[bool], err error) {
+	m.record("SetMessageReaction", chatID, messageID, options)
+
+	if m.SetMessageReactionFunc != nil {
+		return m.SetMessageReactionFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// GetFile records the call, and returns the result of GetFileFunc.
+func (m *Mock) GetFile(ctx context.Context, fileID string) (result telegrambot.APIResponse[telegrambot.File], err error) {
+	m.record("GetFile", fileID)
+
+	if m.GetFileFunc != nil {
+		return m.GetFileFunc(ctx, fileID)
+	}
+	result.OK = true
+	return
+}
+
+// GetFileURL records the call, and returns the result of GetFileURLFunc.
+func (m *Mock) GetFileURL(file telegrambot.File) (r0 string) {
+	m.record("GetFileURL", file)
+
+	if m.GetFileURLFunc != nil {
+		return m.GetFileURLFunc(file)
+	}
+	return
+}
+
+// AnswerCallbackQuery records the call, and returns the result of AnswerCallbackQueryFunc.
+func (m *Mock) AnswerCallbackQuery(ctx context.Context, callbackQueryID string, options telegrambot.OptionsAnswerCallbackQuery) (result telegrambot.APIResponse[bool], err error) {
+	m.record("AnswerCallbackQuery", callbackQueryID, options)
+
+	if m.AnswerCallbackQueryFunc != nil {
+		return m.AnswerCallbackQueryFunc(ctx, callbackQueryID, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageText records the call, and returns the result of EditMessageTextFunc.
+func (m *Mock) EditMessageText(ctx context.Context, text string, options telegrambot.OptionsEditMessageText) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("EditMessageText", text, options)
+
+	if m.EditMessageTextFunc != nil {
+		return m.EditMessageTextFunc(ctx, text, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageCaption records the call, and returns the result of EditMessageCaptionFunc.
+func (m *Mock) EditMessageCaption(ctx context.Context, options telegrambot.OptionsEditMessageCaption) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("EditMessageCaption", options)
+
+	if m.EditMessageCaptionFunc != nil {
+		return m.EditMessageCaptionFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageMedia records the call, and returns the result of EditMessageMediaFunc.
+func (m *Mock) EditMessageMedia(ctx context.Context, media telegrambot.InputMedia, options telegrambot.OptionsEditMessageMedia) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("EditMessageMedia", media, options)
+
+	if m.EditMessageMediaFunc != nil {
+		return m.EditMessageMediaFunc(ctx, media, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageLiveLocation records the call, and returns the result of EditMessageLiveLocationFunc.
+func (m *Mock) EditMessageLiveLocation(ctx context.Context, latitude float32, longitude float32, options telegrambot.OptionsEditMessageLiveLocation) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("EditMessageLiveLocation", latitude, longitude, options)
+
+	if m.EditMessageLiveLocationFunc != nil {
+		return m.EditMessageLiveLocationFunc(ctx, latitude, longitude, options)
+	}
+	result.OK = true
+	return
+}
+
+// StopMessageLiveLocation records the call, and returns the result of StopMessageLiveLocationFunc.
+func (m *Mock) StopMessageLiveLocation(ctx context.Context, options telegrambot.OptionsStopMessageLiveLocation) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("StopMessageLiveLocation", options)
+
+	if m.StopMessageLiveLocationFunc != nil {
+		return m.StopMessageLiveLocationFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageChecklist records the call, and returns the result of EditMessageChecklistFunc.
+func (m *Mock) EditMessageChecklist(ctx context.Context, businessConnectionID string, chatID int64, messageID int64, checklist telegrambot.InputChecklist, options telegrambot.OptionsEditMessageChecklist) (result telegrambot.APIResponse[telegrambot.Message], err error) {
+	m.record("EditMessageChecklist", businessConnectionID, chatID, messageID, checklist, options)
+
+	if m.EditMessageChecklistFunc != nil {
+		return m.EditMessageChecklistFunc(ctx, businessConnectionID, chatID, messageID, checklist, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditMessageReplyMarkup records the call, and returns the result of EditMessageReplyMarkupFunc.
+func (m *Mock) EditMessageReplyMarkup(ctx context.Context, options telegrambot.OptionsEditMessageReplyMarkup) (result telegrambot.APIResponseMessageOrBool, err error) {
+	m.record("EditMessageReplyMarkup", options)
+
+	if m.EditMessageReplyMarkupFunc != nil {
+		return m.EditMessageReplyMarkupFunc(ctx, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteMessage records the call, and returns the result of DeleteMessageFunc.
+func (m *Mock) DeleteMessage(ctx context.Context, chatID telegrambot.ChatID, messageID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteMessage", chatID, messageID)
+
+	if m.DeleteMessageFunc != nil {
+		return m.DeleteMessageFunc(ctx, chatID, messageID)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteMessages records the call, and returns the result of DeleteMessagesFunc.
+func (m *Mock) DeleteMessages(ctx context.Context, chatID telegrambot.ChatID, messageIDs []int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteMessages", chatID, messageIDs)
+
+	if m.DeleteMessagesFunc != nil {
+		return m.DeleteMessagesFunc(ctx, chatID, messageIDs)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteMessageReaction records the call, and returns the result of DeleteMessageReactionFunc.
+func (m *Mock) DeleteMessageReaction(ctx context.Context, chatID telegrambot.ChatID, messageID int64, options telegrambot.OptionsDeleteMessageReaction) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteMessageReaction", chatID, messageID, options)
+
+	if m.DeleteMessageReactionFunc != nil {
+		return m.DeleteMessageReactionFunc(ctx, chatID, messageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteAllMessageReactions records the call, and returns the result of DeleteAllMessageReactionsFunc.
+func (m *Mock) DeleteAllMessageReactions(ctx context.Context, chatID telegrambot.ChatID, options telegrambot.OptionsDeleteAllMessageReactions) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteAllMessageReactions", chatID, options)
+
+	if m.DeleteAllMessageReactionsFunc != nil {
+		return m.DeleteAllMessageReactionsFunc(ctx, chatID, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditEphemeralMessageText records the call, and returns the result of EditEphemeralMessageTextFunc.
+func (m *Mock) EditEphemeralMessageText(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, text string, options telegrambot.OptionsEditEphemeralMessageText) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditEphemeralMessageText", chatID, receiverUserID, ephemeralMessageID, text, options)
+
+	if m.EditEphemeralMessageTextFunc != nil {
+		return m.EditEphemeralMessageTextFunc(ctx, chatID, receiverUserID, ephemeralMessageID, text, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditEphemeralMessageMedia records the call, and returns the result of EditEphemeralMessageMediaFunc.
+func (m *Mock) EditEphemeralMessageMedia(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageMedia, media telegrambot.InputMedia) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditEphemeralMessageMedia", chatID, receiverUserID, ephemeralMessageID, options, media)
+
+	if m.EditEphemeralMessageMediaFunc != nil {
+		return m.EditEphemeralMessageMediaFunc(ctx, chatID, receiverUserID, ephemeralMessageID, options, media)
+	}
+	result.OK = true
+	return
+}
+
+// EditEphemeralMessageCaption records the call, and returns the result of EditEphemeralMessageCaptionFunc.
+func (m *Mock) EditEphemeralMessageCaption(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageCaption) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditEphemeralMessageCaption", chatID, receiverUserID, ephemeralMessageID, options)
+
+	if m.EditEphemeralMessageCaptionFunc != nil {
+		return m.EditEphemeralMessageCaptionFunc(ctx, chatID, receiverUserID, ephemeralMessageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// EditEphemeralMessageReplyMarkup records the call, and returns the result of EditEphemeralMessageReplyMarkupFunc.
+func (m *Mock) EditEphemeralMessageReplyMarkup(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64, options telegrambot.OptionsEditEphemeralMessageReplyMarkup) (result telegrambot.APIResponse[bool], err error) {
+	m.record("EditEphemeralMessageReplyMarkup", chatID, receiverUserID, ephemeralMessageID, options)
+
+	if m.EditEphemeralMessageReplyMarkupFunc != nil {
+		return m.EditEphemeralMessageReplyMarkupFunc(ctx, chatID, receiverUserID, ephemeralMessageID, options)
+	}
+	result.OK = true
+	return
+}
+
+// DeleteEphemeralMessage records the call, and returns the result of DeleteEphemeralMessageFunc.
+func (m *Mock) DeleteEphemeralMessage(ctx context.Context, chatID telegrambot.ChatID, receiverUserID int64, ephemeralMessageID int64) (result telegrambot.APIResponse[bool], err error) {
+	m.record("DeleteEphemeralMessage", chatID, receiverUserID, ephemeralMessageID)
+
+	if m.DeleteEphemeralMessageFunc != nil {
+		return m.DeleteEphemeralMessageFunc(ctx, chatID, receiverUserID, ephemeralMessageID)
+	}
+	result.OK = true
+	return
+}
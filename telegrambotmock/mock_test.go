@@ -0,0 +1,53 @@
+// mock_test.go
+//
+// offline tests of the mock
+
+package telegrambotmock
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	telegrambot "github.com/meinside/telegram-bot-go"
+)
+
+// a function which depends on a capability, not *telegrambot.Bot
+func greet(ctx context.Context, api telegrambot.MessagingAPI, chatID int64) (messageID int64, err error) {
+	res, err := api.SendMessage(ctx, chatID, "hello", nil)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result.MessageID, nil
+}
+
+// calls should be recorded, and programmed results should be returned.
+func TestMock(t *testing.T) {
+	slog.Info("testing mock...")
+
+	mock := &Mock{}
+	mock.SendMessageFunc = func(ctx context.Context, chatID telegrambot.ChatID, text string, options telegrambot.OptionsSendMessage) (telegrambot.APIResponse[telegrambot.Message], error) {
+		return telegrambot.APIResponse[telegrambot.Message]{OK: true, Result: &telegrambot.Message{MessageID: 42}}, nil
+	}
+
+	if messageID, err := greet(context.TODO(), mock, 12345); err != nil {
+		t.Errorf("failed to greet: %s", err)
+	} else if messageID != 42 {
+		t.Errorf("expected programmed message id, got %d", messageID)
+	}
+	if res, err := mock.DeleteMessage(context.TODO(), int64(12345), 42); err != nil || !res.OK {
+		t.Errorf("unprogrammed method should succeed with a zero result, got: %+v, %v", res, err)
+	}
+
+	calls := mock.CallsOf("SendMessage")
+	if len(calls) != 1 || calls[0].Args[0] != int64(12345) || calls[0].Args[1] != "hello" {
+		t.Errorf("unexpected recorded calls: %+v", calls)
+	}
+	if len(mock.Calls()) != 2 {
+		t.Errorf("expected 2 recorded calls, got %+v", mock.Calls())
+	}
+	mock.Reset()
+	if len(mock.Calls()) != 0 {
+		t.Errorf("recorded calls should be removed after reset")
+	}
+}
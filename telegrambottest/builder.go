@@ -0,0 +1,585 @@
+package telegrambottest
+
+// Fluent builders of realistic updates (for tests)
+//
+//	update := telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/start foo").Build()
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode/utf16"
+
+	bot "github.com/meinside/telegram-bot-go"
+)
+
+const (
+	testBaseDate = 1700000000 // base unix time of dates in built updates
+)
+
+// sequence for ids and dates of built updates
+var testSequence atomic.Int64
+
+// next value of test sequence
+func nextTestSequence() int64 {
+	return testSequence.Add(1)
+}
+
+// User returns a user for tests.
+func User() bot.User {
+	return bot.User{
+		ID:           10001,
+		FirstName:    "Test",
+		LastName:     new("User"),
+		Username:     new("test_user"),
+		LanguageCode: new("en"),
+	}
+}
+
+// PrivateChat returns a private chat with given user for tests.
+func PrivateChat(user bot.User) bot.Chat {
+	return bot.Chat{
+		ID:        user.ID,
+		Type:      bot.ChatTypePrivate,
+		Username:  user.Username,
+		FirstName: &user.FirstName,
+		LastName:  user.LastName,
+	}
+}
+
+// GroupChat returns a supergroup chat for tests.
+func GroupChat() bot.Chat {
+	return bot.Chat{
+		ID:    -1001234567890,
+		Type:  bot.ChatTypeSupergroup,
+		Title: new("Test Group"),
+	}
+}
+
+// ForumChat returns a supergroup chat with topics for tests.
+func ForumChat() bot.Chat {
+	chat := GroupChat()
+	chat.Title = new("Test Forum")
+	chat.IsForum = new(true)
+	return chat
+}
+
+////////////////////////////////
+// UpdateBuilder
+//
+
+// UpdateBuilder builds a telegrambot.Update for tests.
+type UpdateBuilder struct {
+	update bot.Update
+}
+
+// NewUpdate returns a new UpdateBuilder with a consistent `update_id`.
+func NewUpdate() *UpdateBuilder {
+	return &UpdateBuilder{
+		update: bot.Update{
+			UpdateID: nextTestSequence(),
+		},
+	}
+}
+
+// WithID sets the `update_id` of the update.
+func (b *UpdateBuilder) WithID(updateID int64) *UpdateBuilder {
+	b.update.UpdateID = updateID
+	return b
+}
+
+// Message returns a MessageBuilder for the `message` of the update.
+func (b *UpdateBuilder) Message() *MessageBuilder {
+	return newMessageBuilder(b, func(u *bot.Update, m *bot.Message) { u.Message = m })
+}
+
+// EditedMessage returns a MessageBuilder for the `edited_message` of the update.
+func (b *UpdateBuilder) EditedMessage() *MessageBuilder {
+	mb := newMessageBuilder(b, func(u *bot.Update, m *bot.Message) { u.EditedMessage = m })
+	mb.message.EditDate = new(mb.message.Date + 60)
+	return mb
+}
+
+// ChannelPost returns a MessageBuilder for the `channel_post` of the update.
+func (b *UpdateBuilder) ChannelPost() *MessageBuilder {
+	mb := newMessageBuilder(b, func(u *bot.Update, m *bot.Message) { u.ChannelPost = m })
+	mb.chat = &bot.Chat{
+		ID:    -1009876543210,
+		Type:  bot.ChatTypeChannel,
+		Title: new("Test Channel"),
+	}
+	mb.noSender = true
+	return mb
+}
+
+// BusinessMessage returns a MessageBuilder for the `business_message` of the update.
+func (b *UpdateBuilder) BusinessMessage(businessConnectionID string) *MessageBuilder {
+	mb := newMessageBuilder(b, func(u *bot.Update, m *bot.Message) { u.BusinessMessage = m })
+	mb.message.BusinessConnectionID = &businessConnectionID
+	return mb
+}
+
+// CallbackQuery returns a CallbackQueryBuilder for the `callback_query` of the update.
+func (b *UpdateBuilder) CallbackQuery() *CallbackQueryBuilder {
+	return &CallbackQueryBuilder{
+		parent: b,
+		query: bot.CallbackQuery{
+			ID:           testID("callback", b.update.UpdateID),
+			From:         User(),
+			ChatInstance: testID("instance", b.update.UpdateID),
+		},
+	}
+}
+
+// InlineQuery returns an InlineQueryBuilder for the `inline_query` of the update.
+func (b *UpdateBuilder) InlineQuery() *InlineQueryBuilder {
+	return &InlineQueryBuilder{
+		parent: b,
+		query: bot.InlineQuery{
+			ID:   testID("inline", b.update.UpdateID),
+			From: User(),
+		},
+	}
+}
+
+// ChatJoinRequest returns a ChatJoinRequestBuilder for the `chat_join_request` of the update.
+func (b *UpdateBuilder) ChatJoinRequest() *ChatJoinRequestBuilder {
+	user := User()
+	return &ChatJoinRequestBuilder{
+		parent: b,
+		request: bot.ChatJoinRequest{
+			Chat:       GroupChat(),
+			From:       user,
+			UserChatID: user.ID,
+			Date:       testDate(b.update.UpdateID),
+		},
+	}
+}
+
+// ChatMember returns a ChatMemberUpdatedBuilder for the `chat_member` of the update.
+func (b *UpdateBuilder) ChatMember() *ChatMemberUpdatedBuilder {
+	return newChatMemberUpdatedBuilder(b, func(u *bot.Update, m *bot.ChatMemberUpdated) { u.ChatMember = m })
+}
+
+// MyChatMember returns a ChatMemberUpdatedBuilder for the `my_chat_member` of the update.
+func (b *UpdateBuilder) MyChatMember() *ChatMemberUpdatedBuilder {
+	return newChatMemberUpdatedBuilder(b, func(u *bot.Update, m *bot.ChatMemberUpdated) { u.MyChatMember = m })
+}
+
+// Build returns the built Update.
+func (b *UpdateBuilder) Build() bot.Update {
+	return b.update
+}
+
+////////////////////////////////
+// MessageBuilder
+//
+
+// MessageBuilder builds a Message of an Update for tests.
+type MessageBuilder struct {
+	parent *UpdateBuilder
+	assign func(u *bot.Update, m *bot.Message)
+
+	message  bot.Message
+	from     *bot.User
+	chat     *bot.Chat
+	noSender bool
+}
+
+// returns a new MessageBuilder
+func newMessageBuilder(parent *UpdateBuilder, assign func(u *bot.Update, m *bot.Message)) *MessageBuilder {
+	seq := nextTestSequence()
+
+	return &MessageBuilder{
+		parent: parent,
+		assign: assign,
+		message: bot.Message{
+			MessageID: seq,
+			Date:      testDate(seq),
+		},
+	}
+}
+
+// From sets the sender of the message.
+func (b *MessageBuilder) From(user bot.User) *MessageBuilder {
+	b.from = &user
+	b.noSender = false
+	return b
+}
+
+// InChat sets the chat of the message.
+//
+// (default: a private chat with the sender)
+func (b *MessageBuilder) InChat(chat bot.Chat) *MessageBuilder {
+	b.chat = &chat
+	return b
+}
+
+// InThread sets the forum topic (`message_thread_id`) of the message.
+func (b *MessageBuilder) InThread(threadID int64) *MessageBuilder {
+	b.message.MessageThreadID = &threadID
+	b.message.IsTopicMessage = new(true)
+	return b
+}
+
+// WithID sets the `message_id` of the message.
+func (b *MessageBuilder) WithID(messageID int64) *MessageBuilder {
+	b.message.MessageID = messageID
+	return b
+}
+
+// At sets the date of the message.
+func (b *MessageBuilder) At(date time.Time) *MessageBuilder {
+	b.message.Date = int(date.Unix())
+	return b
+}
+
+// Text sets the text of the message.
+//
+// `bot_command` entities in the text will be filled in automatically.
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.message.Text = &text
+	b.message.Entities = append(b.message.Entities, detectBotCommandEntities(text)...)
+	return b
+}
+
+// Entities appends entities of the text.
+func (b *MessageBuilder) Entities(entities ...bot.MessageEntity) *MessageBuilder {
+	b.message.Entities = append(b.message.Entities, entities...)
+	return b
+}
+
+// Caption sets the caption of the message.
+func (b *MessageBuilder) Caption(caption string) *MessageBuilder {
+	b.message.Caption = &caption
+	b.message.CaptionEntities = append(b.message.CaptionEntities, detectBotCommandEntities(caption)...)
+	return b
+}
+
+// Photo sets the photo of the message, with a file id.
+func (b *MessageBuilder) Photo(fileID string) *MessageBuilder {
+	b.message.Photo = []bot.PhotoSize{
+		{
+			FileID:       fileID,
+			FileUniqueID: "unique-" + fileID,
+			Width:        1280,
+			Height:       720,
+		},
+	}
+	return b
+}
+
+// Document sets the document of the message, with a file id and a file name.
+func (b *MessageBuilder) Document(fileID, fileName string) *MessageBuilder {
+	b.message.Document = &bot.Document{
+		FileID:       fileID,
+		FileUniqueID: "unique-" + fileID,
+		FileName:     &fileName,
+	}
+	return b
+}
+
+// Contact sets the shared contact of the message.
+func (b *MessageBuilder) Contact(phoneNumber, firstName string) *MessageBuilder {
+	b.message.Contact = &bot.Contact{
+		PhoneNumber: phoneNumber,
+		FirstName:   firstName,
+	}
+	return b
+}
+
+// Location sets the shared location of the message.
+func (b *MessageBuilder) Location(latitude, longitude float32) *MessageBuilder {
+	b.message.Location = &bot.Location{
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+	return b
+}
+
+// ReplyTo sets the message which this message replies to.
+func (b *MessageBuilder) ReplyTo(message bot.Message) *MessageBuilder {
+	b.message.ReplyToMessage = &message
+	return b
+}
+
+// InMediaGroup sets the media group id of the message.
+func (b *MessageBuilder) InMediaGroup(mediaGroupID string) *MessageBuilder {
+	b.message.MediaGroupID = &mediaGroupID
+	return b
+}
+
+// Message returns the built Message.
+func (b *MessageBuilder) Message() bot.Message {
+	message := b.message
+
+	var from bot.User
+	if b.from != nil {
+		from = *b.from
+	} else {
+		from = User()
+	}
+	if !b.noSender {
+		message.From = &from
+	}
+	if b.chat != nil {
+		message.Chat = *b.chat
+	} else {
+		message.Chat = PrivateChat(from)
+	}
+
+	return message
+}
+
+// Build returns the built Update with the message.
+func (b *MessageBuilder) Build() bot.Update {
+	message := b.Message()
+	update := b.parent.update
+	b.assign(&update, &message)
+	return update
+}
+
+////////////////////////////////
+// CallbackQueryBuilder
+//
+
+// CallbackQueryBuilder builds a CallbackQuery of an Update for tests.
+type CallbackQueryBuilder struct {
+	parent *UpdateBuilder
+	query  bot.CallbackQuery
+}
+
+// From sets the sender of the callback query.
+func (b *CallbackQueryBuilder) From(user bot.User) *CallbackQueryBuilder {
+	b.query.From = user
+	return b
+}
+
+// Data sets the `data` of the callback query.
+func (b *CallbackQueryBuilder) Data(data string) *CallbackQueryBuilder {
+	b.query.Data = &data
+	return b
+}
+
+// OnMessage sets the message with the inline keyboard which originated the callback query.
+func (b *CallbackQueryBuilder) OnMessage(message bot.Message) *CallbackQueryBuilder {
+	b.query.Message = (*bot.MaybeInaccessibleMessage)(&message)
+	return b
+}
+
+// OnInaccessibleMessage sets an inaccessible message which originated the callback query.
+func (b *CallbackQueryBuilder) OnInaccessibleMessage(chat bot.Chat, messageID int64) *CallbackQueryBuilder {
+	b.query.Message = &bot.MaybeInaccessibleMessage{
+		Chat:      chat,
+		MessageID: messageID,
+		Date:      0, // NOTE: always 0 for inaccessible messages
+	}
+	return b
+}
+
+// OnInlineMessage sets the id of an inline message which originated the callback query.
+func (b *CallbackQueryBuilder) OnInlineMessage(inlineMessageID string) *CallbackQueryBuilder {
+	b.query.InlineMessageID = &inlineMessageID
+	return b
+}
+
+// CallbackQuery returns the built CallbackQuery.
+func (b *CallbackQueryBuilder) CallbackQuery() bot.CallbackQuery {
+	return b.query
+}
+
+// Build returns the built Update with the callback query.
+func (b *CallbackQueryBuilder) Build() bot.Update {
+	query := b.query
+	update := b.parent.update
+	update.CallbackQuery = &query
+	return update
+}
+
+////////////////////////////////
+// InlineQueryBuilder
+//
+
+// InlineQueryBuilder builds an InlineQuery of an Update for tests.
+type InlineQueryBuilder struct {
+	parent *UpdateBuilder
+	query  bot.InlineQuery
+}
+
+// From sets the sender of the inline query.
+func (b *InlineQueryBuilder) From(user bot.User) *InlineQueryBuilder {
+	b.query.From = user
+	return b
+}
+
+// Query sets the text of the inline query.
+func (b *InlineQueryBuilder) Query(query string) *InlineQueryBuilder {
+	b.query.Query = query
+	return b
+}
+
+// Offset sets the offset of the inline query.
+func (b *InlineQueryBuilder) Offset(offset string) *InlineQueryBuilder {
+	b.query.Offset = offset
+	return b
+}
+
+// ChatType sets the type of the chat where the inline query was sent from.
+func (b *InlineQueryBuilder) ChatType(chatType bot.ChatType) *InlineQueryBuilder {
+	b.query.ChatType = new(string(chatType))
+	return b
+}
+
+// Build returns the built Update with the inline query.
+func (b *InlineQueryBuilder) Build() bot.Update {
+	query := b.query
+	update := b.parent.update
+	update.InlineQuery = &query
+	return update
+}
+
+////////////////////////////////
+// ChatJoinRequestBuilder
+//
+
+// ChatJoinRequestBuilder builds a ChatJoinRequest of an Update for tests.
+type ChatJoinRequestBuilder struct {
+	parent  *UpdateBuilder
+	request bot.ChatJoinRequest
+}
+
+// From sets the user who sent the join request.
+func (b *ChatJoinRequestBuilder) From(user bot.User) *ChatJoinRequestBuilder {
+	b.request.From = user
+	b.request.UserChatID = user.ID
+	return b
+}
+
+// InChat sets the chat which the join request was sent to.
+func (b *ChatJoinRequestBuilder) InChat(chat bot.Chat) *ChatJoinRequestBuilder {
+	b.request.Chat = chat
+	return b
+}
+
+// Bio sets the bio of the user who sent the join request.
+func (b *ChatJoinRequestBuilder) Bio(bio string) *ChatJoinRequestBuilder {
+	b.request.Bio = &bio
+	return b
+}
+
+// Build returns the built Update with the chat join request.
+func (b *ChatJoinRequestBuilder) Build() bot.Update {
+	request := b.request
+	update := b.parent.update
+	update.ChatJoinRequest = &request
+	return update
+}
+
+////////////////////////////////
+// ChatMemberUpdatedBuilder
+//
+
+// ChatMemberUpdatedBuilder builds a ChatMemberUpdated of an Update for tests.
+type ChatMemberUpdatedBuilder struct {
+	parent  *UpdateBuilder
+	assign  func(u *bot.Update, m *bot.ChatMemberUpdated)
+	updated bot.ChatMemberUpdated
+}
+
+// returns a new ChatMemberUpdatedBuilder
+func newChatMemberUpdatedBuilder(parent *UpdateBuilder, assign func(u *bot.Update, m *bot.ChatMemberUpdated)) *ChatMemberUpdatedBuilder {
+	user := User()
+
+	return &ChatMemberUpdatedBuilder{
+		parent: parent,
+		assign: assign,
+		updated: bot.ChatMemberUpdated{
+			Chat: GroupChat(),
+			From: user,
+			Date: testDate(parent.update.UpdateID),
+			OldChatMember: bot.ChatMember{
+				Status: bot.ChatMemberStatusLeft,
+				User:   user,
+			},
+			NewChatMember: bot.ChatMember{
+				Status: bot.ChatMemberStatusMember,
+				User:   user,
+			},
+		},
+	}
+}
+
+// From sets the user who changed the member's status.
+func (b *ChatMemberUpdatedBuilder) From(user bot.User) *ChatMemberUpdatedBuilder {
+	b.updated.From = user
+	return b
+}
+
+// InChat sets the chat where the member's status was changed.
+func (b *ChatMemberUpdatedBuilder) InChat(chat bot.Chat) *ChatMemberUpdatedBuilder {
+	b.updated.Chat = chat
+	return b
+}
+
+// Change sets the old and new status of the member.
+func (b *ChatMemberUpdatedBuilder) Change(oldMember, newMember bot.ChatMember) *ChatMemberUpdatedBuilder {
+	b.updated.OldChatMember = oldMember
+	b.updated.NewChatMember = newMember
+	return b
+}
+
+// Build returns the built Update with the chat member update.
+func (b *ChatMemberUpdatedBuilder) Build() bot.Update {
+	updated := b.updated
+	update := b.parent.update
+	b.assign(&update, &updated)
+	return update
+}
+
+////////////////////////////////
+// helper functions
+//
+
+// generate a test id string with given prefix and sequence
+func testID(prefix string, seq int64) string {
+	return prefix + "-" + strconv.FormatInt(seq, 10)
+}
+
+// generate a consistent unix time for given sequence
+func testDate(seq int64) int {
+	return testBaseDate + int(seq)
+}
+
+// detect bot commands (eg. "/start", "/help@bot") in given text as entities
+func detectBotCommandEntities(text string) (entities []bot.MessageEntity) {
+	offset := 0 // in UTF-16 code units
+	atWordStart := true
+	for i, r := range text {
+		if atWordStart && r == '/' {
+			end := i + 1
+			for end < len(text) && isBotCommandChar(text[end]) {
+				end++
+			}
+			if end > i+1 {
+				entities = append(entities, bot.NewMessageEntity(bot.MessageEntityTypeBotCommand, offset, utf16Length(text[i:end])))
+			}
+		}
+
+		atWordStart = r == ' ' || r == '\n' || r == '\t'
+		offset += utf16Length(string(r))
+	}
+	return entities
+}
+
+// check if given byte can be a part of a bot command
+func isBotCommandChar(c byte) bool {
+	return c == '_' || c == '@' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+// length of given string in UTF-16 code units
+func utf16Length(str string) int {
+	return len(utf16.Encode([]rune(str)))
+}
@@ -0,0 +1,64 @@
+// builder_test.go
+//
+// offline tests of fluent builders of updates
+
+package telegrambottest
+
+import (
+	"log/slog"
+	"testing"
+
+	bot "github.com/meinside/telegram-bot-go"
+)
+
+// built updates should have consistent ids, dates, and bot_command entities.
+func TestBuildUpdates(t *testing.T) {
+	slog.Info("testing building of updates...")
+
+	user := User()
+	group := ForumChat()
+
+	first := NewUpdate().Message().From(user).InChat(group).InThread(3).Text("hi 🤖 /start@test_bot foo").Build()
+	second := NewUpdate().Message().Text("hello").Build()
+
+	if second.UpdateID <= first.UpdateID {
+		t.Errorf("expected increasing update ids, got %d and %d", first.UpdateID, second.UpdateID)
+	}
+	if second.Message.MessageID <= first.Message.MessageID || second.Message.Date <= first.Message.Date {
+		t.Errorf("expected increasing message ids and dates, got: %+v, %+v", first.Message, second.Message)
+	}
+	if first.Message.Chat.ID != group.ID || *first.Message.MessageThreadID != 3 || first.Message.From.ID != user.ID {
+		t.Errorf("unexpected message: %+v", first.Message)
+	}
+	if second.Message.Chat.Type != bot.ChatTypePrivate || second.Message.Chat.ID != second.Message.From.ID {
+		t.Errorf("expected a private chat with the sender, got: %+v", second.Message.Chat)
+	}
+
+	// "🤖" is 2 UTF-16 code units long
+	if len(first.Message.Entities) != 1 {
+		t.Fatalf("expected 1 entity, got: %+v", first.Message.Entities)
+	}
+	if entity := first.Message.Entities[0]; entity.Type != bot.MessageEntityTypeBotCommand || entity.Offset != 6 || entity.Length != 15 {
+		t.Errorf("unexpected bot_command entity: %+v", entity)
+	}
+
+	// callback query on an inaccessible message
+	query := NewUpdate().CallbackQuery().Data("data").OnInaccessibleMessage(group, 7).Build()
+	if !query.HasCallbackQuery() || *query.CallbackQuery.Data != "data" || !query.CallbackQuery.Message.IsInaccessible() {
+		t.Errorf("unexpected callback query: %+v", query.CallbackQuery)
+	}
+
+	// other variants
+	if update := NewUpdate().InlineQuery().Query("cats").Build(); update.InlineQuery.Query != "cats" {
+		t.Errorf("unexpected inline query: %+v", update.InlineQuery)
+	}
+	if update := NewUpdate().ChatJoinRequest().Bio("hello").Build(); *update.ChatJoinRequest.Bio != "hello" {
+		t.Errorf("unexpected chat join request: %+v", update.ChatJoinRequest)
+	}
+	if update := NewUpdate().MyChatMember().Build(); update.MyChatMember.NewChatMember.Status != bot.ChatMemberStatusMember {
+		t.Errorf("unexpected my chat member: %+v", update.MyChatMember)
+	}
+	if update := NewUpdate().ChannelPost().Text("news").Build(); update.ChannelPost.From != nil || update.ChannelPost.Chat.Type != bot.ChatTypeChannel {
+		t.Errorf("unexpected channel post: %+v", update.ChannelPost)
+	}
+}
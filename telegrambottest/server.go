@@ -0,0 +1,653 @@
+// Package telegrambottest provides a fake Telegram Bot API server for offline tests.
+//
+// The server records every call with its decoded params (including multipart files),
+// replies with scripted responses or errors, and serves injected updates
+// through `getUpdates` or posts them to a webhook handler.
+//
+//	server := telegrambottest.NewServer()
+//	defer server.Close()
+//
+//	client := server.NewClient()
+//	client.SendMessage(ctx, 12345, "hello", nil)
+//
+//	call, _ := server.LastCall("sendMessage") // call.Params["text"] == "hello"
+package telegrambottest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bot "github.com/meinside/telegram-bot-go"
+)
+
+const (
+	// DefaultToken is the bot token used by NewServer().NewClient().
+	DefaultToken = "1234567890:TEST-telegram-bot-go-fake-token"
+
+	maxMultipartMemory = 32 << 20 // 32MB
+
+	maxLongPollSeconds = 10
+)
+
+// Call is a recorded API call.
+type Call struct {
+	Method string            // name of the API method (eg. "sendMessage")
+	Token  string            // bot token in the request url
+	Params map[string]string // decoded form values
+	Files  map[string]File   // multipart files, by form field name
+	Time   time.Time         // when the call was received
+}
+
+// Param returns the value of param with given key ("" if not exists).
+func (c Call) Param(key string) string {
+	return c.Params[key]
+}
+
+// HasParam checks if the call has a param with given key.
+func (c Call) HasParam(key string) bool {
+	_, exists := c.Params[key]
+	return exists
+}
+
+// DecodeParam decodes a JSON-encoded param (eg. `reply_markup`) into `v`.
+func (c Call) DecodeParam(key string, v any) error {
+	param, exists := c.Params[key]
+	if !exists {
+		return fmt.Errorf("no such param: %s", key)
+	}
+	return json.Unmarshal([]byte(param), v)
+}
+
+// File is a file uploaded with a multipart request.
+type File struct {
+	Filename string
+	Data     []byte
+}
+
+// Response is a scripted response of the fake server.
+type Response struct {
+	StatusCode  int
+	OK          bool
+	Result      any
+	ErrorCode   int
+	Description string
+	Parameters  *bot.APIResponseParameters
+}
+
+// OK returns a successful Response with given result.
+func OK(result any) Response {
+	return Response{
+		StatusCode: http.StatusOK,
+		OK:         true,
+		Result:     result,
+	}
+}
+
+// Error returns a failed Response with given error code and description.
+func Error(code int, description string) Response {
+	return Response{
+		StatusCode:  code,
+		OK:          false,
+		ErrorCode:   code,
+		Description: description,
+	}
+}
+
+// TooManyRequests returns a failed Response for flood limits (429) with `retry_after`.
+func TooManyRequests(retryAfter int) Response {
+	res := Error(http.StatusTooManyRequests, fmt.Sprintf("Too Many Requests: retry after %d", retryAfter))
+	res.Parameters = &bot.APIResponseParameters{
+		RetryAfter: &retryAfter,
+	}
+	return res
+}
+
+// BlockedByUser returns a failed Response (403) for a bot blocked by the user.
+func BlockedByUser() Response {
+	return Error(http.StatusForbidden, "Forbidden: bot was blocked by the user")
+}
+
+// ChatNotFound returns a failed Response (400) for a chat which does not exist.
+func ChatNotFound() Response {
+	return Error(http.StatusBadRequest, "Bad Request: chat not found")
+}
+
+// Responder generates a Response for given call.
+type Responder func(call Call) Response
+
+// Server is a fake Telegram Bot API server.
+type Server struct {
+	*httptest.Server
+
+	// Me is returned from `getMe` (and used as the sender of messages).
+	Me bot.User
+
+	mu sync.Mutex
+
+	calls      []Call
+	scripted   map[string][]Response // one-shot responses, by method
+	responders map[string]Responder  // persistent responders, by method
+	files      map[string][]byte     // downloadable files, by file path
+
+	updates      []bot.Update
+	nextUpdateID int64
+	updated      chan struct{} // notified on newly injected updates
+
+	nextMessageID int64
+	called        chan struct{} // notified on new calls
+}
+
+// NewServer starts and returns a new fake Bot API server.
+//
+// NOTE: Close it after use.
+func NewServer() *Server {
+	s := &Server{
+		Me: bot.User{
+			ID:        botIDFromToken(DefaultToken),
+			IsBot:     true,
+			FirstName: "Test Bot",
+			Username:  new("test_bot"),
+		},
+
+		scripted:   map[string][]Response{},
+		responders: map[string]Responder{},
+		files:      map[string][]byte{},
+
+		nextUpdateID: 1,
+		updated:      make(chan struct{}),
+
+		nextMessageID: 1,
+		called:        make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// NewClient returns a new bot client which sends requests to this server.
+func (s *Server) NewClient() *bot.Bot {
+	return s.NewClientWithToken(DefaultToken)
+}
+
+// NewClientWithToken returns a new bot client with given token which sends requests to this server.
+func (s *Server) NewClientWithToken(token string) *bot.Bot {
+	client := bot.NewClient(token)
+	client.SetBaseURL(s.URL)
+	return client
+}
+
+// Enqueue appends one-shot responses for given method.
+//
+// They will be returned in order, before falling back to the method's responder.
+func (s *Server) Enqueue(method string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripted[method] = append(s.scripted[method], responses...)
+}
+
+// Handle sets a responder for given method, replacing the default one.
+func (s *Server) Handle(method string, responder Responder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responders[method] = responder
+}
+
+// AddFile adds a file which can be downloaded from `Bot.GetFileURL()`.
+func (s *Server) AddFile(filePath string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[filePath] = data
+}
+
+// InjectUpdates queues updates which will be returned from `getUpdates`.
+//
+// `update_id`s will be assigned to updates without one.
+func (s *Server) InjectUpdates(updates ...bot.Update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, update := range updates {
+		if update.UpdateID == 0 {
+			update.UpdateID = s.nextUpdateID
+		}
+		if update.UpdateID >= s.nextUpdateID {
+			s.nextUpdateID = update.UpdateID + 1
+		}
+		s.updates = append(s.updates, update)
+	}
+
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// DeliverWebhook posts given update to a webhook handler (eg. `Bot.WebhookHandler()`),
+// and returns the http status code of the result.
+func (s *Server) DeliverWebhook(handler http.Handler, update bot.Update) (statusCode int, err error) {
+	var body []byte
+	if body, err = json.Marshal(update); err != nil {
+		return 0, fmt.Errorf("failed to marshal update: %w", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder.Code, nil
+}
+
+// PostWebhook posts given update to a running webhook server at `url`.
+func (s *Server) PostWebhook(ctx context.Context, url string, update bot.Update) (err error) {
+	var body []byte
+	if body, err = json.Marshal(update); err != nil {
+		return fmt.Errorf("failed to marshal update: %w", err)
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp *http.Response
+	if resp, err = s.Client().Do(req); err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook responded with http status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Calls returns all recorded calls.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call{}, s.calls...)
+}
+
+// CallsTo returns recorded calls to given method.
+func (s *Server) CallsTo(method string) (calls []Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, call := range s.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// LastCall returns the last recorded call to given method.
+func (s *Server) LastCall(method string) (call Call, exists bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.calls) - 1; i >= 0; i-- {
+		if s.calls[i].Method == method {
+			return s.calls[i], true
+		}
+	}
+	return Call{}, false
+}
+
+// WaitForCall waits for the `n`th (1-based) call to given method,
+// which is useful when calls are made from handler goroutines.
+func (s *Server) WaitForCall(method string, n int, timeout time.Duration) (call Call, err error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		count := 0
+		for _, c := range s.calls {
+			if c.Method == method {
+				count++
+				if count == n {
+					s.mu.Unlock()
+					return c, nil
+				}
+			}
+		}
+		called := s.called
+		s.mu.Unlock()
+
+		select {
+		case <-called:
+		case <-timer.C:
+			return Call{}, fmt.Errorf("timed out waiting for call #%d to %s (got %d)", n, method, count)
+		}
+	}
+}
+
+// Reset clears recorded calls, scripted responses, responders, and pending updates.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = nil
+	s.scripted = map[string][]Response{}
+	s.responders = map[string]Responder{}
+	s.updates = nil
+}
+
+// serve http requests
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// file downloads: /file/bot<token>/<file_path>
+	if rest, ok := strings.CutPrefix(r.URL.Path, "/file/bot"); ok {
+		s.serveFile(w, rest)
+		return
+	}
+
+	// api calls: /bot<token>/<method>
+	rest, ok := strings.CutPrefix(r.URL.Path, "/bot")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	token, method, ok := strings.Cut(rest, "/")
+	if !ok || method == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	call, err := decodeCall(r)
+	if err != nil {
+		writeResponse(w, Error(http.StatusBadRequest, "Bad Request: "+err.Error()))
+		return
+	}
+	call.Method = method
+	call.Token = token
+	call.Time = time.Now()
+
+	s.record(call)
+
+	writeResponse(w, s.respond(r.Context(), call))
+}
+
+// serve a downloadable file
+func (s *Server) serveFile(w http.ResponseWriter, rest string) {
+	_, filePath, _ := strings.Cut(rest, "/")
+
+	s.mu.Lock()
+	data, exists := s.files[filePath]
+	s.mu.Unlock()
+
+	if !exists {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+// record a call and notify waiters
+func (s *Server) record(call Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+
+	close(s.called)
+	s.called = make(chan struct{})
+}
+
+// generate a response for given call
+func (s *Server) respond(ctx context.Context, call Call) Response {
+	s.mu.Lock()
+	if scripted := s.scripted[call.Method]; len(scripted) > 0 {
+		s.scripted[call.Method] = scripted[1:]
+		s.mu.Unlock()
+		return scripted[0]
+	}
+	responder, exists := s.responders[call.Method]
+	s.mu.Unlock()
+
+	if exists {
+		return responder(call)
+	}
+
+	return s.respondDefault(ctx, call)
+}
+
+// default responses for methods without scripted responses nor responders
+func (s *Server) respondDefault(ctx context.Context, call Call) Response {
+	switch call.Method {
+	case "getMe":
+		return OK(s.Me)
+	case "getUpdates":
+		return OK(s.pollUpdates(ctx, call))
+	case "sendMessage", "sendPhoto", "sendAudio", "sendDocument", "sendVideo",
+		"sendAnimation", "sendVoice", "sendVideoNote", "sendSticker", "sendLocation",
+		"sendVenue", "sendContact", "sendPoll", "sendDice", "sendRichMessage",
+		"forwardMessage", "sendGame", "sendInvoice":
+		return OK(s.newMessage(call))
+	case "copyMessage":
+		return OK(bot.MessageID{MessageID: s.newMessageID()})
+	case "editMessageText", "editMessageCaption", "editMessageMedia", "editMessageReplyMarkup":
+		if call.HasParam("inline_message_id") {
+			return OK(true)
+		}
+		message := s.newMessage(call)
+		if messageID, err := strconv.ParseInt(call.Param("message_id"), 10, 64); err == nil {
+			message.MessageID = messageID
+		}
+		return OK(message)
+	}
+
+	return OK(true)
+}
+
+// return pending updates for `getUpdates`, waiting for `timeout` seconds if there is none
+func (s *Server) pollUpdates(ctx context.Context, call Call) []bot.Update {
+	offset, _ := strconv.ParseInt(call.Param("offset"), 10, 64)
+	limit, err := strconv.Atoi(call.Param("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	timeout, _ := strconv.Atoi(call.Param("timeout"))
+	timeout = min(timeout, maxLongPollSeconds)
+
+	deadline := time.After(time.Duration(timeout) * time.Second)
+	for {
+		s.mu.Lock()
+
+		// forget confirmed updates
+		if offset > 0 {
+			pending := []bot.Update{}
+			for _, update := range s.updates {
+				if update.UpdateID >= offset {
+					pending = append(pending, update)
+				}
+			}
+			s.updates = pending
+		}
+
+		if len(s.updates) > 0 || timeout <= 0 {
+			updates := append([]bot.Update{}, s.updates[:min(limit, len(s.updates))]...)
+			s.mu.Unlock()
+			return updates
+		}
+		updated := s.updated
+		s.mu.Unlock()
+
+		select {
+		case <-updated:
+		case <-deadline:
+			return []bot.Update{}
+		case <-ctx.Done():
+			return []bot.Update{}
+		}
+	}
+}
+
+// generate a new message id
+func (s *Server) newMessageID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextMessageID
+	s.nextMessageID++
+	return id
+}
+
+// build a sent message from params of given call
+func (s *Server) newMessage(call Call) bot.Message {
+	message := bot.Message{
+		MessageID: s.newMessageID(),
+		From:      &s.Me,
+		Date:      int(time.Now().Unix()),
+		Chat:      chatFromParam(call.Param("chat_id")),
+	}
+	if call.HasParam("message_thread_id") {
+		if threadID, err := strconv.ParseInt(call.Param("message_thread_id"), 10, 64); err == nil {
+			message.MessageThreadID = &threadID
+		}
+	}
+	if call.HasParam("text") {
+		message.Text = new(call.Param("text"))
+		_ = call.DecodeParam("entities", &message.Entities)
+	}
+	if call.HasParam("caption") {
+		message.Caption = new(call.Param("caption"))
+		_ = call.DecodeParam("caption_entities", &message.CaptionEntities)
+	}
+	if call.HasParam("reply_markup") {
+		var markup bot.InlineKeyboardMarkup
+		if err := call.DecodeParam("reply_markup", &markup); err == nil && len(markup.InlineKeyboard) > 0 {
+			message.ReplyMarkup = &markup
+		}
+	}
+
+	return message
+}
+
+// build a chat from `chat_id` param
+func chatFromParam(chatID string) bot.Chat {
+	if id, err := strconv.ParseInt(chatID, 10, 64); err == nil {
+		typ := bot.ChatTypePrivate
+		if strings.HasPrefix(chatID, "-100") {
+			typ = bot.ChatTypeSupergroup
+		} else if id < 0 {
+			typ = bot.ChatTypeGroup
+		}
+		return bot.Chat{
+			ID:   id,
+			Type: typ,
+		}
+	}
+
+	// "@channelusername"
+	return bot.Chat{
+		ID:       -1,
+		Type:     bot.ChatTypeChannel,
+		Username: new(strings.TrimPrefix(chatID, "@")),
+	}
+}
+
+// decode params and files of a request
+func decodeCall(r *http.Request) (call Call, err error) {
+	call.Params = map[string]string{}
+	call.Files = map[string]File{}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err = r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return call, fmt.Errorf("failed to parse multipart form: %w", err)
+		}
+		for key, values := range r.MultipartForm.Value {
+			if len(values) > 0 {
+				call.Params[key] = values[0]
+			}
+		}
+		for key, headers := range r.MultipartForm.File {
+			if len(headers) == 0 {
+				continue
+			}
+			var file File
+			if file, err = readFile(headers[0]); err != nil {
+				return call, err
+			}
+			call.Files[key] = file
+		}
+	} else {
+		if err = r.ParseForm(); err != nil {
+			return call, fmt.Errorf("failed to parse form: %w", err)
+		}
+		for key, values := range r.PostForm {
+			if len(values) > 0 {
+				call.Params[key] = values[0]
+			}
+		}
+	}
+
+	return call, nil
+}
+
+// read an uploaded file
+func readFile(header *multipart.FileHeader) (file File, err error) {
+	var f multipart.File
+	if f, err = header.Open(); err != nil {
+		return file, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var data []byte
+	if data, err = io.ReadAll(f); err != nil {
+		return file, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	return File{
+		Filename: header.Filename,
+		Data:     data,
+	}, nil
+}
+
+// write a response as JSON
+func writeResponse(w http.ResponseWriter, res Response) {
+	body := map[string]any{
+		"ok": res.OK,
+	}
+	if res.OK {
+		body["result"] = res.Result
+	} else {
+		body["error_code"] = res.ErrorCode
+		body["description"] = res.Description
+	}
+	if res.Parameters != nil {
+		body["parameters"] = res.Parameters
+	}
+
+	statusCode := res.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// get bot id from given token ("<bot id>:<secret>")
+func botIDFromToken(token string) int64 {
+	idStr, _, _ := strings.Cut(token, ":")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	return id
+}
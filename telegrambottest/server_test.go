@@ -0,0 +1,198 @@
+// server_test.go
+//
+// offline tests of the fake Bot API server
+
+package telegrambottest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	bot "github.com/meinside/telegram-bot-go"
+)
+
+// calls should be recorded with their decoded params.
+func TestServerRecordsCalls(t *testing.T) {
+	slog.Info("testing recording of calls...")
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	sent, err := client.SendMessage(
+		context.TODO(),
+		12345,
+		"hello",
+		bot.OptionsSendMessage{}.
+			SetReplyMarkup(bot.NewInlineKeyboardMarkup(bot.NewInlineKeyboardButtonsAsRowsWithCallbackData(map[string]string{
+				"button": "data",
+			}))),
+	)
+	if err != nil {
+		t.Fatalf("failed to send message: %s", err)
+	}
+	if sent.Result.Chat.ID != 12345 || *sent.Result.Text != "hello" {
+		t.Errorf("unexpected sent message: %+v", sent.Result)
+	}
+
+	call, exists := server.LastCall("sendMessage")
+	if !exists {
+		t.Fatalf("expected a recorded call to sendMessage")
+	}
+	if call.Token != DefaultToken {
+		t.Errorf("expected token %q, got %q", DefaultToken, call.Token)
+	}
+	if call.Param("chat_id") != "12345" || call.Param("text") != "hello" {
+		t.Errorf("unexpected params: %+v", call.Params)
+	}
+
+	var markup bot.InlineKeyboardMarkup
+	if err := call.DecodeParam("reply_markup", &markup); err != nil {
+		t.Fatalf("failed to decode reply markup: %s", err)
+	}
+	if *markup.InlineKeyboard[0][0].CallbackData != "data" {
+		t.Errorf("unexpected reply markup: %+v", markup)
+	}
+}
+
+// files in multipart requests should be recorded.
+func TestServerRecordsFiles(t *testing.T) {
+	slog.Info("testing recording of multipart files...")
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	data := []byte("\x89PNG\r\n\x1a\n fake image")
+	if _, err := client.SendPhoto(
+		context.TODO(),
+		12345,
+		bot.NewInputFileFromBytes(data),
+		bot.OptionsSendPhoto{}.
+			SetCaption("a photo"),
+	); err != nil {
+		t.Fatalf("failed to send photo: %s", err)
+	}
+
+	call, _ := server.LastCall("sendPhoto")
+	if call.Param("caption") != "a photo" {
+		t.Errorf("unexpected caption: %q", call.Param("caption"))
+	}
+	if file, exists := call.Files["photo"]; !exists {
+		t.Errorf("expected an uploaded file, got: %+v", call.Files)
+	} else if string(file.Data) != string(data) {
+		t.Errorf("unexpected file data: %q", file.Data)
+	}
+}
+
+// scripted responses should be returned in order, then fall back to defaults.
+func TestServerScriptedResponses(t *testing.T) {
+	slog.Info("testing scripted responses...")
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	server.Enqueue("sendMessage", TooManyRequests(3), BlockedByUser())
+
+	res, err := client.SendMessage(context.TODO(), 12345, "first", nil)
+	if err == nil {
+		t.Fatalf("expected an error from flood limit")
+	}
+	if res.Parameters == nil || res.Parameters.RetryAfter == nil || *res.Parameters.RetryAfter != 3 {
+		t.Errorf("expected `retry_after` = 3, got: %+v", res.Parameters)
+	}
+
+	if _, err = client.SendMessage(context.TODO(), 12345, "second", nil); err == nil {
+		t.Fatalf("expected an error from a blocked bot")
+	}
+
+	if _, err = client.SendMessage(context.TODO(), 12345, "third", nil); err != nil {
+		t.Errorf("expected a default response, got error: %s", err)
+	}
+
+	server.Handle("getChat", func(call Call) Response {
+		return ChatNotFound()
+	})
+	if _, err = client.GetChat(context.TODO(), 12345); err == nil {
+		t.Errorf("expected an error from a responder")
+	} else if _, ok := errors.AsType[bot.ErrChatNotFound](err); !ok {
+		t.Errorf("expected `ErrChatNotFound` but got: %[1]s (%[1]T)", err)
+	}
+
+	if calls := server.CallsTo("sendMessage"); len(calls) != 3 {
+		t.Errorf("expected 3 calls to sendMessage, got %d", len(calls))
+	}
+}
+
+// injected updates should be polled and dispatched to handlers.
+func TestServerInjectedUpdates(t *testing.T) {
+	slog.Info("testing polling of injected updates...")
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+	client.AddCommandHandler("/start", func(b *bot.Bot, update bot.Update, args string) {
+		_, _ = b.SendMessage(context.TODO(), update.Message.Chat.ID, "started with: "+args, nil)
+	})
+
+	server.InjectUpdates(bot.Update{
+		Message: &bot.Message{
+			MessageID: 1,
+			Chat:      bot.Chat{ID: 12345, Type: bot.ChatTypePrivate},
+			Text:      new("/start foo"),
+		},
+	})
+
+	go client.StartPollingUpdates(0, 0, func(b *bot.Bot, update bot.Update, err error) {})
+	defer client.StopPollingUpdates()
+
+	call, err := server.WaitForCall("sendMessage", 1, 5*time.Second)
+	if err != nil {
+		t.Fatalf("failed to wait for a reply: %s", err)
+	}
+	if call.Param("text") != "started with: foo" {
+		t.Errorf("unexpected reply: %q", call.Param("text"))
+	}
+}
+
+// updates should be delivered to webhook handlers.
+func TestServerDeliverWebhook(t *testing.T) {
+	slog.Info("testing delivery of webhooks...")
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	received := make(chan bot.Update, 1)
+	handler := client.WebhookHandler(func(b *bot.Bot, webhook bot.Update, err error) {
+		received <- webhook
+	})
+
+	if status, err := server.DeliverWebhook(handler, bot.Update{
+		UpdateID: 42,
+		Message: &bot.Message{
+			Chat: bot.Chat{ID: 12345, Type: bot.ChatTypePrivate},
+			Text: new("hello"),
+		},
+	}); err != nil || status != 200 {
+		t.Fatalf("failed to deliver webhook: %d, %v", status, err)
+	}
+
+	select {
+	case update := <-received:
+		if update.UpdateID != 42 || *update.Message.Text != "hello" {
+			t.Errorf("unexpected update: %+v", update)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for a webhook")
+	}
+}
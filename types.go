@@ -9,6 +9,10 @@ import (
 
 // ChatID can be `Message.Chat.Id`,
 // or target channel name (in string, eg. "@channelusername")
+//
+// NOTE: Unlike other unions, ChatID cannot be sealed with a marker interface,
+// as methods cannot be declared on the built-in int64 and string types
+// (so are params of `InputFile or String`, eg. `thumbnail` and `cover` of options).
 type ChatID any
 
 // ChatType is a type of Chat
@@ -16,9 +20,10 @@ type ChatType string
 
 // ChatType strings
 const (
-	ChatTypePrivate ChatType = "private"
-	ChatTypeGroup   ChatType = "group"
-	ChatTypeChannel ChatType = "channel"
+	ChatTypePrivate    ChatType = "private"
+	ChatTypeGroup      ChatType = "group"
+	ChatTypeSupergroup ChatType = "supergroup"
+	ChatTypeChannel    ChatType = "channel"
 )
 
 // ParseMode is a mode of parse
@@ -115,8 +120,9 @@ const (
 // ChatMemberStatus is a status of chat member
 //
 // NOTE: When the API adds a new chat member status, add its string to the
-// const block below, add any new fields to the flat ChatMember struct, and
-// add a corresponding ChatMemberXXX variant struct.
+// const block below, add any new fields to the flat ChatMember struct,
+// add a corresponding ChatMemberXXX variant struct, and register it in
+// newChatMemberOfStatus() in chat_member.go.
 //
 // https://core.telegram.org/bots/api#chatmember
 type ChatMemberStatus string
@@ -275,6 +281,8 @@ type Update struct {
 	RemovedChatBoost        *ChatBoostRemoved            `json:"removed_chat_boost,omitempty"`
 	ManagedBot              *ManagedBotUpdated           `json:"managed_bot,omitempty"`
 	Subscription            *BotSubscriptionUpdated      `json:"subscription,omitempty"`
+
+	raw string // original json of the update (kept with `SetKeepRawJSON`)
 }
 
 // AllowedUpdate is a type for 'allowed_updates'
@@ -431,7 +439,16 @@ const (
 // InputMediaLivePhoto, InputMediaPhoto, or InputMediaVideo.
 //
 // https://core.telegram.org/bots/api#inputmedia
-type InputMedia any
+type InputMedia interface {
+	inputMedia()
+}
+
+func (InputMediaAnimation) inputMedia() {}
+func (InputMediaAudio) inputMedia()     {}
+func (InputMediaDocument) inputMedia()  {}
+func (InputMediaLivePhoto) inputMedia() {}
+func (InputMediaPhoto) inputMedia()     {}
+func (InputMediaVideo) inputMedia()     {}
 
 // InputPollMedia represents the content of a poll description or a quiz explanation to be sent.
 //
@@ -441,7 +458,18 @@ type InputMedia any
 // InputMediaVenue, or InputMediaVideo.
 //
 // https://core.telegram.org/bots/api#inputpollmedia
-type InputPollMedia any
+type InputPollMedia interface {
+	inputPollMedia()
+}
+
+func (InputMediaAnimation) inputPollMedia() {}
+func (InputMediaAudio) inputPollMedia()     {}
+func (InputMediaDocument) inputPollMedia()  {}
+func (InputMediaLivePhoto) inputPollMedia() {}
+func (InputMediaLocation) inputPollMedia()  {}
+func (InputMediaPhoto) inputPollMedia()     {}
+func (InputMediaVenue) inputPollMedia()     {}
+func (InputMediaVideo) inputPollMedia()     {}
 
 // InputPollOptionMedia represents the content of a poll option to be sent.
 //
@@ -450,7 +478,18 @@ type InputPollMedia any
 // InputMediaPhoto, InputMediaSticker, InputMediaVenue, or InputMediaVideo.
 //
 // https://core.telegram.org/bots/api#inputpolloptionmedia
-type InputPollOptionMedia any
+type InputPollOptionMedia interface {
+	inputPollOptionMedia()
+}
+
+func (InputMediaAnimation) inputPollOptionMedia() {}
+func (InputMediaLink) inputPollOptionMedia()      {}
+func (InputMediaLivePhoto) inputPollOptionMedia() {}
+func (InputMediaLocation) inputPollOptionMedia()  {}
+func (InputMediaPhoto) inputPollOptionMedia()     {}
+func (InputMediaSticker) inputPollOptionMedia()   {}
+func (InputMediaVenue) inputPollOptionMedia()     {}
+func (InputMediaVideo) inputPollOptionMedia()     {}
 
 // InputMediaAnimation is a struct of an animation
 //
@@ -610,10 +649,16 @@ type InputFile struct {
 	FileID   *string
 }
 
-// InputPaidMedia can be one of `InputPaidMediaPhoto` or `InputPaidMediaVideo`
+// InputPaidMedia can be one of `InputPaidMediaPhoto`, `InputPaidMediaLivePhoto`, or `InputPaidMediaVideo`
 //
 // https://core.telegram.org/bots/api#inputpaidmedia
-type InputPaidMedia any
+type InputPaidMedia interface {
+	inputPaidMedia()
+}
+
+func (InputPaidMediaPhoto) inputPaidMedia()     {}
+func (InputPaidMediaLivePhoto) inputPaidMedia() {}
+func (InputPaidMediaVideo) inputPaidMedia()     {}
 
 // InputPaidMediaPhoto struct
 //
@@ -926,10 +971,95 @@ type PaidMediaInfo struct {
 	PaidMedia []PaidMedia `json:"paid_media"`
 }
 
-// PaidMedia can be one of `PaidMediaPreview`, `PaidMediaPhoto`, or `PaidMediaVideo`
+// PaidMedia can be one of `*PaidMediaPreview`, `*PaidMediaPhoto`, `*PaidMediaLivePhoto`, or `*PaidMediaVideo`
+// after unmarshalling (an unknown `type` falls back to `*PaidMediaUnknown`).
+//
+// NOTE: When adding a new PaidMedia variant, also register its `type` string in newPaidMediaOfType() below.
 //
 // https://core.telegram.org/bots/api#paidmedia
-type PaidMedia any
+type PaidMedia interface {
+	paidMedia()
+}
+
+func (PaidMediaPreview) paidMedia()   {}
+func (PaidMediaPhoto) paidMedia()     {}
+func (PaidMediaLivePhoto) paidMedia() {}
+func (PaidMediaVideo) paidMedia()     {}
+func (PaidMediaUnknown) paidMedia()   {}
+
+// PaidMediaUnknown is a paid media of a type which is not supported by this library yet.
+type PaidMediaUnknown struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"` // original json of the paid media
+}
+
+// MarshalJSON encodes a PaidMediaUnknown as its original json
+// (or only with its `type` if it was not decoded).
+func (m PaidMediaUnknown) MarshalJSON() ([]byte, error) {
+	if len(m.Raw) == 0 {
+		return json.Marshal(map[string]string{"type": m.Type})
+	}
+	return m.Raw, nil
+}
+
+// returns a new PaidMedia of given type (nil if unknown)
+func newPaidMediaOfType(typ string) PaidMedia {
+	switch typ {
+	case "preview":
+		return &PaidMediaPreview{}
+	case "photo":
+		return &PaidMediaPhoto{}
+	case "live_photo":
+		return &PaidMediaLivePhoto{}
+	case "video":
+		return &PaidMediaVideo{}
+	}
+	return nil
+}
+
+// decode paid media from their json, discriminated by `type`
+func decodePaidMedia(raws []json.RawMessage) (media []PaidMedia, err error) {
+	if raws == nil {
+		return nil, nil
+	}
+
+	media = make([]PaidMedia, 0, len(raws))
+	for _, raw := range raws {
+		var head struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &head); err != nil {
+			return nil, err
+		}
+
+		m := newPaidMediaOfType(head.Type)
+		if m == nil {
+			media = append(media, &PaidMediaUnknown{Type: head.Type, Raw: raw})
+			continue
+		}
+		if err := json.Unmarshal(raw, m); err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+	return media, nil
+}
+
+// UnmarshalJSON decodes a PaidMediaInfo, with its paid media as concrete types.
+func (i *PaidMediaInfo) UnmarshalJSON(data []byte) (err error) {
+	type paidMediaInfo PaidMediaInfo // (for avoiding recursion)
+
+	var decoded struct {
+		paidMediaInfo
+		PaidMedia []json.RawMessage `json:"paid_media"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*i = PaidMediaInfo(decoded.paidMediaInfo)
+	i.PaidMedia, err = decodePaidMedia(decoded.PaidMedia)
+	return err
+}
 
 // PaidMediaPreview struct
 //
@@ -1935,6 +2065,22 @@ type TransactionPartner struct {
 	RequestCount *int `json:"request_count,omitempty"`
 }
 
+// UnmarshalJSON decodes a TransactionPartner, with its paid media as concrete types.
+func (p *TransactionPartner) UnmarshalJSON(data []byte) (err error) {
+	type transactionPartner TransactionPartner // (for avoiding recursion)
+
+	var decoded struct {
+		transactionPartner
+		PaidMedia []json.RawMessage `json:"paid_media,omitempty"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = TransactionPartner(decoded.transactionPartner)
+	p.PaidMedia, err = decodePaidMedia(decoded.PaidMedia)
+	return err
+}
+
 // AffiliateInfo is a struct for a affiliate of transaction
 //
 // https://core.telegram.org/bots/api#affiliateinfo
@@ -1974,6 +2120,16 @@ type ForceReply struct {
 	Selective             *bool   `json:"selective,omitempty"`
 }
 
+// ReplyMarkup can be one of `InlineKeyboardMarkup`, `ReplyKeyboardMarkup`, `ReplyKeyboardRemove`, or `ForceReply`
+type ReplyMarkup interface {
+	replyMarkup()
+}
+
+func (InlineKeyboardMarkup) replyMarkup() {}
+func (ReplyKeyboardMarkup) replyMarkup()  {}
+func (ReplyKeyboardRemove) replyMarkup()  {}
+func (ForceReply) replyMarkup()           {}
+
 // Community is a struct for a community(a group of chats).
 //
 // https://core.telegram.org/bots/api#community
@@ -2034,31 +2190,48 @@ type ChatAdministratorRights struct {
 
 // ChatMember is a struct of a chat member
 //
+// It is a flat struct of all fields of ChatMemberXXX variants;
+// use `Variant()` for getting the concrete variant of its status.
+//
 // https://core.telegram.org/bots/api#chatmember
 type ChatMember struct {
-	Status                ChatMemberStatus `json:"status"`
-	User                  User             `json:"user"`
-	IsAnonymous           *bool            `json:"is_anonymous,omitempty"`              // owner and administrators only
-	CustomTitle           *string          `json:"custom_title,omitempty"`              // owner and administrators only
-	CanBeEdited           *bool            `json:"can_be_edited,omitempty"`             // administrators only
-	CanManageChat         *bool            `json:"can_manage_chat,omitempty"`           // administrators only
-	CanPostMessages       *bool            `json:"can_post_messages,omitempty"`         // administrators only
-	CanEditMessages       *bool            `json:"can_edit_messages,omitempty"`         // administrators only
-	CanDeleteMessages     *bool            `json:"can_delete_messages,omitempty"`       // administrators only
-	CanManageVideoChats   *bool            `json:"can_manage_video_chats,omitempty"`    // administrators only
-	CanRestrictMembers    *bool            `json:"can_restrict_members,omitempty"`      // administrators only
-	CanPromoteMembers     *bool            `json:"can_promote_members,omitempty"`       // administrators only
-	CanChangeInfo         *bool            `json:"can_change_info,omitempty"`           // administrators and restricted only
-	CanInviteUsers        *bool            `json:"can_invite_users,omitempty"`          // administrators and restricted only
-	CanPinMessages        *bool            `json:"can_pin_messages,omitempty"`          // administrators and restricted only
-	CanManageTopics       *bool            `json:"can_manage_topics,omitempty"`         // administrators and restricted only
-	IsMember              *bool            `json:"is_member,omitempty"`                 // restricted only
-	CanSendMessages       *bool            `json:"can_send_messages,omitempty"`         // restricted only
-	CanSendMediaMessages  *bool            `json:"can_send_media_messages,omitempty"`   // restricted only
-	CanSendPolls          *bool            `json:"can_send_polls,omitempty"`            // restricted only
-	CanSendOtherMessages  *bool            `json:"can_send_other_messages,omitempty"`   // restricted only
-	CanAddWebPagePreviews *bool            `json:"can_add_web_page_previews,omitempty"` // restricted only
-	UntilDate             *int             `json:"until_date,omitempty"`                // restricted and kicked only
+	Status                  ChatMemberStatus `json:"status"`
+	Tag                     *string          `json:"tag,omitempty"` // members and restricted only
+	User                    User             `json:"user"`
+	IsAnonymous             *bool            `json:"is_anonymous,omitempty"`               // owner and administrators only
+	CustomTitle             *string          `json:"custom_title,omitempty"`               // owner and administrators only
+	CanBeEdited             *bool            `json:"can_be_edited,omitempty"`              // administrators only
+	CanManageChat           *bool            `json:"can_manage_chat,omitempty"`            // administrators only
+	CanPostMessages         *bool            `json:"can_post_messages,omitempty"`          // administrators only
+	CanEditMessages         *bool            `json:"can_edit_messages,omitempty"`          // administrators only
+	CanDeleteMessages       *bool            `json:"can_delete_messages,omitempty"`        // administrators only
+	CanManageVideoChats     *bool            `json:"can_manage_video_chats,omitempty"`     // administrators only
+	CanRestrictMembers      *bool            `json:"can_restrict_members,omitempty"`       // administrators only
+	CanPromoteMembers       *bool            `json:"can_promote_members,omitempty"`        // administrators only
+	CanPostStories          *bool            `json:"can_post_stories,omitempty"`           // administrators only
+	CanEditStories          *bool            `json:"can_edit_stories,omitempty"`           // administrators only
+	CanDeleteStories        *bool            `json:"can_delete_stories,omitempty"`         // administrators only
+	CanManageDirectMessages *bool            `json:"can_manage_direct_messages,omitempty"` // administrators only
+	CanManageTags           *bool            `json:"can_manage_tags,omitempty"`            // administrators only
+	CanChangeInfo           *bool            `json:"can_change_info,omitempty"`            // administrators and restricted only
+	CanInviteUsers          *bool            `json:"can_invite_users,omitempty"`           // administrators and restricted only
+	CanPinMessages          *bool            `json:"can_pin_messages,omitempty"`           // administrators and restricted only
+	CanManageTopics         *bool            `json:"can_manage_topics,omitempty"`          // administrators and restricted only
+	IsMember                *bool            `json:"is_member,omitempty"`                  // restricted only
+	CanSendMessages         *bool            `json:"can_send_messages,omitempty"`          // restricted only
+	CanSendAudios           *bool            `json:"can_send_audios,omitempty"`            // restricted only
+	CanSendDocuments        *bool            `json:"can_send_documents,omitempty"`         // restricted only
+	CanSendPhotos           *bool            `json:"can_send_photos,omitempty"`            // restricted only
+	CanSendVideos           *bool            `json:"can_send_videos,omitempty"`            // restricted only
+	CanSendVideoNotes       *bool            `json:"can_send_video_notes,omitempty"`       // restricted only
+	CanSendVoiceNotes       *bool            `json:"can_send_voice_notes,omitempty"`       // restricted only
+	CanSendMediaMessages    *bool            `json:"can_send_media_messages,omitempty"`    // restricted only (deprecated)
+	CanSendPolls            *bool            `json:"can_send_polls,omitempty"`             // restricted only
+	CanSendOtherMessages    *bool            `json:"can_send_other_messages,omitempty"`    // restricted only
+	CanAddWebPagePreviews   *bool            `json:"can_add_web_page_previews,omitempty"`  // restricted only
+	CanReactToMessages      *bool            `json:"can_react_to_messages,omitempty"`      // restricted only
+	CanEditTag              *bool            `json:"can_edit_tag,omitempty"`               // restricted only
+	UntilDate               *int             `json:"until_date,omitempty"`                 // members, restricted and kicked only
 }
 
 // ChatMemberUpdated is a struct of an updated chat member
@@ -2401,6 +2574,23 @@ const (
 	BotCommandScopeTypeChatMember            BotCommandScopeType = "chat_member"
 )
 
+// BotCommandScope can be one of `BotCommandScopeDefault`, `BotCommandScopeAllPrivateChats`,
+// `BotCommandScopeAllGroupChats`, `BotCommandScopeAllChatAdministrators`, `BotCommandScopeChat`,
+// `BotCommandScopeChatAdministrators`, or `BotCommandScopeChatMember`
+//
+// https://core.telegram.org/bots/api#botcommandscope
+type BotCommandScope interface {
+	botCommandScope()
+}
+
+func (BotCommandScopeDefault) botCommandScope()               {}
+func (BotCommandScopeAllPrivateChats) botCommandScope()       {}
+func (BotCommandScopeAllGroupChats) botCommandScope()         {}
+func (BotCommandScopeAllChatAdministrators) botCommandScope() {}
+func (BotCommandScopeChat) botCommandScope()                  {}
+func (BotCommandScopeChatAdministrators) botCommandScope()    {}
+func (BotCommandScopeChatMember) botCommandScope()            {}
+
 // BotCommandScopeDefault represents the bot command scopes
 //
 // https://core.telegram.org/bots/api#botcommandscopedefault
@@ -2569,6 +2759,8 @@ type Message struct {
 	VideoChatParticipantsInvited *VideoChatParticipantsInvited `json:"video_chat_participants_invited,omitempty"`
 	WebAppData                   *WebAppData                   `json:"web_app_data,omitempty"`
 	ReplyMarkup                  *InlineKeyboardMarkup         `json:"reply_markup,omitempty"`
+
+	raw string // original json of the message (kept with `SetKeepRawJSON`)
 }
 
 // MaybeInaccessibleMessage is a struct of a message that can be one of `Message` or `InaccessibleMessage`
@@ -2652,6 +2844,33 @@ type InlineQueryResult struct {
 	ID   string                `json:"id"`
 }
 
+// InlineQueryResultVariant can be one of InlineQueryResultXXX types,
+// which embed InlineQueryResult.
+type InlineQueryResultVariant interface {
+	inlineQueryResult()
+}
+
+func (InlineQueryResultArticle) inlineQueryResult()        {}
+func (InlineQueryResultPhoto) inlineQueryResult()          {}
+func (InlineQueryResultGif) inlineQueryResult()            {}
+func (InlineQueryResultMpeg4Gif) inlineQueryResult()       {}
+func (InlineQueryResultVideo) inlineQueryResult()          {}
+func (InlineQueryResultAudio) inlineQueryResult()          {}
+func (InlineQueryResultVoice) inlineQueryResult()          {}
+func (InlineQueryResultDocument) inlineQueryResult()       {}
+func (InlineQueryResultLocation) inlineQueryResult()       {}
+func (InlineQueryResultVenue) inlineQueryResult()          {}
+func (InlineQueryResultContact) inlineQueryResult()        {}
+func (InlineQueryResultGame) inlineQueryResult()           {}
+func (InlineQueryResultCachedPhoto) inlineQueryResult()    {}
+func (InlineQueryResultCachedGif) inlineQueryResult()      {}
+func (InlineQueryResultCachedMpeg4Gif) inlineQueryResult() {}
+func (InlineQueryResultCachedSticker) inlineQueryResult()  {}
+func (InlineQueryResultCachedDocument) inlineQueryResult() {}
+func (InlineQueryResultCachedVideo) inlineQueryResult()    {}
+func (InlineQueryResultCachedVoice) inlineQueryResult()    {}
+func (InlineQueryResultCachedAudio) inlineQueryResult()    {}
+
 // InlineQueryResultArticle is a struct for InlineQueryResultArticle
 type InlineQueryResultArticle struct { // https://core.telegram.org/bots/api#inlinequeryresultarticle
 	InlineQueryResult
@@ -2944,12 +3163,19 @@ type InlineQueryResultCachedAudio struct { // https://core.telegram.org/bots/api
 // NOTE: Can be generated with NewInput*MessageContent() function in types_helper.go
 //
 // https://core.telegram.org/bots/api#inputmessagecontent
-type InputMessageContent any
+type InputMessageContent interface {
+	inputMessageContent()
+}
+
+func (InputTextMessageContent) inputMessageContent()     {}
+func (InputRichMessageContent) inputMessageContent()     {}
+func (InputLocationMessageContent) inputMessageContent() {}
+func (InputVenueMessageContent) inputMessageContent()    {}
+func (InputContactMessageContent) inputMessageContent()  {}
+func (InputInvoiceMessageContent) inputMessageContent()  {}
 
 // InputTextMessageContent is a struct of InputTextMessageContent
 type InputTextMessageContent struct { // https://core.telegram.org/bots/api#inputtextmessagecontent
-	InputMessageContent
-
 	MessageText        string              `json:"message_text"`
 	ParseMode          *ParseMode          `json:"parse_mode,omitempty"`
 	CaptionEntities    []MessageEntity     `json:"caption_entities,omitempty"`
@@ -2960,15 +3186,11 @@ type InputTextMessageContent struct { // https://core.telegram.org/bots/api#inpu
 //
 // https://core.telegram.org/bots/api#inputrichmessagecontent
 type InputRichMessageContent struct {
-	InputMessageContent
-
 	RichMessage InputRichMessage `json:"rich_message"`
 }
 
 // InputLocationMessageContent is a struct of InputLocationMessageContent
 type InputLocationMessageContent struct { // https://core.telegram.org/bots/api#inputlocationmessagecontent
-	InputMessageContent
-
 	Latitude             float32  `json:"latitude"`
 	Longitude            float32  `json:"longitude"`
 	HorizontalAccuracy   *float32 `json:"horizontal_accuracy,omitempty"`
@@ -2979,8 +3201,6 @@ type InputLocationMessageContent struct { // https://core.telegram.org/bots/api#
 
 // InputVenueMessageContent is a struct of InputVenueMessageContent
 type InputVenueMessageContent struct { // https://core.telegram.org/bots/api#inputvenuemessagecontent
-	InputMessageContent
-
 	Latitude        float32 `json:"latitude"`
 	Longitude       float32 `json:"longitude"`
 	Title           string  `json:"title"`
@@ -2993,8 +3213,6 @@ type InputVenueMessageContent struct { // https://core.telegram.org/bots/api#inp
 
 // InputContactMessageContent is a struct of InputContactMessageContent
 type InputContactMessageContent struct { // https://core.telegram.org/bots/api#inputcontactmessagecontent
-	InputMessageContent
-
 	PhoneNumber string  `json:"phone_number"`
 	FirstName   string  `json:"first_name"`
 	LastName    *string `json:"last_name,omitempty"`
@@ -3007,8 +3225,6 @@ type InputContactMessageContent struct { // https://core.telegram.org/bots/api#i
 // - `ProviderToken`: Set "" for payments in Telegram Stars.
 // - `Currency`: Set "XTR" for payments in Telegram Stars.
 type InputInvoiceMessageContent struct { // https://core.telegram.org/bots/api#inputinvoicemessagecontent
-	InputMessageContent
-
 	Title                     string         `json:"title"`
 	Description               string         `json:"description"`
 	Payload                   string         `json:"payload"`
@@ -1074,6 +1074,42 @@ func NewInputProfilePhotoFromBytes(
 ////////////////////////////////
 // Helper functions for MessageEntity
 
+// get the length of given string in UTF-16 code units (used for offsets and lengths of MessageEntity)
+func utf16Length(str string) (length int) {
+	for _, r := range str {
+		if r >= 0x10000 {
+			length += 2
+		} else {
+			length++
+		}
+	}
+	return length
+}
+
+// get the substring of given string at given offset and length in UTF-16 code units
+func utf16Substring(str string, offset, length int) string {
+	start, end := -1, len(str)
+	pos := 0
+	for i, r := range str {
+		if start < 0 && pos >= offset {
+			start = i
+		}
+		if pos >= offset+length {
+			end = i
+			break
+		}
+		if r >= 0x10000 {
+			pos += 2
+		} else {
+			pos++
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return str[start:end]
+}
+
 // NewMessageEntity returns a new MessageEntity.
 func NewMessageEntity(typ MessageEntityType, offset, length int) MessageEntity {
 	return MessageEntity{
@@ -3088,7 +3124,7 @@ func (c InputInvoiceMessageContent) SetIsFlexible(isFlexible bool) InputInvoiceM
 // NewChatBoostSourcePremium returns a new ChatBoostSourcePremium.
 func NewChatBoostSourcePremium(user User) ChatBoostSource {
 	return ChatBoostSource{
-		Source: "premium",
+		Source: ChatBoostSourceTypePremium,
 		User:   &user,
 	}
 }
@@ -3096,7 +3132,7 @@ func NewChatBoostSourcePremium(user User) ChatBoostSource {
 // NewChatBoostSourceGiftCode returns a new ChatBoostSourceGiftCode.
 func NewChatBoostSourceGiftCode(user User) ChatBoostSource {
 	return ChatBoostSource{
-		Source: "gift_code",
+		Source: ChatBoostSourceTypeGiftCode,
 		User:   &user,
 	}
 }
@@ -3108,7 +3144,7 @@ func NewChatBoostSourceGiveaway(
 	isUnclaimed bool,
 ) ChatBoostSource {
 	return ChatBoostSource{
-		Source:            "giveaway",
+		Source:            ChatBoostSourceTypeGiveaway,
 		GiveawayMessageID: &giveawayMessageID,
 		User:              user,
 		IsUnclaimed:       &isUnclaimed,
@@ -7,6 +7,7 @@ package telegrambot
 import (
 	"encoding/json"
 	"log/slog"
+	"reflect"
 	"testing"
 )
 
@@ -200,3 +201,99 @@ func TestRichTextMarshalCompat(t *testing.T) {
 		t.Errorf("expected %q, got %q", `["a","b"]`, string(b))
 	}
 }
+
+// Sealed unions: only valid variants satisfy them, at compile time.
+var (
+	_ InputMedia               = InputMediaPhoto{}
+	_ InputMedia               = &InputMediaVideo{}
+	_ InputPollMedia           = InputMediaVenue{}
+	_ InputPollOptionMedia     = InputMediaSticker{}
+	_ InputPaidMedia           = InputPaidMediaLivePhoto{}
+	_ ReplyMarkup              = InlineKeyboardMarkup{}
+	_ ReplyMarkup              = ForceReply{}
+	_ InputMessageContent      = InputTextMessageContent{}
+	_ InlineQueryResultVariant = InlineQueryResultArticle{}
+	_ PaidMedia                = &PaidMediaVideo{}
+)
+
+// Sealed unions should be marshalled as before.
+func TestSealedUnionsMarshalCompat(t *testing.T) {
+	slog.Info("testing marshalling compatibility of sealed unions...")
+
+	var markup ReplyMarkup = NewReplyKeyboardRemove(true)
+	if b, err := json.Marshal(markup); err != nil {
+		t.Fatalf("failed to marshal reply markup: %s", err)
+	} else if string(b) != `{"remove_keyboard":true}` {
+		t.Errorf("unexpected json of reply markup: %s", string(b))
+	}
+
+	var content InputMessageContent = InputTextMessageContent{MessageText: "hi"}
+	if b, err := json.Marshal(content); err != nil {
+		t.Fatalf("failed to marshal input message content: %s", err)
+	} else if string(b) != `{"message_text":"hi"}` {
+		t.Errorf("unexpected json of input message content: %s", string(b))
+	}
+
+	options := OptionsSetMyCommands{}.SetScope(BotCommandScopeChat{
+		BotCommandScopeDefault: BotCommandScopeDefault{Type: BotCommandScopeTypeChat},
+		ChatID:                 int64(-1001234567890),
+	})
+	if b, err := json.Marshal(options["scope"]); err != nil {
+		t.Fatalf("failed to marshal bot command scope: %s", err)
+	} else if string(b) != `{"type":"chat","chat_id":-1001234567890}` {
+		t.Errorf("unexpected json of bot command scope: %s", string(b))
+	}
+
+	var media PaidMedia = PaidMediaUnknown{Type: "future_type"}
+	if b, err := json.Marshal(media); err != nil {
+		t.Fatalf("failed to marshal paid media which was not decoded: %s", err)
+	} else if string(b) != `{"type":"future_type"}` {
+		t.Errorf("unexpected json of paid media: %s", string(b))
+	}
+
+	// (the embedded base struct should not satisfy the union)
+	if reflect.TypeFor[InlineQueryResult]().Implements(reflect.TypeFor[InlineQueryResultVariant]()) {
+		t.Errorf("InlineQueryResult should not be an InlineQueryResultVariant")
+	}
+}
+
+// PaidMedia should be decoded into concrete types, discriminated by `type`.
+func TestPaidMediaUnmarshal(t *testing.T) {
+	slog.Info("testing unmarshalling of PaidMedia...")
+
+	var info PaidMediaInfo
+	if err := json.Unmarshal([]byte(`{
+		"star_count": 10,
+		"paid_media": [
+			{"type": "preview", "width": 100, "height": 200},
+			{"type": "photo", "photo": [{"file_id": "a", "file_unique_id": "b", "width": 1, "height": 1}]},
+			{"type": "future_type", "something": 1}
+		]
+	}`), &info); err != nil {
+		t.Fatalf("failed to unmarshal PaidMediaInfo: %s", err)
+	}
+	if info.StarCount != 10 || len(info.PaidMedia) != 3 {
+		t.Fatalf("unexpected PaidMediaInfo: %+v", info)
+	}
+	if preview, ok := info.PaidMedia[0].(*PaidMediaPreview); !ok || preview.Height != 200 {
+		t.Errorf("expected *PaidMediaPreview, got %#v", info.PaidMedia[0])
+	}
+	if photo, ok := info.PaidMedia[1].(*PaidMediaPhoto); !ok || photo.Photo[0].FileID != "a" {
+		t.Errorf("expected *PaidMediaPhoto, got %#v", info.PaidMedia[1])
+	}
+	if unknown, ok := info.PaidMedia[2].(*PaidMediaUnknown); !ok || unknown.Type != "future_type" {
+		t.Errorf("expected *PaidMediaUnknown, got %#v", info.PaidMedia[2])
+	} else if b, _ := json.Marshal(unknown); string(b) != `{"type":"future_type","something":1}` {
+		t.Errorf("unexpected json of unknown paid media: %s", string(b))
+	}
+
+	var partner TransactionPartner
+	if err := json.Unmarshal([]byte(`{"type":"user","paid_media":[{"type":"video","video":{"file_id":"v","file_unique_id":"u","width":1,"height":1,"duration":1}}]}`), &partner); err != nil {
+		t.Fatalf("failed to unmarshal TransactionPartner: %s", err)
+	}
+	if len(partner.PaidMedia) != 1 {
+		t.Errorf("unexpected paid media of TransactionPartner: %+v", partner.PaidMedia)
+	} else if _, ok := partner.PaidMedia[0].(*PaidMediaVideo); !ok {
+		t.Errorf("expected *PaidMediaVideo, got %T", partner.PaidMedia[0])
+	}
+}
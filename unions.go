@@ -0,0 +1,813 @@
+package telegrambot
+
+// Typed accessors of flat unions which are discriminated by their types
+//
+// NOTE: Variants whose names in the API documentation are already taken by
+// constants of their types (eg. `TransactionPartnerUser`) are named with a `Variant` suffix.
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrUnexpectedUnionType is returned when accessing a flat union as a variant of another type.
+	ErrUnexpectedUnionType = errors.New("unexpected type of union")
+
+	// ErrUnknownUnionType is returned when a flat union has a type which is not supported by this library yet.
+	ErrUnknownUnionType = errors.New("unknown type of union")
+)
+
+// unionTypeChecker is a flat union which can check if its type is known
+type unionTypeChecker interface {
+	checkUnionType() error
+}
+
+// check if given type of a union is expected
+func expectUnionType[T ~string](union string, typ, expected T) error {
+	if typ != expected {
+		return fmt.Errorf("%w: %s is '%s', not '%s'", ErrUnexpectedUnionType, union, typ, expected)
+	}
+	return nil
+}
+
+// check if given type of a union is one of known types
+func knownUnionType[T ~string](union string, typ T, known ...T) error {
+	for _, k := range known {
+		if typ == k {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s of type '%s'", ErrUnknownUnionType, union, typ)
+}
+
+// value of given pointer, or zero value if nil
+func valueOrZero[T any](p *T) (v T) {
+	if p != nil {
+		v = *p
+	}
+	return v
+}
+
+// CheckUnionTypes checks if all flat unions (eg. MessageOrigin, TransactionPartner, ...) in given value
+// have types which are known to this library, and returns an error wrapping ErrUnknownUnionType if not.
+//
+// It can be used for catching API drift in responses, and is applied to updates in strict mode (see `SetStrictUnions`).
+func CheckUnionTypes(v any) error {
+	return checkUnionTypes(reflect.ValueOf(v))
+}
+
+// check types of flat unions in given value recursively
+func checkUnionTypes(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkUnionTypes(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 { // (bytes)
+			return nil
+		}
+		for i := range v.Len() {
+			if err := checkUnionTypes(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.CanInterface() {
+			if checker, ok := v.Interface().(unionTypeChecker); ok {
+				if err := checker.checkUnionType(); err != nil {
+					return err
+				}
+			}
+		}
+		for field := range v.Type().Fields() {
+			if !field.IsExported() {
+				continue
+			}
+			if err := checkUnionTypes(v.FieldByIndex(field.Index)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetStrictUnions sets whether updates with unknown types of flat unions should be treated as errors.
+//
+// In strict mode, such updates are not dispatched to handlers,
+// but passed to the update handler with an error wrapping ErrUnknownUnionType.
+func (b *Bot) SetStrictUnions(strict bool) {
+	b.strictUnions = strict
+}
+
+////////////////////////////////
+// MessageOrigin
+//
+
+// MessageOrigin types
+const (
+	MessageOriginTypeUser       = "user"
+	MessageOriginTypeHiddenUser = "hidden_user"
+	MessageOriginTypeChat       = "chat"
+	MessageOriginTypeChannel    = "channel"
+)
+
+// MessageOriginUser is a struct of a message originally sent by a known user.
+//
+// https://core.telegram.org/bots/api#messageoriginuser
+type MessageOriginUser struct {
+	Type       string `json:"type"` // == "user"
+	Date       int    `json:"date"`
+	SenderUser User   `json:"sender_user"`
+}
+
+// MessageOriginHiddenUser is a struct of a message originally sent by an unknown user.
+//
+// https://core.telegram.org/bots/api#messageoriginhiddenuser
+type MessageOriginHiddenUser struct {
+	Type           string `json:"type"` // == "hidden_user"
+	Date           int    `json:"date"`
+	SenderUserName string `json:"sender_user_name"`
+}
+
+// MessageOriginChat is a struct of a message originally sent on behalf of a chat to a group chat.
+//
+// https://core.telegram.org/bots/api#messageoriginchat
+type MessageOriginChat struct {
+	Type            string  `json:"type"` // == "chat"
+	Date            int     `json:"date"`
+	SenderChat      Chat    `json:"sender_chat"`
+	AuthorSignature *string `json:"author_signature,omitempty"`
+}
+
+// MessageOriginChannel is a struct of a message originally sent to a channel chat.
+//
+// https://core.telegram.org/bots/api#messageoriginchannel
+type MessageOriginChannel struct {
+	Type            string  `json:"type"` // == "channel"
+	Date            int     `json:"date"`
+	Chat            Chat    `json:"chat"`
+	MessageID       int64   `json:"message_id"`
+	AuthorSignature *string `json:"author_signature,omitempty"`
+}
+
+// AsUser returns the origin as MessageOriginUser.
+func (o MessageOrigin) AsUser() (*MessageOriginUser, error) {
+	if err := expectUnionType("MessageOrigin", o.Type, MessageOriginTypeUser); err != nil {
+		return nil, err
+	}
+	return &MessageOriginUser{
+		Type:       o.Type,
+		Date:       o.Date,
+		SenderUser: valueOrZero(o.SenderUser),
+	}, nil
+}
+
+// AsHiddenUser returns the origin as MessageOriginHiddenUser.
+func (o MessageOrigin) AsHiddenUser() (*MessageOriginHiddenUser, error) {
+	if err := expectUnionType("MessageOrigin", o.Type, MessageOriginTypeHiddenUser); err != nil {
+		return nil, err
+	}
+	return &MessageOriginHiddenUser{
+		Type:           o.Type,
+		Date:           o.Date,
+		SenderUserName: valueOrZero(o.SenderUserName),
+	}, nil
+}
+
+// AsChat returns the origin as MessageOriginChat.
+func (o MessageOrigin) AsChat() (*MessageOriginChat, error) {
+	if err := expectUnionType("MessageOrigin", o.Type, MessageOriginTypeChat); err != nil {
+		return nil, err
+	}
+	return &MessageOriginChat{
+		Type:            o.Type,
+		Date:            o.Date,
+		SenderChat:      valueOrZero(o.SenderChat),
+		AuthorSignature: o.AuthorSignature,
+	}, nil
+}
+
+// AsChannel returns the origin as MessageOriginChannel.
+func (o MessageOrigin) AsChannel() (*MessageOriginChannel, error) {
+	if err := expectUnionType("MessageOrigin", o.Type, MessageOriginTypeChannel); err != nil {
+		return nil, err
+	}
+	return &MessageOriginChannel{
+		Type:            o.Type,
+		Date:            o.Date,
+		Chat:            valueOrZero(o.Chat),
+		MessageID:       valueOrZero(o.MessageID),
+		AuthorSignature: o.AuthorSignature,
+	}, nil
+}
+
+func (o MessageOrigin) checkUnionType() error {
+	return knownUnionType("MessageOrigin", o.Type, MessageOriginTypeUser, MessageOriginTypeHiddenUser, MessageOriginTypeChat, MessageOriginTypeChannel)
+}
+
+////////////////////////////////
+// BackgroundType
+//
+
+// BackgroundTypeFillVariant is a struct of a background filled with a fill.
+//
+// https://core.telegram.org/bots/api#backgroundtypefill
+type BackgroundTypeFillVariant struct {
+	Type             BackgroundTypeType `json:"type"` // == "fill"
+	Fill             BackgroundFill     `json:"fill"`
+	DarkThemeDimming int                `json:"dark_theme_dimming"`
+}
+
+// BackgroundTypeWallpaperVariant is a struct of a background which is a wallpaper.
+//
+// https://core.telegram.org/bots/api#backgroundtypewallpaper
+type BackgroundTypeWallpaperVariant struct {
+	Type             BackgroundTypeType `json:"type"` // == "wallpaper"
+	Document         Document           `json:"document"`
+	DarkThemeDimming int                `json:"dark_theme_dimming"`
+	IsBlurred        *bool              `json:"is_blurred,omitempty"`
+	IsMoving         *bool              `json:"is_moving,omitempty"`
+}
+
+// BackgroundTypePatternVariant is a struct of a background which is a pattern on a fill.
+//
+// https://core.telegram.org/bots/api#backgroundtypepattern
+type BackgroundTypePatternVariant struct {
+	Type       BackgroundTypeType `json:"type"` // == "pattern"
+	Document   Document           `json:"document"`
+	Fill       BackgroundFill     `json:"fill"`
+	Intensity  int                `json:"intensity"`
+	IsInverted *bool              `json:"is_inverted,omitempty"`
+	IsMoving   *bool              `json:"is_moving,omitempty"`
+}
+
+// BackgroundTypeChatThemeVariant is a struct of a background taken from a chat theme.
+//
+// https://core.telegram.org/bots/api#backgroundtypechattheme
+type BackgroundTypeChatThemeVariant struct {
+	Type      BackgroundTypeType `json:"type"` // == "chat_theme"
+	ThemeName string             `json:"theme_name"`
+}
+
+// AsFill returns the background type as BackgroundTypeFillVariant.
+func (t BackgroundType) AsFill() (*BackgroundTypeFillVariant, error) {
+	if err := expectUnionType("BackgroundType", t.Type, BackgroundTypeFill); err != nil {
+		return nil, err
+	}
+	return &BackgroundTypeFillVariant{
+		Type:             t.Type,
+		Fill:             valueOrZero(t.Fill),
+		DarkThemeDimming: valueOrZero(t.DarkThemeDimming),
+	}, nil
+}
+
+// AsWallpaper returns the background type as BackgroundTypeWallpaperVariant.
+func (t BackgroundType) AsWallpaper() (*BackgroundTypeWallpaperVariant, error) {
+	if err := expectUnionType("BackgroundType", t.Type, BackgroundTypeWallpaper); err != nil {
+		return nil, err
+	}
+	return &BackgroundTypeWallpaperVariant{
+		Type:             t.Type,
+		Document:         valueOrZero(t.Document),
+		DarkThemeDimming: valueOrZero(t.DarkThemeDimming),
+		IsBlurred:        t.IsBlurred,
+		IsMoving:         t.IsMoving,
+	}, nil
+}
+
+// AsPattern returns the background type as BackgroundTypePatternVariant.
+func (t BackgroundType) AsPattern() (*BackgroundTypePatternVariant, error) {
+	if err := expectUnionType("BackgroundType", t.Type, BackgroundTypePattern); err != nil {
+		return nil, err
+	}
+	return &BackgroundTypePatternVariant{
+		Type:       t.Type,
+		Document:   valueOrZero(t.Document),
+		Fill:       valueOrZero(t.Fill),
+		Intensity:  valueOrZero(t.Intensity),
+		IsInverted: t.IsInverted,
+		IsMoving:   t.IsMoving,
+	}, nil
+}
+
+// AsChatTheme returns the background type as BackgroundTypeChatThemeVariant.
+func (t BackgroundType) AsChatTheme() (*BackgroundTypeChatThemeVariant, error) {
+	if err := expectUnionType("BackgroundType", t.Type, BackgroundTypeChatTheme); err != nil {
+		return nil, err
+	}
+	return &BackgroundTypeChatThemeVariant{
+		Type:      t.Type,
+		ThemeName: valueOrZero(t.ThemeName),
+	}, nil
+}
+
+func (t BackgroundType) checkUnionType() error {
+	return knownUnionType("BackgroundType", t.Type, BackgroundTypeFill, BackgroundTypeWallpaper, BackgroundTypePattern, BackgroundTypeChatTheme)
+}
+
+////////////////////////////////
+// BackgroundFill
+//
+
+// BackgroundFillSolid is a struct of a background filled with a color.
+//
+// https://core.telegram.org/bots/api#backgroundfillsolid
+type BackgroundFillSolid struct {
+	Type  BackgroundFillType `json:"type"` // == "solid"
+	Color int                `json:"color"`
+}
+
+// BackgroundFillGradient is a struct of a background filled with a gradient.
+//
+// https://core.telegram.org/bots/api#backgroundfillgradient
+type BackgroundFillGradient struct {
+	Type          BackgroundFillType `json:"type"` // == "gradient"
+	TopColor      int                `json:"top_color"`
+	BottomColor   int                `json:"bottom_color"`
+	RotationAngle int                `json:"rotation_angle"`
+}
+
+// BackgroundFillFreeformGradient is a struct of a background filled with a freeform gradient.
+//
+// https://core.telegram.org/bots/api#backgroundfillfreeformgradient
+type BackgroundFillFreeformGradient struct {
+	Type   BackgroundFillType `json:"type"` // == "freeform_gradient"
+	Colors []int              `json:"colors"`
+}
+
+// AsSolid returns the fill as BackgroundFillSolid.
+func (f BackgroundFill) AsSolid() (*BackgroundFillSolid, error) {
+	if err := expectUnionType("BackgroundFill", f.Type, BackgroundFillTypeSolid); err != nil {
+		return nil, err
+	}
+	return &BackgroundFillSolid{
+		Type:  f.Type,
+		Color: valueOrZero(f.Color),
+	}, nil
+}
+
+// AsGradient returns the fill as BackgroundFillGradient.
+func (f BackgroundFill) AsGradient() (*BackgroundFillGradient, error) {
+	if err := expectUnionType("BackgroundFill", f.Type, BackgroundFillTypeGradient); err != nil {
+		return nil, err
+	}
+	return &BackgroundFillGradient{
+		Type:          f.Type,
+		TopColor:      valueOrZero(f.TopColor),
+		BottomColor:   valueOrZero(f.BottomColor),
+		RotationAngle: valueOrZero(f.RotationAngle),
+	}, nil
+}
+
+// AsFreeformGradient returns the fill as BackgroundFillFreeformGradient.
+func (f BackgroundFill) AsFreeformGradient() (*BackgroundFillFreeformGradient, error) {
+	if err := expectUnionType("BackgroundFill", f.Type, BackgroundFillTypeFreeformGradient); err != nil {
+		return nil, err
+	}
+	return &BackgroundFillFreeformGradient{
+		Type:   f.Type,
+		Colors: f.Colors,
+	}, nil
+}
+
+func (f BackgroundFill) checkUnionType() error {
+	return knownUnionType("BackgroundFill", f.Type, BackgroundFillTypeSolid, BackgroundFillTypeGradient, BackgroundFillTypeFreeformGradient)
+}
+
+////////////////////////////////
+// RevenueWithdrawalState
+//
+
+// RevenueWithdrawalStatePendingVariant is a struct of a withdrawal in progress.
+//
+// https://core.telegram.org/bots/api#revenuewithdrawalstatepending
+type RevenueWithdrawalStatePendingVariant struct {
+	Type RevenueWithdrawalStateType `json:"type"` // == "pending"
+}
+
+// RevenueWithdrawalStateSucceededVariant is a struct of a succeeded withdrawal.
+//
+// https://core.telegram.org/bots/api#revenuewithdrawalstatesucceeded
+type RevenueWithdrawalStateSucceededVariant struct {
+	Type RevenueWithdrawalStateType `json:"type"` // == "succeeded"
+	Date int                        `json:"date"`
+	URL  string                     `json:"url"`
+}
+
+// RevenueWithdrawalStateFailedVariant is a struct of a failed withdrawal.
+//
+// https://core.telegram.org/bots/api#revenuewithdrawalstatefailed
+type RevenueWithdrawalStateFailedVariant struct {
+	Type RevenueWithdrawalStateType `json:"type"` // == "failed"
+}
+
+// AsPending returns the state as RevenueWithdrawalStatePendingVariant.
+func (s RevenueWithdrawalState) AsPending() (*RevenueWithdrawalStatePendingVariant, error) {
+	if err := expectUnionType("RevenueWithdrawalState", s.Type, RevenueWithdrawalStatePending); err != nil {
+		return nil, err
+	}
+	return &RevenueWithdrawalStatePendingVariant{Type: s.Type}, nil
+}
+
+// AsSucceeded returns the state as RevenueWithdrawalStateSucceededVariant.
+func (s RevenueWithdrawalState) AsSucceeded() (*RevenueWithdrawalStateSucceededVariant, error) {
+	if err := expectUnionType("RevenueWithdrawalState", s.Type, RevenueWithdrawalStateSucceeded); err != nil {
+		return nil, err
+	}
+	return &RevenueWithdrawalStateSucceededVariant{
+		Type: s.Type,
+		Date: valueOrZero(s.Date),
+		URL:  valueOrZero(s.URL),
+	}, nil
+}
+
+// AsFailed returns the state as RevenueWithdrawalStateFailedVariant.
+func (s RevenueWithdrawalState) AsFailed() (*RevenueWithdrawalStateFailedVariant, error) {
+	if err := expectUnionType("RevenueWithdrawalState", s.Type, RevenueWithdrawalStateFailed); err != nil {
+		return nil, err
+	}
+	return &RevenueWithdrawalStateFailedVariant{Type: s.Type}, nil
+}
+
+func (s RevenueWithdrawalState) checkUnionType() error {
+	return knownUnionType("RevenueWithdrawalState", s.Type, RevenueWithdrawalStatePending, RevenueWithdrawalStateSucceeded, RevenueWithdrawalStateFailed)
+}
+
+////////////////////////////////
+// TransactionPartner
+//
+
+// TransactionPartnerUserVariant is a struct of a transaction with a user.
+//
+// https://core.telegram.org/bots/api#transactionpartneruser
+type TransactionPartnerUserVariant struct {
+	Type                        TransactionPartnerType                `json:"type"` // == "user"
+	TransactionType             TransactionPartnerUserTransactionType `json:"transaction_type"`
+	User                        User                                  `json:"user"`
+	Affiliate                   *AffiliateInfo                        `json:"affiliate,omitempty"`
+	InvoicePayload              *string                               `json:"invoice_payload,omitempty"`
+	SubscriptionPeriod          *int                                  `json:"subscription_period,omitempty"`
+	PaidMedia                   []PaidMedia                           `json:"paid_media,omitempty"`
+	PaidMediaPayload            *string                               `json:"paid_media_payload,omitempty"`
+	Gift                        *string                               `json:"gift,omitempty"`
+	PremiumSubscriptionDuration *int                                  `json:"premium_subscription_duration,omitempty"`
+}
+
+// TransactionPartnerChatVariant is a struct of a transaction with a chat.
+//
+// https://core.telegram.org/bots/api#transactionpartnerchat
+type TransactionPartnerChatVariant struct {
+	Type TransactionPartnerType `json:"type"` // == "chat"
+	Chat Chat                   `json:"chat"`
+	Gift *string                `json:"gift,omitempty"`
+}
+
+// TransactionPartnerAffiliateProgramVariant is a struct of a transaction with an affiliate program.
+//
+// https://core.telegram.org/bots/api#transactionpartneraffiliateprogram
+type TransactionPartnerAffiliateProgramVariant struct {
+	Type               TransactionPartnerType `json:"type"` // == "affiliate_program"
+	SponsorUser        *User                  `json:"sponsor_user,omitempty"`
+	CommissionPerMille int                    `json:"commission_per_mille"`
+}
+
+// TransactionPartnerFragmentVariant is a struct of a withdrawal transaction with Fragment.
+//
+// https://core.telegram.org/bots/api#transactionpartnerfragment
+type TransactionPartnerFragmentVariant struct {
+	Type            TransactionPartnerType  `json:"type"` // == "fragment"
+	WithdrawalState *RevenueWithdrawalState `json:"withdrawal_state,omitempty"`
+}
+
+// TransactionPartnerTelegramAdsVariant is a struct of a withdrawal transaction to the Telegram Ads platform.
+//
+// https://core.telegram.org/bots/api#transactionpartnertelegramads
+type TransactionPartnerTelegramAdsVariant struct {
+	Type TransactionPartnerType `json:"type"` // == "telegram_ads"
+}
+
+// TransactionPartnerTelegramAPIVariant is a struct of a transaction with payment for paid broadcasting.
+//
+// https://core.telegram.org/bots/api#transactionpartnertelegramapi
+type TransactionPartnerTelegramAPIVariant struct {
+	Type         TransactionPartnerType `json:"type"` // == "telegram_api"
+	RequestCount int                    `json:"request_count"`
+}
+
+// TransactionPartnerOtherVariant is a struct of a transaction with an unknown source or recipient.
+//
+// https://core.telegram.org/bots/api#transactionpartnerother
+type TransactionPartnerOtherVariant struct {
+	Type TransactionPartnerType `json:"type"` // == "other"
+}
+
+// AsUser returns the partner as TransactionPartnerUserVariant.
+func (p TransactionPartner) AsUser() (*TransactionPartnerUserVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerUser); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerUserVariant{
+		Type:                        p.Type,
+		TransactionType:             valueOrZero(p.TransactionType),
+		User:                        valueOrZero(p.User),
+		Affiliate:                   p.Affiliate,
+		InvoicePayload:              p.InvoicePayload,
+		SubscriptionPeriod:          p.SubscriptionPeriod,
+		PaidMedia:                   p.PaidMedia,
+		PaidMediaPayload:            p.PaidMediaPayload,
+		Gift:                        p.Gift,
+		PremiumSubscriptionDuration: p.PremiumSubscriptionDuration,
+	}, nil
+}
+
+// AsChat returns the partner as TransactionPartnerChatVariant.
+func (p TransactionPartner) AsChat() (*TransactionPartnerChatVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerChat); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerChatVariant{
+		Type: p.Type,
+		Chat: valueOrZero(p.Chat),
+		Gift: p.Gift,
+	}, nil
+}
+
+// AsAffiliateProgram returns the partner as TransactionPartnerAffiliateProgramVariant.
+func (p TransactionPartner) AsAffiliateProgram() (*TransactionPartnerAffiliateProgramVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerAffiliateProgram); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerAffiliateProgramVariant{
+		Type:               p.Type,
+		SponsorUser:        p.SponsorUser,
+		CommissionPerMille: valueOrZero(p.CommissionPerMile),
+	}, nil
+}
+
+// AsFragment returns the partner as TransactionPartnerFragmentVariant.
+func (p TransactionPartner) AsFragment() (*TransactionPartnerFragmentVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerFragment); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerFragmentVariant{
+		Type:            p.Type,
+		WithdrawalState: p.WithdrawlState,
+	}, nil
+}
+
+// AsTelegramAds returns the partner as TransactionPartnerTelegramAdsVariant.
+func (p TransactionPartner) AsTelegramAds() (*TransactionPartnerTelegramAdsVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerTelegramAds); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerTelegramAdsVariant{Type: p.Type}, nil
+}
+
+// AsTelegramAPI returns the partner as TransactionPartnerTelegramAPIVariant.
+func (p TransactionPartner) AsTelegramAPI() (*TransactionPartnerTelegramAPIVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerTelegramAPI); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerTelegramAPIVariant{
+		Type:         p.Type,
+		RequestCount: valueOrZero(p.RequestCount),
+	}, nil
+}
+
+// AsOther returns the partner as TransactionPartnerOtherVariant.
+func (p TransactionPartner) AsOther() (*TransactionPartnerOtherVariant, error) {
+	if err := expectUnionType("TransactionPartner", p.Type, TransactionPartnerOther); err != nil {
+		return nil, err
+	}
+	return &TransactionPartnerOtherVariant{Type: p.Type}, nil
+}
+
+func (p TransactionPartner) checkUnionType() error {
+	return knownUnionType("TransactionPartner", p.Type,
+		TransactionPartnerUser,
+		TransactionPartnerChat,
+		TransactionPartnerAffiliateProgram,
+		TransactionPartnerFragment,
+		TransactionPartnerTelegramAds,
+		TransactionPartnerTelegramAPI,
+		TransactionPartnerOther,
+	)
+}
+
+////////////////////////////////
+// StoryAreaType
+//
+
+// StoryAreaTypeLocationVariant is a struct of a story area pointing to a location.
+//
+// https://core.telegram.org/bots/api#storyareatypelocation
+type StoryAreaTypeLocationVariant struct {
+	Type      StoryAreaTypeType `json:"type"` // == "location"
+	Latitude  float32           `json:"latitude"`
+	Longitude float32           `json:"longitude"`
+	Address   *LocationAddress  `json:"address,omitempty"`
+}
+
+// StoryAreaTypeSuggestedReactionVariant is a struct of a story area pointing to a suggested reaction.
+//
+// https://core.telegram.org/bots/api#storyareatypesuggestedreaction
+type StoryAreaTypeSuggestedReactionVariant struct {
+	Type         StoryAreaTypeType `json:"type"` // == "suggested_reaction"
+	ReactionType ReactionType      `json:"reaction_type"`
+	IsDark       *bool             `json:"is_dark,omitempty"`
+	IsFlipped    *bool             `json:"is_flipped,omitempty"`
+}
+
+// StoryAreaTypeLinkVariant is a struct of a story area pointing to an HTTP or tg:// link.
+//
+// https://core.telegram.org/bots/api#storyareatypelink
+type StoryAreaTypeLinkVariant struct {
+	Type StoryAreaTypeType `json:"type"` // == "link"
+	URL  string            `json:"url"`
+}
+
+// StoryAreaTypeWeatherVariant is a struct of a story area containing weather information.
+//
+// https://core.telegram.org/bots/api#storyareatypeweather
+type StoryAreaTypeWeatherVariant struct {
+	Type            StoryAreaTypeType `json:"type"` // == "weather"
+	Temperature     float32           `json:"temperature"`
+	Emoji           string            `json:"emoji"`
+	BackgroundColor int               `json:"background_color"`
+}
+
+// StoryAreaTypeUniqueGiftVariant is a struct of a story area pointing to a unique gift.
+//
+// https://core.telegram.org/bots/api#storyareatypeuniquegift
+type StoryAreaTypeUniqueGiftVariant struct {
+	Type StoryAreaTypeType `json:"type"` // == "unique_gift"
+	Name string            `json:"name"`
+}
+
+// AsLocation returns the story area type as StoryAreaTypeLocationVariant.
+func (t StoryAreaType) AsLocation() (*StoryAreaTypeLocationVariant, error) {
+	if err := expectUnionType("StoryAreaType", t.Type, StoryAreaTypeLocation); err != nil {
+		return nil, err
+	}
+	return &StoryAreaTypeLocationVariant{
+		Type:      t.Type,
+		Latitude:  valueOrZero(t.Latitude),
+		Longitude: valueOrZero(t.Longitude),
+		Address:   t.Address,
+	}, nil
+}
+
+// AsSuggestedReaction returns the story area type as StoryAreaTypeSuggestedReactionVariant.
+func (t StoryAreaType) AsSuggestedReaction() (*StoryAreaTypeSuggestedReactionVariant, error) {
+	if err := expectUnionType("StoryAreaType", t.Type, StoryAreaTypeSuggestedReaction); err != nil {
+		return nil, err
+	}
+	return &StoryAreaTypeSuggestedReactionVariant{
+		Type:         t.Type,
+		ReactionType: valueOrZero(t.ReactionType),
+		IsDark:       t.IsDark,
+		IsFlipped:    t.IsFlipped,
+	}, nil
+}
+
+// AsLink returns the story area type as StoryAreaTypeLinkVariant.
+func (t StoryAreaType) AsLink() (*StoryAreaTypeLinkVariant, error) {
+	if err := expectUnionType("StoryAreaType", t.Type, StoryAreaTypeLink); err != nil {
+		return nil, err
+	}
+	return &StoryAreaTypeLinkVariant{
+		Type: t.Type,
+		URL:  valueOrZero(t.URL),
+	}, nil
+}
+
+// AsWeather returns the story area type as StoryAreaTypeWeatherVariant.
+func (t StoryAreaType) AsWeather() (*StoryAreaTypeWeatherVariant, error) {
+	if err := expectUnionType("StoryAreaType", t.Type, StoryAreaTypeWeather); err != nil {
+		return nil, err
+	}
+	return &StoryAreaTypeWeatherVariant{
+		Type:            t.Type,
+		Temperature:     valueOrZero(t.Temperature),
+		Emoji:           valueOrZero(t.Emoji),
+		BackgroundColor: valueOrZero(t.BackgroundColor),
+	}, nil
+}
+
+// AsUniqueGift returns the story area type as StoryAreaTypeUniqueGiftVariant.
+func (t StoryAreaType) AsUniqueGift() (*StoryAreaTypeUniqueGiftVariant, error) {
+	if err := expectUnionType("StoryAreaType", t.Type, StoryAreaTypeUniqueGift); err != nil {
+		return nil, err
+	}
+	return &StoryAreaTypeUniqueGiftVariant{
+		Type: t.Type,
+		Name: valueOrZero(t.Name),
+	}, nil
+}
+
+func (t StoryAreaType) checkUnionType() error {
+	return knownUnionType("StoryAreaType", t.Type,
+		StoryAreaTypeLocation,
+		StoryAreaTypeSuggestedReaction,
+		StoryAreaTypeLink,
+		StoryAreaTypeWeather,
+		StoryAreaTypeUniqueGift,
+	)
+}
+
+////////////////////////////////
+// ChatBoostSource
+//
+
+// ChatBoostSource sources
+const (
+	ChatBoostSourceTypePremium  = "premium"
+	ChatBoostSourceTypeGiftCode = "gift_code"
+	ChatBoostSourceTypeGiveaway = "giveaway"
+)
+
+// ChatBoostSourcePremium is a struct of a boost obtained by subscribing to Telegram Premium.
+//
+// https://core.telegram.org/bots/api#chatboostsourcepremium
+type ChatBoostSourcePremium struct {
+	Source string `json:"source"` // == "premium"
+	User   User   `json:"user"`
+}
+
+// ChatBoostSourceGiftCode is a struct of a boost obtained by the creation of Telegram Premium gift codes.
+//
+// https://core.telegram.org/bots/api#chatboostsourcegiftcode
+type ChatBoostSourceGiftCode struct {
+	Source string `json:"source"` // == "gift_code"
+	User   User   `json:"user"`
+}
+
+// ChatBoostSourceGiveaway is a struct of a boost obtained by the creation of a giveaway.
+//
+// https://core.telegram.org/bots/api#chatboostsourcegiveaway
+type ChatBoostSourceGiveaway struct {
+	Source            string `json:"source"` // == "giveaway"
+	GiveawayMessageID int64  `json:"giveaway_message_id"`
+	User              *User  `json:"user,omitempty"`
+	PrizeStarCount    *int   `json:"prize_star_count,omitempty"`
+	IsUnclaimed       *bool  `json:"is_unclaimed,omitempty"`
+}
+
+// AsPremium returns the source as ChatBoostSourcePremium.
+func (s ChatBoostSource) AsPremium() (*ChatBoostSourcePremium, error) {
+	if err := expectUnionType("ChatBoostSource", s.Source, ChatBoostSourceTypePremium); err != nil {
+		return nil, err
+	}
+	return &ChatBoostSourcePremium{
+		Source: s.Source,
+		User:   valueOrZero(s.User),
+	}, nil
+}
+
+// AsGiftCode returns the source as ChatBoostSourceGiftCode.
+func (s ChatBoostSource) AsGiftCode() (*ChatBoostSourceGiftCode, error) {
+	if err := expectUnionType("ChatBoostSource", s.Source, ChatBoostSourceTypeGiftCode); err != nil {
+		return nil, err
+	}
+	return &ChatBoostSourceGiftCode{
+		Source: s.Source,
+		User:   valueOrZero(s.User),
+	}, nil
+}
+
+// AsGiveaway returns the source as ChatBoostSourceGiveaway.
+func (s ChatBoostSource) AsGiveaway() (*ChatBoostSourceGiveaway, error) {
+	if err := expectUnionType("ChatBoostSource", s.Source, ChatBoostSourceTypeGiveaway); err != nil {
+		return nil, err
+	}
+	return &ChatBoostSourceGiveaway{
+		Source:            s.Source,
+		GiveawayMessageID: valueOrZero(s.GiveawayMessageID),
+		User:              s.User,
+		PrizeStarCount:    s.PrizeStarCount,
+		IsUnclaimed:       s.IsUnclaimed,
+	}, nil
+}
+
+func (s ChatBoostSource) checkUnionType() error {
+	return knownUnionType("ChatBoostSource", s.Source, ChatBoostSourceTypePremium, ChatBoostSourceTypeGiftCode, ChatBoostSourceTypeGiveaway)
+}
+
+////////////////////////////////
+// other unions
+//
+
+func (m PaidMediaUnknown) checkUnionType() error {
+	return knownUnionType[string]("PaidMedia", m.Type)
+}
+
+func (m ChatMember) checkUnionType() error {
+	if newChatMemberOfStatus(m.Status) == nil {
+		return knownUnionType[ChatMemberStatus]("ChatMember", m.Status)
+	}
+	return nil
+}
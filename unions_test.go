@@ -0,0 +1,103 @@
+// unions_test.go
+//
+// offline tests of typed accessors of flat unions
+
+package telegrambot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// flat unions should be accessed as their concrete variants, and fail for other types.
+func TestUnionAccessors(t *testing.T) {
+	slog.Info("testing accessors of flat unions...")
+
+	var origin MessageOrigin
+	if err := json.Unmarshal([]byte(`{"type":"channel","date":1,"chat":{"id":-100123,"type":"channel"},"message_id":42,"author_signature":"editor"}`), &origin); err != nil {
+		t.Fatalf("failed to unmarshal message origin: %s", err)
+	}
+	if channel, err := origin.AsChannel(); err != nil {
+		t.Errorf("failed to access message origin as channel: %s", err)
+	} else if channel.Chat.ID != -100123 || channel.MessageID != 42 || channel.AuthorSignature == nil || *channel.AuthorSignature != "editor" {
+		t.Errorf("unexpected channel origin: %+v", channel)
+	}
+	if _, err := origin.AsUser(); !errors.Is(err, ErrUnexpectedUnionType) {
+		t.Errorf("expected an unexpected type error, got: %v", err)
+	}
+
+	var partner TransactionPartner
+	if err := json.Unmarshal([]byte(`{"type":"fragment","withdrawal_state":{"type":"succeeded","date":2,"url":"https://fragment.com"}}`), &partner); err != nil {
+		t.Fatalf("failed to unmarshal transaction partner: %s", err)
+	}
+	if fragment, err := partner.AsFragment(); err != nil {
+		t.Errorf("failed to access transaction partner as fragment: %s", err)
+	} else if fragment.WithdrawalState == nil {
+		t.Errorf("no withdrawal state in fragment: %+v", fragment)
+	} else if succeeded, err := fragment.WithdrawalState.AsSucceeded(); err != nil {
+		t.Errorf("failed to access withdrawal state as succeeded: %s", err)
+	} else if succeeded.Date != 2 || succeeded.URL != "https://fragment.com" {
+		t.Errorf("unexpected succeeded withdrawal state: %+v", succeeded)
+	}
+
+	var fill BackgroundFill
+	if err := json.Unmarshal([]byte(`{"type":"freeform_gradient","colors":[1,2,3]}`), &fill); err != nil {
+		t.Fatalf("failed to unmarshal background fill: %s", err)
+	}
+	if gradient, err := fill.AsFreeformGradient(); err != nil {
+		t.Errorf("failed to access background fill as freeform gradient: %s", err)
+	} else if len(gradient.Colors) != 3 {
+		t.Errorf("unexpected freeform gradient: %+v", gradient)
+	}
+
+	source := NewChatBoostSourceGiveaway(7, nil, false)
+	if giveaway, err := source.AsGiveaway(); err != nil {
+		t.Errorf("failed to access chat boost source as giveaway: %s", err)
+	} else if giveaway.GiveawayMessageID != 7 {
+		t.Errorf("unexpected giveaway source: %+v", giveaway)
+	}
+}
+
+// unknown types of flat unions should be reported, and updates with them should not be dispatched in strict mode.
+func TestStrictUnions(t *testing.T) {
+	slog.Info("testing strict mode of flat unions...")
+
+	var update Update
+	if err := json.Unmarshal([]byte(`{"update_id":1,"message":{"message_id":1,"date":1,"chat":{"id":12345,"type":"private"},"text":"hi","forward_origin":{"type":"bot","date":1}}}`), &update); err != nil {
+		t.Fatalf("failed to unmarshal update: %s", err)
+	}
+	if err := CheckUnionTypes(update); !errors.Is(err, ErrUnknownUnionType) {
+		t.Errorf("expected an unknown type error, got: %v", err)
+	}
+	if err := CheckUnionTypes(telegrambottest.NewUpdate().Message().Text("hi").Build()); err != nil {
+		t.Errorf("failed to check known types: %s", err)
+	}
+
+	var mu sync.Mutex
+	var handled []error
+	client := NewClient(TestToken)
+	client.SetUpdateHandler(func(b *Bot, update Update, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		handled = append(handled, err)
+	})
+	client.SetMessageHandler(func(b *Bot, update Update, message Message, edited bool) {
+		t.Errorf("update with an unknown type should not be dispatched")
+	})
+	client.SetStrictUnions(true)
+	client.DispatchUpdates([]Update{update})
+	client.WaitForHandlers()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 1 || !errors.Is(handled[0], ErrUnknownUnionType) {
+		t.Errorf("expected an unknown type error in the update handler, got: %v", handled)
+	}
+}
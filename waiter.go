@@ -0,0 +1,215 @@
+package telegrambot
+
+// Waiting for replies of users in handlers
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// waiter is a goroutine waiting for a matching update
+type waiter struct {
+	match func(update Update) bool
+	ch    chan Update
+}
+
+// waiterRegistry is a registry of waiters
+type waiterRegistry struct {
+	mu      sync.Mutex
+	waiters []*waiter
+}
+
+// add a waiter
+func (r *waiterRegistry) add(w *waiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.waiters = append(r.waiters, w)
+}
+
+// remove a waiter
+func (r *waiterRegistry) remove(w *waiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, candidate := range r.waiters {
+		if candidate == w {
+			r.waiters = append(r.waiters[:i], r.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// deliver given update to the first matching waiter, and remove it (returns true if delivered)
+func (r *waiterRegistry) deliver(update Update) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, w := range r.waiters {
+		if w.match(update) {
+			r.waiters = append(r.waiters[:i], r.waiters[i+1:]...)
+			w.ch <- update // (buffered, never blocks)
+			return true
+		}
+	}
+	return false
+}
+
+// checks if given update is waited by any goroutine and deliver it (returns true if delivered)
+func handleUpdateAsWaited(b *Bot, update Update) bool {
+	return b.waiters.deliver(update)
+}
+
+// WaitForUpdate blocks until an update which matches `match` arrives, or `ctx` is done.
+//
+// Matching updates are delivered to the waiting goroutine instead of other handlers.
+//
+// When called in a handler, its worker slot is released while waiting,
+// so that waiting handlers do not block dispatching of other updates (including the awaited one).
+func (b *Bot) WaitForUpdate(ctx context.Context, match func(update Update) bool) (update Update, err error) {
+	w := &waiter{
+		match: match,
+		ch:    make(chan Update, 1),
+	}
+	b.waiters.add(w)
+
+	// release the worker slot of the calling handler while waiting
+	if b.inHandler() {
+		<-b.workerSem
+		defer func() { b.workerSem <- struct{}{} }()
+	}
+
+	select {
+	case update = <-w.ch:
+		return update, nil
+	case <-ctx.Done():
+		b.waiters.remove(w)
+
+		// (delivered while being removed)
+		select {
+		case update = <-w.ch:
+			return update, nil
+		default:
+		}
+		return update, ctx.Err()
+	}
+}
+
+// check if the current goroutine is running a handler
+//
+// NOTE: handlers are not given contexts, so they are told apart by their goroutines.
+func (b *Bot) inHandler() bool {
+	_, exists := b.handlerGoroutines.Load(currentGoroutineID())
+	return exists
+}
+
+// id of the current goroutine (parsed from "goroutine 123 [running]: ...")
+func currentGoroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	fields := bytes.Fields(buf[:n])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return id
+}
+
+// ReplyFilter is a function for filtering replies of WaitForReply.
+type ReplyFilter func(message Message) bool
+
+// FilterText accepts replies with texts.
+func FilterText(message Message) bool {
+	return message.HasText()
+}
+
+// FilterNotCommand accepts replies which are not commands.
+func FilterNotCommand(message Message) bool {
+	return !message.HasText() || !strings.HasPrefix(*message.Text, "/")
+}
+
+// FilterContact accepts replies with contacts.
+func FilterContact(message Message) bool {
+	return message.HasContact()
+}
+
+// FilterLocation accepts replies with locations.
+func FilterLocation(message Message) bool {
+	return message.HasLocation()
+}
+
+// FilterPhoto accepts replies with photos.
+func FilterPhoto(message Message) bool {
+	return message.HasPhoto()
+}
+
+// FilterDocument accepts replies with documents.
+func FilterDocument(message Message) bool {
+	return message.HasDocument()
+}
+
+// WaitForReply blocks until the user sends a message (which passes all filters) in the chat, or `ctx` is done.
+//
+//	b.AddCommandHandler("/register", func(b *Bot, update Update, args string) {
+//		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//		defer cancel()
+//
+//		chatID, userID := update.Message.Chat.ID, update.Message.From.ID
+//		_, _ = b.SendMessage(ctx, chatID, "Send me your phone number.", nil)
+//		reply, err := b.WaitForReply(ctx, chatID, userID, FilterContact)
+//		...
+//	})
+func (b *Bot) WaitForReply(ctx context.Context, chatID, userID int64, filters ...ReplyFilter) (message Message, err error) {
+	update, err := b.WaitForUpdate(ctx, func(update Update) bool {
+		var m *Message
+		if update.HasMessage() {
+			m = update.Message
+		} else if update.BusinessMessage != nil {
+			m = update.BusinessMessage
+		} else {
+			return false
+		}
+		if m.Chat.ID != chatID || m.From == nil || m.From.ID != userID {
+			return false
+		}
+		for _, filter := range filters {
+			if !filter(*m) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return message, err
+	}
+
+	if update.HasMessage() {
+		return *update.Message, nil
+	}
+	return *update.BusinessMessage, nil
+}
+
+// WaitForCallback blocks until a callback query on given message arrives, or `ctx` is done.
+//
+// If `userID` is not 0, only callback queries from the user are accepted.
+//
+// NOTE: The returned callback query should be answered with `AnswerCallbackQuery`.
+func (b *Bot) WaitForCallback(ctx context.Context, chatID, messageID, userID int64) (query CallbackQuery, err error) {
+	update, err := b.WaitForUpdate(ctx, func(update Update) bool {
+		if !update.HasCallbackQuery() || update.CallbackQuery.Message == nil {
+			return false
+		}
+		q := update.CallbackQuery
+		return q.Message.Chat.ID == chatID &&
+			q.Message.MessageID == messageID &&
+			(userID == 0 || q.From.ID == userID)
+	})
+	if err != nil {
+		return query, err
+	}
+	return *update.CallbackQuery, nil
+}
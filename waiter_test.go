@@ -0,0 +1,139 @@
+// waiter_test.go
+//
+// offline tests of waiting for replies in handlers
+
+package telegrambot_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/meinside/telegram-bot-go"
+	"github.com/meinside/telegram-bot-go/telegrambottest"
+)
+
+// handlers should receive awaited replies without blocking other updates.
+func TestWaitForReply(t *testing.T) {
+	slog.Info("testing waiting for replies...")
+
+	client := NewClient(TestToken)
+	client.SetMaxWorkers(1) // only one worker, which will be waiting
+
+	user := telegrambottest.User()
+	chat := telegrambottest.PrivateChat(user)
+
+	replies := make(chan string, 1)
+	client.AddCommandHandler("/ask", func(b *Bot, update Update, args string) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		reply, err := b.WaitForReply(ctx, chat.ID, user.ID, FilterLocation)
+		if err != nil {
+			replies <- err.Error()
+			return
+		}
+		if reply.HasLocation() {
+			replies <- "location"
+		}
+	})
+	others := make(chan string, 2)
+	client.SetMessageHandler(func(b *Bot, update Update, message Message, edited bool) {
+		others <- *message.Text
+	})
+
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("/ask").Build()})
+	time.Sleep(50 * time.Millisecond)
+
+	// not matching the filter: handled by the message handler
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Text("hello").Build()})
+	select {
+	case text := <-others:
+		if text != "hello" {
+			t.Errorf("unexpected message: %s", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("other updates were blocked by the waiting handler")
+	}
+
+	// matching the filter: delivered to the waiting handler
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().From(user).InChat(chat).Location(37.5, 127.0).Build()})
+	if reply := <-replies; reply != "location" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	client.WaitForHandlers()
+}
+
+// waiting should end when the context is done.
+func TestWaitForCallbackTimeout(t *testing.T) {
+	slog.Info("testing timeouts of waiting for callbacks...")
+
+	client := NewClient(TestToken)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForCallback(ctx, 1, 2, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	if client.NumWaiters() != 0 {
+		t.Errorf("expected the waiter to be removed")
+	}
+
+	// a callback query on the message
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		message := telegrambottest.NewUpdate().Message().InChat(telegrambottest.GroupChat()).WithID(2).Message()
+		client.DispatchUpdates([]Update{telegrambottest.NewUpdate().CallbackQuery().Data("ok").OnMessage(message).Build()})
+	}()
+	query, err := client.WaitForCallback(context.Background(), telegrambottest.GroupChat().ID, 2, 0)
+	if err != nil || *query.Data != "ok" {
+		t.Errorf("unexpected callback query: %+v, %v", query, err)
+	}
+}
+
+// waiting outside handlers should not take slots of running handlers.
+func TestWaitForUpdateOutsideHandlers(t *testing.T) {
+	slog.Info("testing waiting for updates outside handlers...")
+
+	client := NewClient(TestToken)
+	client.SetMaxWorkers(1)
+
+	var running atomic.Int32
+	block := make(chan struct{})
+	client.SetMessageHandler(func(b *Bot, update Update, message Message, edited bool) {
+		if n := running.Add(1); n > 1 {
+			t.Errorf("expected at most 1 running handler, got %d", n)
+		}
+		defer running.Add(-1)
+		<-block
+	})
+
+	// saturate the only worker, and queue another update
+	client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().Text("first").Build()})
+	go client.DispatchUpdates([]Update{telegrambottest.NewUpdate().Message().Text("second").Build()})
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.WaitForUpdate(ctx, func(update Update) bool { return false })
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("waiting outside handlers was blocked by the saturated worker")
+	}
+
+	close(block)
+	time.Sleep(20 * time.Millisecond)
+	client.WaitForHandlers()
+}